@@ -0,0 +1,131 @@
+// Copyright 2026 LINE Corporation
+//
+// LINE Corporation licenses this file to you under the Apache License,
+// version 2.0 (the "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at:
+//
+//   https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+// WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+// License for the specific language governing permissions and limitations
+// under the License.
+
+package centraldogma
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"path"
+)
+
+const (
+	pathStatus      = "status"
+	pathHealthCheck = "monitor/l7check"
+	pathVersion     = "monitor/version"
+)
+
+type adminService service
+
+// ServerStatus represents the status of the Central Dogma server.
+type ServerStatus struct {
+	// Writable is false if the server is in read-only mode.
+	Writable bool `json:"writable"`
+	// Replicating is false if the server does not replicate the changes from or to the other servers.
+	Replicating bool `json:"replicating"`
+}
+
+// ServerVersion represents the version of the Central Dogma server.
+type ServerVersion struct {
+	Version    string `json:"version"`
+	CommitHash string `json:"commitHash,omitempty"`
+	Timestamp  string `json:"timestamp,omitempty"`
+}
+
+func (a *adminService) getStatus(ctx context.Context) (*ServerStatus, int, error) {
+	u, err := url.Parse(path.Join(
+		defaultPathPrefix,
+		pathStatus,
+	))
+	if err != nil {
+		return nil, UnknownHttpStatusCode, err
+	}
+
+	req, err := a.client.newRequest(http.MethodGet, u, nil)
+	if err != nil {
+		return nil, UnknownHttpStatusCode, err
+	}
+
+	status := new(ServerStatus)
+	httpStatusCode, err := a.client.do(ctx, req, status, false)
+	if err != nil {
+		return nil, httpStatusCode, err
+	}
+	return status, httpStatusCode, nil
+}
+
+func (a *adminService) setWritable(ctx context.Context, writable bool) (*ServerStatus, int, error) {
+	u, err := url.Parse(path.Join(
+		defaultPathPrefix,
+		pathStatus,
+	))
+	if err != nil {
+		return nil, UnknownHttpStatusCode, err
+	}
+
+	body := []map[string]interface{}{{"op": "replace", "path": "/writable", "value": writable}}
+	req, err := a.client.newRequest(http.MethodPatch, u, body)
+	if err != nil {
+		return nil, UnknownHttpStatusCode, err
+	}
+
+	status := new(ServerStatus)
+	httpStatusCode, err := a.client.do(ctx, req, status, false)
+	if err != nil {
+		return nil, httpStatusCode, err
+	}
+	return status, httpStatusCode, nil
+}
+
+func (a *adminService) checkHealth(ctx context.Context) (bool, int, error) {
+	u, err := url.Parse(pathHealthCheck)
+	if err != nil {
+		return false, UnknownHttpStatusCode, err
+	}
+
+	req, err := a.client.newRequest(http.MethodGet, u, nil)
+	if err != nil {
+		return false, UnknownHttpStatusCode, err
+	}
+
+	httpStatusCode, err := a.client.do(ctx, req, nil, false)
+	if httpStatusCode == http.StatusServiceUnavailable {
+		// The server is running but not ready to serve, e.g. it is starting up or shutting down.
+		return false, httpStatusCode, nil
+	}
+	if err != nil {
+		return false, httpStatusCode, err
+	}
+	return true, httpStatusCode, nil
+}
+
+func (a *adminService) getVersion(ctx context.Context) (*ServerVersion, int, error) {
+	u, err := url.Parse(pathVersion)
+	if err != nil {
+		return nil, UnknownHttpStatusCode, err
+	}
+
+	req, err := a.client.newRequest(http.MethodGet, u, nil)
+	if err != nil {
+		return nil, UnknownHttpStatusCode, err
+	}
+
+	version := new(ServerVersion)
+	httpStatusCode, err := a.client.do(ctx, req, version, false)
+	if err != nil {
+		return nil, httpStatusCode, err
+	}
+	return version, httpStatusCode, nil
+}
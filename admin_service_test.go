@@ -0,0 +1,102 @@
+// Copyright 2026 LINE Corporation
+//
+// LINE Corporation licenses this file to you under the Apache License,
+// version 2.0 (the "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at:
+//
+//   https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+// WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+// License for the specific language governing permissions and limitations
+// under the License.
+
+package centraldogma
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"reflect"
+	"testing"
+)
+
+func TestGetServerStatus(t *testing.T) {
+	c, mux, teardown := setup()
+	defer teardown()
+
+	mux.HandleFunc("/api/v1/status", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, http.MethodGet)
+		fmt.Fprint(w, `{"writable":true, "replicating":true}`)
+	})
+
+	status, _, err := c.GetServerStatus(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := (&ServerStatus{Writable: true, Replicating: true}); !reflect.DeepEqual(status, want) {
+		t.Errorf("GetServerStatus returned %+v, want %+v", status, want)
+	}
+}
+
+func TestSetReadOnly(t *testing.T) {
+	c, mux, teardown := setup()
+	defer teardown()
+
+	mux.HandleFunc("/api/v1/status", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, http.MethodPatch)
+		testHeader(t, r, "Content-Type", "application/json-patch+json")
+		testBody(t, r, `[{"op":"replace","path":"/writable","value":false}]`+"\n")
+		fmt.Fprint(w, `{"writable":false, "replicating":true}`)
+	})
+
+	status, _, err := c.SetReadOnly(context.Background(), true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := (&ServerStatus{Writable: false, Replicating: true}); !reflect.DeepEqual(status, want) {
+		t.Errorf("SetReadOnly returned %+v, want %+v", status, want)
+	}
+}
+
+func TestCheckHealth(t *testing.T) {
+	c, mux, teardown := setup()
+	defer teardown()
+
+	healthy := true
+	mux.HandleFunc("/monitor/l7check", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, http.MethodGet)
+		if !healthy {
+			w.WriteHeader(http.StatusServiceUnavailable)
+		}
+	})
+
+	if got, _, err := c.CheckHealth(context.Background()); err != nil || !got {
+		t.Errorf("CheckHealth returned %v, %v, want true", got, err)
+	}
+	healthy = false
+	got, httpStatusCode, err := c.CheckHealth(context.Background())
+	if err != nil || got {
+		t.Errorf("CheckHealth returned %v, %v, want false", got, err)
+	}
+	testStatusCode(t, httpStatusCode, 503)
+}
+
+func TestGetServerVersion(t *testing.T) {
+	c, mux, teardown := setup()
+	defer teardown()
+
+	mux.HandleFunc("/monitor/version", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, http.MethodGet)
+		fmt.Fprint(w, `{"version":"0.60.0", "commitHash":"abcdef0"}`)
+	})
+
+	version, _, err := c.GetServerVersion(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := (&ServerVersion{Version: "0.60.0", CommitHash: "abcdef0"}); !reflect.DeepEqual(version, want) {
+		t.Errorf("GetServerVersion returned %+v, want %+v", version, want)
+	}
+}
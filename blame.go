@@ -0,0 +1,326 @@
+// Copyright 2026 LINE Corporation
+//
+// LINE Corporation licenses this file to you under the Apache License,
+// version 2.0 (the "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at:
+//
+//   https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+// WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+// License for the specific language governing permissions and limitations
+// under the License.
+
+package centraldogma
+
+import (
+	"container/list"
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+
+	"go.linecorp.com/centraldogma/jsonpatch"
+	"go.linecorp.com/centraldogma/textdiff"
+)
+
+const (
+	// maxBlameCommits is the maximum number of commits which Blame walks through.
+	maxBlameCommits = 1000
+
+	defaultDiffCacheSize = 1024
+)
+
+// Blame represents which commit last modified each line of a text file or each value of a JSON file.
+type Blame struct {
+	Path     string
+	Revision int
+	Type     EntryType
+	Lines    []*BlameLine  // the lines of a text file
+	Values   []*BlameValue // the values of a JSON file, sorted by their JSON pointers
+}
+
+// BlameLine represents a line of a text file and the commit which last modified it.
+type BlameLine struct {
+	Number int // 1-based line number
+	Text   string
+	// Commit is the commit which last modified the line. It is nil if the commit is older than
+	// the commits which Blame walked through.
+	Commit *Commit
+}
+
+// BlameValue represents a value of a JSON file and the commit which last modified it. A value is a string,
+// a number, a boolean, null or an empty array or object.
+type BlameValue struct {
+	Pointer string // the JSON pointer of the value
+	Value   interface{}
+	// Commit is the commit which last modified the value. It is nil if the commit is older than
+	// the commits which Blame walked through.
+	Commit *Commit
+}
+
+// blameTarget is a line or a value to blame.
+type blameTarget struct {
+	// the line number or the JSON pointer of the target in the revision being walked through
+	line    int
+	pointer string
+	commit  **Commit
+}
+
+func (con *contentService) blame(ctx context.Context,
+	projectName, repoName, path, revision string) (*Blame, int, error) {
+	rev, httpStatusCode, err := con.client.repository.absoluteRevision(ctx, projectName, repoName, revision)
+	if err != nil {
+		return nil, httpStatusCode, err
+	}
+	entry, httpStatusCode, err := con.getFile(
+		ctx, projectName, repoName, strconv.Itoa(rev), &Query{Path: path, Type: Identity})
+	if err != nil {
+		return nil, httpStatusCode, err
+	}
+
+	blame := &Blame{Path: entry.Path, Revision: rev, Type: entry.Type}
+	var targets []*blameTarget
+	switch entry.Type {
+	case JSON:
+		var value interface{}
+		if err := json.Unmarshal(entry.Content, &value); err != nil {
+			return nil, httpStatusCode, err
+		}
+		collectJSONValues("", value, func(pointer string, value interface{}) {
+			v := &BlameValue{Pointer: pointer, Value: value}
+			blame.Values = append(blame.Values, v)
+			targets = append(targets, &blameTarget{pointer: pointer, commit: &v.Commit})
+		})
+	case Text:
+		content := string(entry.Content)
+		if len(content) != 0 {
+			for i, text := range strings.Split(strings.TrimSuffix(content, "\n"), "\n") {
+				line := &BlameLine{Number: i + 1, Text: text}
+				blame.Lines = append(blame.Lines, line)
+				targets = append(targets, &blameTarget{line: i + 1, commit: &line.Commit})
+			}
+		}
+	default:
+		return nil, httpStatusCode, fmt.Errorf("cannot blame %v: the type of the entry is %v", path, entry.Type)
+	}
+
+	it := con.iterateHistory(ctx, projectName, repoName, &HistoryOptions{
+		From: Revision(rev), To: InitRevision, PathPattern: entry.Path})
+	for numCommits := 0; len(targets) != 0 && numCommits < maxBlameCommits && it.Next(); numCommits++ {
+		commit := it.Commit()
+		if commit.Revision <= 1 {
+			break
+		}
+		change, httpStatusCode, err := con.getCachedDiff(ctx, projectName, repoName,
+			commit.Revision-1, commit.Revision, entry.Path)
+		if err != nil {
+			return nil, httpStatusCode, err
+		}
+		if targets, err = blameChange(targets, change, commit); err != nil {
+			return nil, UnknownHttpStatusCode, err
+		}
+	}
+	if err := it.Err(); err != nil {
+		return nil, UnknownHttpStatusCode, err
+	}
+	return blame, httpStatusCode, nil
+}
+
+// blameChange attributes the targets modified by the change to the commit. It returns the rest of the targets
+// whose line numbers or JSON pointers are translated into the ones before the change.
+func blameChange(targets []*blameTarget, change *Change, commit *Commit) ([]*blameTarget, error) {
+	switch change.Type {
+	case UpsertJSON, UpsertText:
+		// The file was created or overwritten.
+		for _, target := range targets {
+			*target.commit = commit
+		}
+		return nil, nil
+	case ApplyTextPatch:
+		patch, err := textdiff.Parse(fmt.Sprint(change.Content))
+		if err != nil {
+			return nil, err
+		}
+		var rest []*blameTarget
+		for _, target := range targets {
+			oldLine, ok := patch.OldLine(target.line)
+			if !ok {
+				*target.commit = commit
+				continue
+			}
+			target.line = oldLine
+			rest = append(rest, target)
+		}
+		return rest, nil
+	case ApplyJSONPatch:
+		patch, err := change.JSONPatch()
+		if err != nil {
+			return nil, err
+		}
+		// Undo the operations in the reverse order.
+		for i := len(patch) - 1; i >= 0; i-- {
+			targets = blameOperation(targets, patch[i], commit)
+		}
+		return targets, nil
+	}
+	return targets, nil
+}
+
+func blameOperation(targets []*blameTarget, op jsonpatch.Operation, commit *Commit) []*blameTarget {
+	var rest []*blameTarget
+	switch op.Op {
+	case jsonpatch.Test, jsonpatch.TestAbsence:
+		return targets
+	case jsonpatch.Remove, jsonpatch.RemoveIfExists:
+		// The elements after a removed array element were shifted.
+		for _, target := range targets {
+			target.pointer = shiftArrayIndex(target.pointer, op.Path, 1)
+		}
+		return targets
+	}
+
+	for _, target := range targets {
+		if isPointerUnder(target.pointer, op.Path) {
+			*target.commit = commit
+			continue
+		}
+		if op.Op == jsonpatch.Add || op.Op == jsonpatch.Copy || op.Op == jsonpatch.Move {
+			// The elements after an added array element were shifted.
+			target.pointer = shiftArrayIndex(target.pointer, op.Path, -1)
+		}
+		rest = append(rest, target)
+	}
+	if op.Op == jsonpatch.Move {
+		for _, target := range rest {
+			target.pointer = shiftArrayIndex(target.pointer, op.From, 1)
+		}
+	}
+	return rest
+}
+
+// isPointerUnder returns whether the JSON pointer is the parent pointer or a descendant of it.
+func isPointerUnder(pointer, parent string) bool {
+	return parent == "" || pointer == parent || strings.HasPrefix(pointer, parent+"/")
+}
+
+// shiftArrayIndex adds delta to the array index in the pointer if the pointer is an element after the element
+// which arrayElement points to, or a descendant of such an element.
+func shiftArrayIndex(pointer, arrayElement string, delta int) string {
+	slash := strings.LastIndex(arrayElement, "/")
+	if slash < 0 {
+		return pointer
+	}
+	array, index := arrayElement[:slash], arrayElement[slash+1:]
+	i, err := strconv.Atoi(index)
+	if err != nil || !strings.HasPrefix(pointer, array+"/") {
+		return pointer
+	}
+	tokens := strings.SplitN(pointer[len(array)+1:], "/", 2)
+	j, err := strconv.Atoi(tokens[0])
+	if err != nil || j < i || (delta < 0 && j == i) {
+		return pointer
+	}
+	shifted := array + "/" + strconv.Itoa(j+delta)
+	if len(tokens) == 2 {
+		shifted += "/" + tokens[1]
+	}
+	return shifted
+}
+
+// collectJSONValues calls the function with the JSON pointer of every leaf value in the document.
+func collectJSONValues(pointer string, value interface{}, f func(pointer string, value interface{})) {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		if len(v) == 0 {
+			f(pointer, v)
+			return
+		}
+		keys := make([]string, 0, len(v))
+		for k := range v {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+		for _, k := range keys {
+			collectJSONValues(pointer+"/"+escapePointerToken(k), v[k], f)
+		}
+	case []interface{}:
+		if len(v) == 0 {
+			f(pointer, v)
+			return
+		}
+		for i, e := range v {
+			collectJSONValues(pointer+"/"+strconv.Itoa(i), e, f)
+		}
+	default:
+		f(pointer, v)
+	}
+}
+
+func escapePointerToken(token string) string {
+	return strings.ReplaceAll(strings.ReplaceAll(token, "~", "~0"), "/", "~1")
+}
+
+// getCachedDiff returns the diff of the file between the two absolute revisions, which never changes.
+func (con *contentService) getCachedDiff(ctx context.Context,
+	projectName, repoName string, from, to int, path string) (*Change, int, error) {
+	key := fmt.Sprintf("%s/%s/%d/%d%s", projectName, repoName, from, to, path)
+	if change, ok := con.client.diffCache.get(key); ok {
+		return change, UnknownHttpStatusCode, nil
+	}
+	change, httpStatusCode, err := con.getDiff(ctx, projectName, repoName,
+		strconv.Itoa(from), strconv.Itoa(to), &Query{Path: path, Type: Identity})
+	if err != nil {
+		return nil, httpStatusCode, err
+	}
+	con.client.diffCache.put(key, change)
+	return change, httpStatusCode, nil
+}
+
+// diffCache is an LRU cache of the diffs between absolute revisions.
+type diffCache struct {
+	mu      sync.Mutex
+	maxSize int
+	entries map[string]*list.Element
+	order   *list.List // the most recently used entry is at the front
+}
+
+type diffCacheEntry struct {
+	key    string
+	change *Change
+}
+
+func newDiffCache(maxSize int) *diffCache {
+	return &diffCache{maxSize: maxSize, entries: make(map[string]*list.Element), order: list.New()}
+}
+
+func (dc *diffCache) get(key string) (*Change, bool) {
+	dc.mu.Lock()
+	defer dc.mu.Unlock()
+	elem, ok := dc.entries[key]
+	if !ok {
+		return nil, false
+	}
+	dc.order.MoveToFront(elem)
+	return elem.Value.(*diffCacheEntry).change, true
+}
+
+func (dc *diffCache) put(key string, change *Change) {
+	dc.mu.Lock()
+	defer dc.mu.Unlock()
+	if elem, ok := dc.entries[key]; ok {
+		elem.Value.(*diffCacheEntry).change = change
+		dc.order.MoveToFront(elem)
+		return
+	}
+	dc.entries[key] = dc.order.PushFront(&diffCacheEntry{key: key, change: change})
+	if dc.order.Len() > dc.maxSize {
+		oldest := dc.order.Back()
+		dc.order.Remove(oldest)
+		delete(dc.entries, oldest.Value.(*diffCacheEntry).key)
+	}
+}
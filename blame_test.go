@@ -0,0 +1,136 @@
+// Copyright 2026 LINE Corporation
+//
+// LINE Corporation licenses this file to you under the Apache License,
+// version 2.0 (the "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at:
+//
+//   https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+// WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+// License for the specific language governing permissions and limitations
+// under the License.
+
+package centraldogma
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"reflect"
+	"testing"
+)
+
+// handleBlameRepository serves the history of a file whose diffs at the revision 2, 3 and 4 are given.
+// It returns the number of the diff requests.
+func handleBlameRepository(t *testing.T, mux *http.ServeMux, path, content string, diffs map[string]string) *int {
+	var numDiffRequests int
+	mux.HandleFunc("/api/v1/projects/foo/repos/bar/revision/-1", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"revision":4}`)
+	})
+	mux.HandleFunc("/api/v1/projects/foo/repos/bar/contents"+path, func(w http.ResponseWriter, r *http.Request) {
+		testURLQuery(t, r, "revision", "4")
+		fmt.Fprint(w, content)
+	})
+	mux.HandleFunc("/api/v1/projects/foo/repos/bar/commits/4", func(w http.ResponseWriter, r *http.Request) {
+		testURLQuery(t, r, "to", "1")
+		testURLQuery(t, r, "path", path)
+		fmt.Fprint(w, `[{"revision":4, "author":{"name":"carol"}}, {"revision":3, "author":{"name":"bob"}},
+{"revision":2, "author":{"name":"alice"}}]`)
+	})
+	mux.HandleFunc("/api/v1/projects/foo/repos/bar/compare", func(w http.ResponseWriter, r *http.Request) {
+		testURLQuery(t, r, "path", path)
+		numDiffRequests++
+		fmt.Fprint(w, diffs[r.URL.Query().Get("to")])
+	})
+	return &numDiffRequests
+}
+
+func TestBlame_Text(t *testing.T) {
+	c, mux, teardown := setup()
+	defer teardown()
+
+	numDiffRequests := handleBlameRepository(t, mux, "/a.txt",
+		`{"path":"/a.txt", "type":"TEXT", "content":"x\na\nB\nc\n"}`,
+		map[string]string{
+			"2": `{"path":"/a.txt", "type":"UPSERT_TEXT", "content":"a\nb\nc\n"}`,
+			"3": `{"path":"/a.txt", "type":"APPLY_TEXT_PATCH",
+"content":"--- /a.txt\n+++ /a.txt\n@@ -1,3 +1,3 @@\n a\n-b\n+B\n c\n"}`,
+			"4": `{"path":"/a.txt", "type":"APPLY_TEXT_PATCH", "content":"--- /a.txt\n+++ /a.txt\n@@ -0,0 +1,1 @@\n+x\n"}`,
+		})
+
+	alice := &Commit{Revision: 2, Author: Author{Name: "alice"}}
+	bob := &Commit{Revision: 3, Author: Author{Name: "bob"}}
+	carol := &Commit{Revision: 4, Author: Author{Name: "carol"}}
+	want := &Blame{Path: "/a.txt", Revision: 4, Type: Text, Lines: []*BlameLine{
+		{Number: 1, Text: "x", Commit: carol},
+		{Number: 2, Text: "a", Commit: alice},
+		{Number: 3, Text: "B", Commit: bob},
+		{Number: 4, Text: "c", Commit: alice},
+	}}
+
+	for i := 0; i < 2; i++ {
+		blame, _, err := c.Blame(context.Background(), "foo", "bar", "/a.txt", Head())
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !reflect.DeepEqual(blame, want) {
+			t.Errorf("Blame returned %+v, want %+v", blame, want)
+		}
+	}
+	// The diffs are fetched only once.
+	if *numDiffRequests != 3 {
+		t.Errorf("Blame fetched diffs %v times, want %v", *numDiffRequests, 3)
+	}
+}
+
+func TestBlame_JSON(t *testing.T) {
+	c, mux, teardown := setup()
+	defer teardown()
+
+	handleBlameRepository(t, mux, "/a.json",
+		`{"path":"/a.json", "type":"JSON", "content":{"a":2, "b":[0, 1, 2], "c":{}}}`,
+		map[string]string{
+			"2": `{"path":"/a.json", "type":"UPSERT_JSON", "content":{"a":1, "b":[1, 2], "c":{}}}`,
+			"3": `{"path":"/a.json", "type":"APPLY_JSON_PATCH",
+"content":[{"op":"safeReplace", "path":"/a", "oldValue":1, "value":2}]}`,
+			"4": `{"path":"/a.json", "type":"APPLY_JSON_PATCH", "content":[{"op":"add", "path":"/b/0", "value":0}]}`,
+		})
+
+	blame, _, err := c.Blame(context.Background(), "foo", "bar", "/a.json", Head())
+	if err != nil {
+		t.Fatal(err)
+	}
+	var got []string
+	for _, v := range blame.Values {
+		got = append(got, fmt.Sprintf("%s=%v@%d", v.Pointer, v.Value, v.Commit.Revision))
+	}
+	want := []string{"/a=2@3", "/b/0=0@4", "/b/1=1@2", "/b/2=2@2", "/c=map[]@2"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Blame returned %v, want %v", got, want)
+	}
+}
+
+func TestShiftArrayIndex(t *testing.T) {
+	var tests = []struct {
+		pointer      string
+		arrayElement string
+		delta        int
+		want         string
+	}{
+		{"/a/3/b", "/a/1", -1, "/a/2/b"},
+		{"/a/1", "/a/1", -1, "/a/1"},
+		{"/a/1", "/a/1", 1, "/a/2"},
+		{"/a/0", "/a/1", 1, "/a/0"},
+		{"/b/3", "/a/1", 1, "/b/3"},
+		{"/a/x", "/a/1", 1, "/a/x"},
+		{"/a/3", "/a/key", 1, "/a/3"},
+	}
+	for _, test := range tests {
+		if got := shiftArrayIndex(test.pointer, test.arrayElement, test.delta); got != test.want {
+			t.Errorf("shiftArrayIndex(%q, %q, %d) = %q, want %q",
+				test.pointer, test.arrayElement, test.delta, got, test.want)
+		}
+	}
+}
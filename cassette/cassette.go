@@ -0,0 +1,133 @@
+// Copyright 2026 LINE Corporation
+//
+// LINE Corporation licenses this file to you under the Apache License,
+// version 2.0 (the "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at:
+//
+//   https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+// WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+// License for the specific language governing permissions and limitations
+// under the License.
+
+// Package cassette records the interactions between a centraldogma.Client and a server to a cassette file,
+// and replays them without a server. Both the Recorder and the Replayer are http.RoundTrippers which are
+// passed to centraldogma.NewClientWithToken. For example, record the interactions with a staging server:
+//
+//	transport, _ := centraldogma.DefaultHTTP2Transport("https://staging.example.com")
+//	recorder := cassette.NewRecorder("testdata/foo.json", transport)
+//	client, _ := centraldogma.NewClientWithToken("https://staging.example.com", token, recorder)
+//	runTest(client)
+//	if err := recorder.Save(); err != nil {
+//	    panic(err)
+//	}
+//
+// and replay them in CI:
+//
+//	replayer, err := cassette.NewReplayer("testdata/foo.json")
+//	client, _ := centraldogma.NewClientWithToken("https://staging.example.com", "anonymous", replayer)
+//	runTest(client)
+//
+// The tokens in the Authorization headers and the secrets in the bodies, such as the secrets of
+// the application tokens and the passwords of the mirror credentials, are redacted.
+// A watch request, which has an If-None-Match header, is matched with the last known revision in the header
+// as well. The watch requests which timed out are not recorded; instead, the Replayer answers a watch request
+// which matches no interaction with 304 Not Modified at once, like a server does when the wait time in its
+// Prefer header elapses.
+package cassette
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"regexp"
+)
+
+const redacted = "REDACTED"
+
+// redactedHeaders are the headers whose values are redacted.
+var redactedHeaders = []string{"Authorization", "Cookie", "Set-Cookie"}
+
+// secretPattern matches the secrets in a JSON body, i.e. the secret of an application token and
+// the secrets of a mirror credential.
+var secretPattern = regexp.MustCompile(
+	`("(?:secret|password|privateKey|passphrase|accessToken)"\s*:\s*)"(?:[^"\\]|\\.)*"`)
+
+// Cassette is the content of a cassette file.
+type Cassette struct {
+	Interactions []*Interaction `json:"interactions"`
+}
+
+// Interaction is a pair of a request and its response.
+type Interaction struct {
+	Request  Request  `json:"request"`
+	Response Response `json:"response"`
+}
+
+// Request is a recorded request. The URL does not have the scheme and the host of the server, so that
+// the interactions can be replayed with any base URL.
+type Request struct {
+	Method string      `json:"method"`
+	URL    string      `json:"url"`
+	Header http.Header `json:"header,omitempty"`
+	Body   string      `json:"body,omitempty"`
+}
+
+// Response is a recorded response.
+type Response struct {
+	StatusCode int         `json:"statusCode"`
+	Header     http.Header `json:"header,omitempty"`
+	Body       string      `json:"body,omitempty"`
+}
+
+// Load reads the cassette file.
+func Load(path string) (*Cassette, error) {
+	b, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	cassette := new(Cassette)
+	if err := json.Unmarshal(b, cassette); err != nil {
+		return nil, err
+	}
+	return cassette, nil
+}
+
+// Save writes the cassette to the file.
+func (c *Cassette) Save(path string) error {
+	b, err := json.MarshalIndent(c, "", "  ")
+	if err != nil {
+		return err
+	}
+	return ioutil.WriteFile(path, append(b, '\n'), 0644)
+}
+
+// requestURL returns the path and the query of the URL. The query is kept as it is because the order of
+// the parameters matters for some APIs, e.g. the paths of the merge API.
+func requestURL(u *url.URL) string {
+	if len(u.RawQuery) == 0 {
+		return u.EscapedPath()
+	}
+	return u.EscapedPath() + "?" + u.RawQuery
+}
+
+func isWatchRequest(header http.Header) bool {
+	return len(header.Get("If-None-Match")) != 0
+}
+
+func redactHeader(header http.Header) http.Header {
+	redactedHeader := header.Clone()
+	for _, name := range redactedHeaders {
+		if len(redactedHeader.Values(name)) != 0 {
+			redactedHeader.Set(name, redacted)
+		}
+	}
+	return redactedHeader
+}
+
+func redactBody(body string) string {
+	return secretPattern.ReplaceAllString(body, `$1"`+redacted+`"`)
+}
@@ -0,0 +1,194 @@
+// Copyright 2026 LINE Corporation
+//
+// LINE Corporation licenses this file to you under the Apache License,
+// version 2.0 (the "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at:
+//
+//   https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+// WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+// License for the specific language governing permissions and limitations
+// under the License.
+
+package cassette_test
+
+import (
+	"context"
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"go.linecorp.com/centraldogma"
+	"go.linecorp.com/centraldogma/cassette"
+	"go.linecorp.com/centraldogma/dogmatest"
+)
+
+// exercise gets a file, pushes a change and watches the file with the client.
+func exercise(t *testing.T, client *centraldogma.Client) {
+	ctx := context.Background()
+	query := &centraldogma.Query{Path: "/a.json", Type: centraldogma.Identity}
+
+	entry, _, err := client.GetFile(ctx, "foo", "bar", "-1", query)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(entry.Content) != `{"a":1}` {
+		t.Errorf("GetFile() = %s, want {\"a\":1}", entry.Content)
+	}
+
+	changes := []*centraldogma.Change{{Path: "/a.json", Type: centraldogma.UpsertJSON, Content: `{"a":2}`}}
+	result, _, err := client.Push(ctx, "foo", "bar", "-1",
+		&centraldogma.CommitMessage{Summary: "Update a.json"}, changes)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if result.Revision != 3 {
+		t.Errorf("Push() revision = %d, want 3", result.Revision)
+	}
+
+	ch, closer, err := client.WatchFile(ctx, "foo", "bar", query, 200*time.Millisecond)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer closer()
+	select {
+	case watchResult := <-ch:
+		if watchResult.Revision != 3 || string(watchResult.Entry.Content) != `{"a":2}` {
+			t.Errorf("WatchFile() = %+v, want {\"a\":2} at the revision 3", watchResult)
+		}
+	case <-time.After(3 * time.Second):
+		t.Fatal("timed out waiting for the watch result")
+	}
+}
+
+func TestRecordAndReplay(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "cassette.json")
+
+	server := dogmatest.NewServer()
+	if _, err := server.UpsertFile("foo", "bar", "/a.json", `{"a":1}`); err != nil {
+		t.Fatal(err)
+	}
+	recorder := cassette.NewRecorder(path, nil)
+	client, err := centraldogma.NewClientWithToken(server.URL, "my-secret-token", recorder)
+	if err != nil {
+		t.Fatal(err)
+	}
+	exercise(t, client)
+	server.Close()
+	if err := recorder.Save(); err != nil {
+		t.Fatal(err)
+	}
+
+	b, err := ioutil.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if strings.Contains(string(b), "my-secret-token") {
+		t.Errorf("the cassette contains the token: %s", b)
+	}
+
+	// Replay the interactions without the server.
+	replayer, err := cassette.NewReplayer(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	client, err = centraldogma.NewClientWithToken(server.URL, "anonymous", replayer)
+	if err != nil {
+		t.Fatal(err)
+	}
+	exercise(t, client)
+	if unused := replayer.Unused(); len(unused) != 0 {
+		t.Errorf("Unused() = %d interactions, want none", len(unused))
+	}
+
+	// The interactions are used up.
+	_, _, err = client.GetFile(context.Background(), "foo", "bar", "-1",
+		&centraldogma.Query{Path: "/a.json", Type: centraldogma.Identity})
+	if !errors.Is(err, cassette.ErrNoInteraction) {
+		t.Errorf("GetFile() error = %v, want %v", err, cassette.ErrNoInteraction)
+	}
+}
+
+func TestReplayUnmatchedWatch(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "cassette.json")
+	if err := ioutil.WriteFile(path, []byte(`{"interactions":[]}`), 0644); err != nil {
+		t.Fatal(err)
+	}
+	replayer, err := cassette.NewReplayer(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	req, err := http.NewRequest(http.MethodGet, "http://localhost/api/v1/projects/foo/repos/bar/contents/a.json",
+		nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	req.Header.Set("If-None-Match", "3")
+	req.Header.Set("Prefer", "wait=60")
+
+	// The watch request is not held for the wait time.
+	start := time.Now()
+	res, err := replayer.RoundTrip(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if res.StatusCode != http.StatusNotModified {
+		t.Errorf("RoundTrip() status code = %d, want 304", res.StatusCode)
+	}
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Errorf("RoundTrip() took %v, want no wait", elapsed)
+	}
+}
+
+func TestRedaction(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "cassette.json")
+	recorder := cassette.NewRecorder(path, roundTripFunc(func(req *http.Request) (*http.Response, error) {
+		return &http.Response{
+			StatusCode: http.StatusCreated,
+			Header:     http.Header{"Content-Type": {"application/json"}},
+			Body:       ioutil.NopCloser(strings.NewReader(`{"appId":"foo","secret":"appToken-123"}`)),
+		}, nil
+	}))
+	client, err := centraldogma.NewClientWithToken("http://localhost:36462", "my-secret-token", recorder)
+	if err != nil {
+		t.Fatal(err)
+	}
+	token, _, err := client.CreateToken(context.Background(), "foo", false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if token.Secret != "appToken-123" {
+		t.Errorf("CreateToken() secret = %q, want the secret which is not redacted", token.Secret)
+	}
+	if err := recorder.Save(); err != nil {
+		t.Fatal(err)
+	}
+
+	c, err := cassette.Load(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(c.Interactions) != 1 {
+		t.Fatalf("len(Interactions) = %d, want 1", len(c.Interactions))
+	}
+	interaction := c.Interactions[0]
+	if got := interaction.Request.Header.Get("Authorization"); got != "REDACTED" {
+		t.Errorf("Authorization = %q, want REDACTED", got)
+	}
+	if want := `{"appId":"foo","secret":"REDACTED"}`; interaction.Response.Body != want {
+		t.Errorf("response body = %s, want %s", interaction.Response.Body, want)
+	}
+}
+
+type roundTripFunc func(req *http.Request) (*http.Response, error)
+
+func (f roundTripFunc) RoundTrip(req *http.Request) (*http.Response, error) {
+	return f(req)
+}
@@ -0,0 +1,125 @@
+// Copyright 2026 LINE Corporation
+//
+// LINE Corporation licenses this file to you under the Apache License,
+// version 2.0 (the "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at:
+//
+//   https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+// WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+// License for the specific language governing permissions and limitations
+// under the License.
+
+package cassette
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"sync"
+)
+
+// ErrNoInteraction is returned by the Replayer when no recorded interaction matches a request.
+var ErrNoInteraction = errors.New("cassette: no recorded interaction matches the request")
+
+// Replayer is an http.RoundTripper which answers the requests with the recorded interactions without
+// sending them. Each interaction answers one request, in the order they were recorded. It is safe for
+// concurrent use.
+type Replayer struct {
+	mu           sync.Mutex
+	interactions []*Interaction
+	used         []bool
+}
+
+// NewReplayer returns a new Replayer which replays the interactions in the cassette file at the path.
+func NewReplayer(path string) (*Replayer, error) {
+	cassette, err := Load(path)
+	if err != nil {
+		return nil, err
+	}
+	return &Replayer{
+		interactions: cassette.Interactions,
+		used:         make([]bool, len(cassette.Interactions)),
+	}, nil
+}
+
+// RoundTrip returns the recorded response of the first unused interaction which matches the request.
+// ErrNoInteraction is returned if there is no such interaction, except for a watch request which is answered
+// with 304 Not Modified at once.
+func (r *Replayer) RoundTrip(req *http.Request) (*http.Response, error) {
+	var body []byte
+	if req.Body != nil {
+		var err error
+		if body, err = ioutil.ReadAll(req.Body); err != nil {
+			return nil, err
+		}
+		_ = req.Body.Close()
+	}
+
+	if interaction := r.take(req, redactBody(string(body))); interaction != nil {
+		return newResponse(req, interaction.Response.StatusCode, interaction.Response.Header,
+			interaction.Response.Body), nil
+	}
+	if !isWatchRequest(req.Header) {
+		return nil, fmt.Errorf("%w: %s %s", ErrNoInteraction, req.Method, requestURL(req.URL))
+	}
+	// Waiting for the wait time in the Prefer header would only slow down the replay, because no change
+	// can happen to the recorded interactions.
+	return newResponse(req, http.StatusNotModified, nil, ""), nil
+}
+
+// Unused returns the interactions which have not answered any request, which usually means the code under
+// test sends fewer requests than when the interactions were recorded.
+func (r *Replayer) Unused() []*Interaction {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	var unused []*Interaction
+	for i, interaction := range r.interactions {
+		if !r.used[i] {
+			unused = append(unused, interaction)
+		}
+	}
+	return unused
+}
+
+func (r *Replayer) take(req *http.Request, body string) *Interaction {
+	u := requestURL(req.URL)
+	watch := isWatchRequest(req.Header)
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for i, interaction := range r.interactions {
+		recorded := &interaction.Request
+		if r.used[i] || recorded.Method != req.Method || recorded.URL != u || recorded.Body != body {
+			continue
+		}
+		if watch != isWatchRequest(recorded.Header) ||
+			(watch && recorded.Header.Get("If-None-Match") != req.Header.Get("If-None-Match")) {
+			continue
+		}
+		r.used[i] = true
+		return interaction
+	}
+	return nil
+}
+
+func newResponse(req *http.Request, statusCode int, header http.Header, body string) *http.Response {
+	if header == nil {
+		header = make(http.Header)
+	}
+	return &http.Response{
+		Status:        fmt.Sprintf("%d %s", statusCode, http.StatusText(statusCode)),
+		StatusCode:    statusCode,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        header.Clone(),
+		Body:          ioutil.NopCloser(bytes.NewReader([]byte(body))),
+		ContentLength: int64(len(body)),
+		Request:       req,
+	}
+}
@@ -18,6 +18,8 @@ var (
 	ErrTransportMustNotBeOAuth2 = fmt.Errorf("transport cannot be oauth2.Transport")
 
 	ErrMetricCollectorConfigMustBeSet = fmt.Errorf("metric collector config should not be nil")
+
+	ErrRevertConflict = fmt.Errorf("the files to revert have been changed by a later commit")
 )
 
 const (
@@ -33,8 +35,15 @@ const (
 	repos    = "repos"
 	contents = "contents"
 	commits  = "commits"
+	metadata = "metadata"
+	members  = "members"
+	tokens   = "tokens"
+	users    = "users"
 
 	actionList    = "list"
 	actionCompare = "compare"
 	actionRemoved = "removed"
+	actionMerge   = "merge"
+
+	actionPermission = "perm"
 )
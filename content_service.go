@@ -22,9 +22,18 @@ import (
 	"net/http"
 	"net/url"
 	"path"
+	"strconv"
 	"strings"
+	"time"
+
+	"go.linecorp.com/centraldogma/jsonpatch"
+	"go.linecorp.com/centraldogma/textdiff"
 )
 
+// maxUpdateAttempts is the number of times Update reads, mutates and pushes a file before giving up
+// on a change conflict.
+const maxUpdateAttempts = 5
+
 type contentService service
 
 // Query specifies a query on a file.
@@ -44,6 +53,45 @@ const (
 	JSONPath
 )
 
+// MergeSource specifies a JSON file to merge.
+type MergeSource struct {
+	Path string
+	// Optional specifies whether the merge succeeds even if the file does not exist.
+	Optional bool
+}
+
+// MergeQuery specifies a query on the result of merging JSON files. The files are merged in the order of
+// the Sources; the values in a latter file override the values in a former file. The series of JSON path
+// Expressions is applied to the merged result if specified.
+type MergeQuery struct {
+	Sources     []*MergeSource
+	Expressions []string
+}
+
+// MergedEntry represents the result of merging JSON files.
+type MergedEntry struct {
+	Revision int          `json:"revision"`
+	Type     EntryType    `json:"type"`
+	Content  EntryContent `json:"content"`
+	Paths    []string     `json:"paths"` // the paths of the files which were actually merged
+}
+
+func (m *MergedEntry) UnmarshalJSON(b []byte) error {
+	type Alias MergedEntry
+	auxiliary := &struct {
+		Type string `json:"type"`
+		*Alias
+	}{
+		Alias: (*Alias)(m),
+	}
+
+	if err := json.Unmarshal(b, &auxiliary); err != nil {
+		return err
+	}
+	m.Type = entryTypeMap[auxiliary.Type]
+	return nil
+}
+
 // Entry represents an entry in the repository.
 type Entry struct {
 	Path       string       `json:"path"`
@@ -111,6 +159,13 @@ type Commit struct {
 	PushedAt      string        `json:"pushedAt,omitempty"`
 }
 
+// CommitDetail represents a commit and the changes made by the commit.
+type CommitDetail struct {
+	Commit   *Commit   `json:"commit"`
+	PushedAt time.Time `json:"pushedAt"` // the time when the commit was pushed, parsed from Commit.PushedAt
+	Changes  []*Change `json:"changes"`
+}
+
 // CommitMessages represents a commit message in the repository.
 type CommitMessage struct {
 	Summary string `json:"summary"`
@@ -152,6 +207,19 @@ func (c *Change) UnmarshalJSON(b []byte) error {
 	return nil
 }
 
+// JSONPatch returns the JSON patch of the Change whose type is ApplyJSONPatch, such as the one returned by
+// GetDiff and GetDiffs.
+func (c *Change) JSONPatch() (jsonpatch.Patch, error) {
+	if c.Type != ApplyJSONPatch {
+		return nil, fmt.Errorf("the type of the change should be %v (type: %v)", ApplyJSONPatch, c.Type)
+	}
+	b, err := json.Marshal(c.Content)
+	if err != nil {
+		return nil, err
+	}
+	return jsonpatch.ParsePatch(b)
+}
+
 func (con *contentService) listFiles(ctx context.Context,
 	projectName, repoName, revision, pathPattern string) ([]*Entry, int, error) {
 	if len(pathPattern) != 0 && !strings.HasPrefix(pathPattern, "/") {
@@ -194,6 +262,21 @@ func (con *contentService) getFile(
 		return nil, UnknownHttpStatusCode, errors.New("query should not be nil")
 	}
 
+	if con.client.evaluatesJSONPathLocally() && query.Type == JSONPath {
+		identityQuery, err := localJSONPathQuery(query)
+		if err != nil {
+			return nil, UnknownHttpStatusCode, err
+		}
+		entry, httpStatusCode, err := con.getFile(ctx, projectName, repoName, revision, identityQuery)
+		if err != nil {
+			return nil, httpStatusCode, err
+		}
+		if err := applyJSONPaths(entry, query); err != nil {
+			return nil, httpStatusCode, err
+		}
+		return entry, httpStatusCode, nil
+	}
+
 	// build relative url
 	u, err := url.Parse(path.Join(
 		defaultPathPrefix,
@@ -296,6 +379,42 @@ func (con *contentService) getHistory(ctx context.Context,
 	return commits, httpStatusCode, nil
 }
 
+func (con *contentService) getCommit(ctx context.Context,
+	projectName, repoName, revision string) (*CommitDetail, int, error) {
+	rev, httpStatusCode, err := con.client.repository.absoluteRevision(ctx, projectName, repoName, revision)
+	if err != nil {
+		return nil, httpStatusCode, err
+	}
+	absolute := strconv.Itoa(rev)
+
+	commits, httpStatusCode, err := con.getHistory(ctx, projectName, repoName, absolute, absolute, "/**", 1)
+	if err != nil {
+		return nil, httpStatusCode, err
+	}
+	if len(commits) == 0 {
+		return nil, httpStatusCode, fmt.Errorf("no commit at the revision %v of /%s/%s",
+			rev, projectName, repoName)
+	}
+
+	detail := &CommitDetail{Commit: commits[0], Changes: []*Change{}}
+	if detail.PushedAt, err = detail.Commit.PushedTime(); err != nil {
+		return nil, httpStatusCode, err
+	}
+
+	// The initial commit creates an empty repository.
+	if rev > 1 {
+		changes, diffStatusCode, err := con.getDiffs(ctx, projectName, repoName,
+			strconv.Itoa(rev-1), absolute, "/**")
+		if err != nil {
+			return nil, diffStatusCode, err
+		}
+		if changes != nil {
+			detail.Changes = changes
+		}
+	}
+	return detail, httpStatusCode, nil
+}
+
 func (con *contentService) getDiff(ctx context.Context,
 	projectName, repoName, from, to string, query *Query) (*Change, int, error) {
 
@@ -379,6 +498,38 @@ func (con *contentService) getDiffs(ctx context.Context,
 	return changes, httpStatusCode, nil
 }
 
+func (con *contentService) mergeFiles(ctx context.Context,
+	projectName, repoName, revision string, mergeQuery *MergeQuery) (*MergedEntry, int, error) {
+	rawQuery, err := mergeQueryURLValues(revision, mergeQuery)
+	if err != nil {
+		return nil, UnknownHttpStatusCode, err
+	}
+
+	// build relative url
+	u, err := url.Parse(path.Join(
+		defaultPathPrefix,
+		projects, projectName,
+		repos, repoName,
+		actionMerge,
+	))
+	if err != nil {
+		return nil, UnknownHttpStatusCode, err
+	}
+	u.RawQuery = rawQuery
+
+	req, err := con.client.newRequest(http.MethodGet, u, nil)
+	if err != nil {
+		return nil, UnknownHttpStatusCode, err
+	}
+
+	mergedEntry := new(MergedEntry)
+	httpStatusCode, err := con.client.do(ctx, req, mergedEntry, false)
+	if err != nil {
+		return nil, httpStatusCode, err
+	}
+	return mergedEntry, httpStatusCode, nil
+}
+
 type push struct {
 	CommitMessage *CommitMessage `json:"commitMessage"`
 	Changes       []*Change      `json:"changes"`
@@ -425,3 +576,82 @@ func (con *contentService) push(ctx context.Context, projectName, repoName, base
 	}
 	return pushResult, httpStatusCode, nil
 }
+
+func (con *contentService) patchJSON(ctx context.Context, projectName, repoName, baseRevision string,
+	commitMessage *CommitMessage, path string, patch jsonpatch.Patch) (*PushResult, int, error) {
+	if len(patch) == 0 {
+		return nil, UnknownHttpStatusCode, errors.New("no operations to patch")
+	}
+	change := &Change{Path: path, Type: ApplyJSONPatch, Content: patch}
+	return con.push(ctx, projectName, repoName, baseRevision, commitMessage, []*Change{change})
+}
+
+func (con *contentService) patchText(ctx context.Context, projectName, repoName, baseRevision string,
+	commitMessage *CommitMessage, path string, patch string) (*PushResult, int, error) {
+	filePatch, err := textdiff.Parse(patch)
+	if err != nil {
+		return nil, UnknownHttpStatusCode, err
+	}
+	if len(filePatch.Hunks) == 0 {
+		return nil, UnknownHttpStatusCode, errors.New("no hunks to patch")
+	}
+	change := &Change{Path: path, Type: ApplyTextPatch, Content: patch}
+	return con.push(ctx, projectName, repoName, baseRevision, commitMessage, []*Change{change})
+}
+
+// UpdateFunc returns the changes to push, computed from the current entry of a file.
+// The current is nil if the file does not exist.
+type UpdateFunc func(current *Entry) ([]*Change, error)
+
+func (con *contentService) update(ctx context.Context, projectName, repoName, path string,
+	commitMessage *CommitMessage, updateFunc UpdateFunc) (*PushResult, int, error) {
+	if updateFunc == nil {
+		return nil, UnknownHttpStatusCode, errors.New("updateFunc should not be nil")
+	}
+
+	for numAttemptsSoFar := 1; ; numAttemptsSoFar++ {
+		pushResult, httpStatusCode, err := con.tryUpdate(
+			ctx, projectName, repoName, path, commitMessage, updateFunc)
+		if isException(err, "RedundantChangeException") {
+			// The changes do not change anything, which is the same as returning no changes.
+			return nil, UnknownHttpStatusCode, nil
+		}
+		if !isException(err, "ChangeConflictException") || numAttemptsSoFar >= maxUpdateAttempts {
+			return pushResult, httpStatusCode, err
+		}
+
+		log.Debugf("Retrying to update %s/%s%s due to a conflict: %v", projectName, repoName, path, err)
+		select {
+		case <-ctx.Done():
+			return nil, httpStatusCode, ctx.Err()
+		case <-time.After(nextDelay(numAttemptsSoFar)):
+		}
+	}
+}
+
+func (con *contentService) tryUpdate(ctx context.Context, projectName, repoName, path string,
+	commitMessage *CommitMessage, updateFunc UpdateFunc) (*PushResult, int, error) {
+	headRevision, httpStatusCode, err := con.client.repository.normalizeRevision(ctx, projectName, repoName, "-1")
+	if err != nil {
+		return nil, httpStatusCode, err
+	}
+	baseRevision := strconv.Itoa(headRevision)
+
+	current, httpStatusCode, err := con.getFile(
+		ctx, projectName, repoName, baseRevision, &Query{Path: path, Type: Identity})
+	if err != nil {
+		if !isException(err, "EntryNotFoundException") {
+			return nil, httpStatusCode, err
+		}
+		current = nil
+	}
+
+	changes, err := updateFunc(current)
+	if err != nil {
+		return nil, UnknownHttpStatusCode, err
+	}
+	if len(changes) == 0 {
+		return nil, UnknownHttpStatusCode, nil
+	}
+	return con.push(ctx, projectName, repoName, baseRevision, commitMessage, changes)
+}
@@ -20,7 +20,13 @@ import (
 	"fmt"
 	"net/http"
 	"reflect"
+	"strconv"
+	"strings"
 	"testing"
+	"time"
+
+	"go.linecorp.com/centraldogma/jsonpatch"
+	"go.linecorp.com/centraldogma/textdiff"
 )
 
 func TestListFiles(t *testing.T) {
@@ -131,6 +137,35 @@ func TestGetFile_WithJSONPathAndRevision(t *testing.T) {
 	}
 }
 
+func TestGetFile_WithLocalJSONPath(t *testing.T) {
+	c, mux, teardown := setup()
+	defer teardown()
+	c.SetLocalJSONPathEvaluation(true)
+
+	mux.HandleFunc("/api/v1/projects/foo/repos/bar/contents/a.json",
+		func(w http.ResponseWriter, r *http.Request) {
+			testMethod(t, r, http.MethodGet)
+			testURLQuery(t, r, "jsonpath", "")
+			testURLQuery(t, r, "revision", "-1")
+			fmt.Fprint(w, `{"path":"/a.json", "type":"JSON", "content":{"a":[{"b":1},{"b":2}]}}`)
+		})
+
+	query := &Query{Path: "/a.json", Type: JSONPath, Expressions: []string{"$.a[?(@.b > 1)]", "$[0]"}}
+	entry, _, err := c.GetFile(context.Background(), "foo", "bar", "-1", query)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := &Entry{Path: "/a.json", Type: JSON, Content: EntryContent(`{"b":2}`)}
+	if !reflect.DeepEqual(entry, want) {
+		t.Errorf("GetFile returned %+v, want %+v", entry, want)
+	}
+
+	query = &Query{Path: "/a.json", Type: JSONPath, Expressions: []string{"$.c"}}
+	if _, _, err := c.GetFile(context.Background(), "foo", "bar", "-1", query); err == nil {
+		t.Error("GetFile with a path which does not exist should fail")
+	}
+}
+
 func TestGetFiles(t *testing.T) {
 	c, mux, teardown := setup()
 	defer teardown()
@@ -177,6 +212,55 @@ func TestGetHistory(t *testing.T) {
 	}
 }
 
+func TestGetCommit(t *testing.T) {
+	c, mux, teardown := setup()
+	defer teardown()
+
+	mux.HandleFunc("/api/v1/projects/foo/repos/bar/revision/-1", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"revision":3}`)
+	})
+	mux.HandleFunc("/api/v1/projects/foo/repos/bar/commits/", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, http.MethodGet)
+		revision := strings.TrimPrefix(r.URL.Path, "/api/v1/projects/foo/repos/bar/commits/")
+		testURLQuery(t, r, "to", revision)
+		testURLQuery(t, r, "maxCommits", "1")
+		fmt.Fprintf(w, `[{"revision":%s, "author":{"name":"minux", "email":"minux@m.x"},
+"commitMessage":{"summary":"Edit a.txt"}, "pushedAt":"2026-01-02T03:04:05Z"}]`, revision)
+	})
+	mux.HandleFunc("/api/v1/projects/foo/repos/bar/compare", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, http.MethodGet)
+		testURLQuery(t, r, "from", "2")
+		testURLQuery(t, r, "to", "3")
+		testURLQuery(t, r, "pathPattern", "/**")
+		fmt.Fprint(w, `[{"path":"/a.txt", "type":"APPLY_TEXT_PATCH",
+"content":"--- /a.txt\n+++ /a.txt\n@@ -1,1 +1,1 @@\n-foo\n+bar"}]`)
+	})
+
+	detail, _, err := c.GetCommit(context.Background(), "foo", "bar", Head())
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := &CommitDetail{
+		Commit: &Commit{Revision: 3, Author: Author{Name: "minux", Email: "minux@m.x"},
+			CommitMessage: CommitMessage{Summary: "Edit a.txt"}, PushedAt: "2026-01-02T03:04:05Z"},
+		PushedAt: time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC),
+		Changes: []*Change{{Path: "/a.txt", Type: ApplyTextPatch,
+			Content: "--- /a.txt\n+++ /a.txt\n@@ -1,1 +1,1 @@\n-foo\n+bar"}},
+	}
+	if !reflect.DeepEqual(detail, want) {
+		t.Errorf("GetCommit returned %+v, want %+v", detail, want)
+	}
+
+	// The initial commit has no changes.
+	detail, _, err = c.GetCommit(context.Background(), "foo", "bar", Init())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if detail.Commit.Revision != 1 || len(detail.Changes) != 0 {
+		t.Errorf("GetCommit returned %+v, want the initial commit without changes", detail)
+	}
+}
+
 func TestGetDiff(t *testing.T) {
 	c, mux, teardown := setup()
 	defer teardown()
@@ -248,6 +332,127 @@ func TestGetDiffs(t *testing.T) {
 	}
 }
 
+func TestMergeFiles(t *testing.T) {
+	c, mux, teardown := setup()
+	defer teardown()
+
+	mux.HandleFunc("/api/v1/projects/foo/repos/bar/merge",
+		func(w http.ResponseWriter, r *http.Request) {
+			testMethod(t, r, http.MethodGet)
+			testString(t, r.URL.RawQuery,
+				"path=%2Fbase.json&optional_path=%2Foverride.json&path=%2Fprod.json&jsonpath=%24.a&revision=-1",
+				"RawQuery")
+			fmt.Fprint(w, `{"revision":3, "type":"JSON", "content":{"b":"c"}, "paths":["/base.json","/prod.json"]}`)
+		})
+
+	mergeQuery := &MergeQuery{
+		Sources: []*MergeSource{
+			{Path: "/base.json"},
+			{Path: "/override.json", Optional: true},
+			{Path: "prod.json"},
+		},
+		Expressions: []string{"$.a"},
+	}
+	mergedEntry, _, err := c.MergeFiles(context.Background(), "foo", "bar", Head(), mergeQuery)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := &MergedEntry{Revision: 3, Type: JSON, Content: EntryContent(`{"b":"c"}`),
+		Paths: []string{"/base.json", "/prod.json"}}
+	if !reflect.DeepEqual(mergedEntry, want) {
+		t.Errorf("MergeFiles returned %+v, want %+v", mergedEntry, want)
+	}
+
+	invalidQueries := []*MergeQuery{
+		nil,
+		{},
+		{Sources: []*MergeSource{{Path: "/a.yaml"}}},
+	}
+	for _, mergeQuery := range invalidQueries {
+		if _, _, err := c.MergeFiles(context.Background(), "foo", "bar", Head(), mergeQuery); err == nil {
+			t.Errorf("MergeFiles with %+v should fail", mergeQuery)
+		}
+	}
+}
+
+func TestChange_JSONPatch(t *testing.T) {
+	change := new(Change)
+	_ = json.Unmarshal([]byte(`{"path":"/a.json", "type":"APPLY_JSON_PATCH",
+"content":[{"op":"safeReplace", "path":"/a", "oldValue":"bar", "value":"baz"}]}`), change)
+
+	patch, err := change.JSONPatch()
+	if err != nil {
+		t.Fatalf("JSONPatch returned an error: %v", err)
+	}
+	want := jsonpatch.Patch{{Op: jsonpatch.SafeReplace, Path: "/a", OldValue: "bar", Value: "baz"}}
+	if !reflect.DeepEqual(patch, want) {
+		t.Errorf("JSONPatch returned %+v, want %+v", patch, want)
+	}
+
+	textChange := &Change{Path: "/b.txt", Type: ApplyTextPatch, Content: "--- /b.txt"}
+	if _, err := textChange.JSONPatch(); err == nil {
+		t.Errorf("JSONPatch returned no error for %v", textChange.Type)
+	}
+}
+
+func TestPatchJSON(t *testing.T) {
+	c, mux, teardown := setup()
+	defer teardown()
+
+	mux.HandleFunc("/api/v1/projects/foo/repos/bar/contents", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, http.MethodPost)
+		testURLQuery(t, r, "revision", "3")
+		testBody(t, r, `{"commitMessage":{"summary":"Edit a.json"},"changes":[{"type":"APPLY_JSON_PATCH",`+
+			`"path":"/a.json","content":[{"op":"safeReplace","path":"/a","oldValue":"bar","value":"baz"}]}]}`+"\n")
+		fmt.Fprint(w, `{"revision":4, "pushedAt":"2017-05-22T00:00:00Z"}`)
+	})
+
+	patch, _ := jsonpatch.DiffJSON([]byte(`{"a":"bar"}`), []byte(`{"a":"baz"}`), jsonpatch.Safe)
+	commitMessage := &CommitMessage{Summary: "Edit a.json"}
+	pushResult, _, err := c.PatchJSON(context.Background(), "foo", "bar", 3, commitMessage, "/a.json", patch)
+	if err != nil {
+		t.Fatalf("PatchJSON returned an error: %v", err)
+	}
+
+	want := &PushResult{Revision: 4, PushedAt: "2017-05-22T00:00:00Z"}
+	if !reflect.DeepEqual(pushResult, want) {
+		t.Errorf("PatchJSON returned %+v, want %+v", pushResult, want)
+	}
+}
+
+func TestPatchText(t *testing.T) {
+	c, mux, teardown := setup()
+	defer teardown()
+
+	patch := textdiff.Diff("/b.txt", "foo\n", "bar\n")
+	mux.HandleFunc("/api/v1/projects/foo/repos/bar/contents", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, http.MethodPost)
+		testURLQuery(t, r, "revision", "3")
+
+		var reqBody push
+		_ = json.NewDecoder(r.Body).Decode(&reqBody)
+		changes := []*Change{{Path: "/b.txt", Type: ApplyTextPatch, Content: patch}}
+		if !reflect.DeepEqual(reqBody.Changes, changes) {
+			t.Errorf("Push request body %+v, want %+v", reqBody.Changes, changes)
+		}
+		fmt.Fprint(w, `{"revision":4, "pushedAt":"2017-05-22T00:00:00Z"}`)
+	})
+
+	commitMessage := &CommitMessage{Summary: "Edit b.txt"}
+	pushResult, _, err := c.PatchText(context.Background(), "foo", "bar", 3, commitMessage, "/b.txt", patch)
+	if err != nil {
+		t.Fatalf("PatchText returned an error: %v", err)
+	}
+	want := &PushResult{Revision: 4, PushedAt: "2017-05-22T00:00:00Z"}
+	if !reflect.DeepEqual(pushResult, want) {
+		t.Errorf("PatchText returned %+v, want %+v", pushResult, want)
+	}
+
+	if _, _, err := c.PatchText(context.Background(), "foo", "bar", 3, commitMessage, "/b.txt", ""); err == nil {
+		t.Errorf("PatchText returned no error for an empty patch")
+	}
+}
+
 func TestPush(t *testing.T) {
 	c, mux, teardown := setup()
 	defer teardown()
@@ -333,3 +538,154 @@ func TestGetTextFile(t *testing.T) {
 		t.Errorf("GetFile returned %+v, want %+v", entry, want)
 	}
 }
+
+func TestUpdate(t *testing.T) {
+	c, mux, teardown := setup()
+	defer teardown()
+
+	headRevision := 2
+	numPushes := 0
+	mux.HandleFunc("/api/v1/projects/foo/repos/bar/revision/-1", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, http.MethodGet)
+		fmt.Fprintf(w, `{"revision":%d}`, headRevision)
+	})
+	mux.HandleFunc("/api/v1/projects/foo/repos/bar/contents/a.json", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, http.MethodGet)
+		testURLQuery(t, r, "revision", strconv.Itoa(headRevision))
+		fmt.Fprintf(w, `{"path":"/a.json", "type":"JSON", "content":{"count":%d}}`, headRevision)
+	})
+	mux.HandleFunc("/api/v1/projects/foo/repos/bar/contents", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, http.MethodPost)
+		testURLQuery(t, r, "revision", strconv.Itoa(headRevision))
+		numPushes++
+		if numPushes == 1 {
+			// Someone else pushed a commit before us.
+			headRevision++
+			w.WriteHeader(http.StatusConflict)
+			fmt.Fprint(w, `{"exception":"com.linecorp.centraldogma.common.ChangeConflictException",`+
+				`"message":"invalid baseRevision"}`)
+			return
+		}
+
+		var reqBody push
+		_ = json.NewDecoder(r.Body).Decode(&reqBody)
+		changes := []*Change{{Path: "/a.json", Type: UpsertJSON, Content: map[string]interface{}{"count": 4.0}}}
+		if !reflect.DeepEqual(reqBody.Changes, changes) {
+			t.Errorf("Push request body %+v, want %+v", reqBody.Changes, changes)
+		}
+		fmt.Fprint(w, `{"revision":4, "pushedAt":"2017-05-22T00:00:00Z"}`)
+	})
+
+	commitMessage := &CommitMessage{Summary: "Increase the count"}
+	pushResult, httpStatusCode, err := c.Update(context.Background(), "foo", "bar", "/a.json", commitMessage,
+		func(current *Entry) ([]*Change, error) {
+			var counter map[string]interface{}
+			if err := json.Unmarshal(current.Content, &counter); err != nil {
+				return nil, err
+			}
+			counter["count"] = counter["count"].(float64) + 1
+			return []*Change{{Path: "/a.json", Type: UpsertJSON, Content: counter}}, nil
+		})
+	if err != nil {
+		t.Fatalf("Update returned an error: %v", err)
+	}
+	testStatusCode(t, httpStatusCode, http.StatusOK)
+	if numPushes != 2 {
+		t.Errorf("Update pushed %d times, want %d", numPushes, 2)
+	}
+
+	want := &PushResult{Revision: 4, PushedAt: "2017-05-22T00:00:00Z"}
+	if !reflect.DeepEqual(pushResult, want) {
+		t.Errorf("Update returned %+v, want %+v", pushResult, want)
+	}
+}
+
+func TestUpdate_NotFoundAndNoChanges(t *testing.T) {
+	c, mux, teardown := setup()
+	defer teardown()
+
+	mux.HandleFunc("/api/v1/projects/foo/repos/bar/revision/-1", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"revision":2}`)
+	})
+	mux.HandleFunc("/api/v1/projects/foo/repos/bar/contents/a.json", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+		fmt.Fprint(w, `{"exception":"com.linecorp.centraldogma.common.EntryNotFoundException",`+
+			`"message":"/a.json does not exist"}`)
+	})
+	mux.HandleFunc("/api/v1/projects/foo/repos/bar/contents", func(w http.ResponseWriter, r *http.Request) {
+		t.Error("Update must not push when there are no changes")
+	})
+
+	commitMessage := &CommitMessage{Summary: "Add a.json"}
+	pushResult, _, err := c.Update(context.Background(), "foo", "bar", "/a.json", commitMessage,
+		func(current *Entry) ([]*Change, error) {
+			if current != nil {
+				t.Errorf("current: %+v, want nil", current)
+			}
+			return nil, nil
+		})
+	if err != nil {
+		t.Fatalf("Update returned an error: %v", err)
+	}
+	if pushResult != nil {
+		t.Errorf("Update returned %+v, want nil", pushResult)
+	}
+}
+
+func TestUpdate_RedundantChange(t *testing.T) {
+	c, mux, teardown := setup()
+	defer teardown()
+
+	numPushes := 0
+	mux.HandleFunc("/api/v1/projects/foo/repos/bar/revision/-1", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"revision":2}`)
+	})
+	mux.HandleFunc("/api/v1/projects/foo/repos/bar/contents/a.json", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"path":"/a.json", "type":"JSON", "content":{"count":1}}`)
+	})
+	mux.HandleFunc("/api/v1/projects/foo/repos/bar/contents", func(w http.ResponseWriter, r *http.Request) {
+		numPushes++
+		w.WriteHeader(http.StatusConflict)
+		fmt.Fprint(w, `{"exception":"com.linecorp.centraldogma.common.RedundantChangeException",`+
+			`"message":"changes did not change anything"}`)
+	})
+
+	commitMessage := &CommitMessage{Summary: "Keep the count"}
+	pushResult, _, err := c.Update(context.Background(), "foo", "bar", "/a.json", commitMessage,
+		func(current *Entry) ([]*Change, error) {
+			return []*Change{{Path: "/a.json", Type: UpsertJSON, Content: current.Content}}, nil
+		})
+	if err != nil {
+		t.Fatalf("Update returned an error: %v", err)
+	}
+	if pushResult != nil {
+		t.Errorf("Update returned %+v, want nil", pushResult)
+	}
+	if numPushes != 1 {
+		t.Errorf("Update pushed %d times, want %d", numPushes, 1)
+	}
+}
+
+func TestUpdate_RepositoryNotFound(t *testing.T) {
+	c, mux, teardown := setup()
+	defer teardown()
+
+	mux.HandleFunc("/api/v1/projects/foo/repos/bar/revision/-1", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"revision":2}`)
+	})
+	mux.HandleFunc("/api/v1/projects/foo/repos/bar/contents/a.json", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+		fmt.Fprint(w, `{"exception":"com.linecorp.centraldogma.common.RepositoryNotFoundException",`+
+			`"message":"foo/bar does not exist"}`)
+	})
+
+	_, httpStatusCode, err := c.Update(context.Background(), "foo", "bar", "/a.json", &CommitMessage{Summary: "x"},
+		func(current *Entry) ([]*Change, error) {
+			t.Error("updateFunc must not be called when the repository does not exist")
+			return nil, nil
+		})
+	if err == nil {
+		t.Fatal("Update should fail")
+	}
+	testStatusCode(t, httpStatusCode, http.StatusNotFound)
+}
@@ -39,6 +39,7 @@ import (
 	"context"
 	"crypto/tls"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"io/ioutil"
@@ -47,12 +48,15 @@ import (
 	"net/url"
 	"strconv"
 	"strings"
+	"sync/atomic"
 	"time"
 
 	metrics "github.com/armon/go-metrics"
 	"github.com/sirupsen/logrus"
 	"golang.org/x/net/http2"
 	"golang.org/x/oauth2"
+
+	"go.linecorp.com/centraldogma/jsonpatch"
 )
 
 var log = logrus.New()
@@ -78,9 +82,20 @@ type Client struct {
 	repository *repositoryService
 	content    *contentService
 	watch      *watchService
+	metadata   *metadataService
+	token      *tokenService
+	mirror     *mirrorService
+	admin      *adminService
 
 	// metrics
 	metricCollector *metrics.Metrics
+
+	// 1 if the JSON path expressions of a query are evaluated by the client rather than the server.
+	// It is accessed atomically because the watchers read it while it can be set.
+	localJSONPath int32
+
+	// the diffs between absolute revisions, which Blame fetches repeatedly
+	diffCache *diffCache
 }
 
 type service struct {
@@ -173,8 +188,9 @@ func newOAuth2HTTP2Client(normalizedURL, token string, transport http.RoundTripp
 // The client should perform the authentication.
 func newClientWithHTTPClient(baseURL *url.URL, client *http.Client) (*Client, error) {
 	c := &Client{
-		client:  client,
-		baseURL: baseURL,
+		client:    client,
+		baseURL:   baseURL,
+		diffCache: newDiffCache(defaultDiffCacheSize),
 	}
 	service := &service{client: c}
 
@@ -182,6 +198,10 @@ func newClientWithHTTPClient(baseURL *url.URL, client *http.Client) (*Client, er
 	c.repository = (*repositoryService)(service)
 	c.content = (*contentService)(service)
 	c.watch = (*watchService)(service)
+	c.metadata = (*metadataService)(service)
+	c.token = (*tokenService)(service)
+	c.mirror = (*mirrorService)(service)
+	c.admin = (*adminService)(service)
 	return c, nil
 }
 
@@ -266,8 +286,27 @@ func (c *Client) newRequest(method string, url *url.URL, body interface{}) (*htt
 	return req, nil
 }
 
+// errorMessage is the error response of the server. Exception is the class name of the exception thrown by
+// the server, e.g. "com.linecorp.centraldogma.common.RedundantChangeException".
 type errorMessage struct {
-	Message string `json:"message"`
+	Exception string `json:"exception"`
+	Message   string `json:"message"`
+
+	statusCode int
+}
+
+func (e *errorMessage) Error() string {
+	return fmt.Sprintf("%s (status: %v)", e.Message, e.statusCode)
+}
+
+// isException returns whether the err is the error response of the server with the exception whose simple
+// class name is the exception, e.g. "RedundantChangeException".
+func isException(err error, exception string) bool {
+	var e *errorMessage
+	if !errors.As(err, &e) {
+		return false
+	}
+	return e.Exception == exception || strings.HasSuffix(e.Exception, "."+exception)
 }
 
 func drainupAndCloseResponseBody(body io.ReadCloser) {
@@ -329,13 +368,13 @@ func (c *Client) do(ctx context.Context,
 	startAt = time.Now()
 	if !watchRequest || statusCode != http.StatusNotModified {
 		if statusCode < 200 || statusCode >= 300 {
-			errorMessage := &errorMessage{}
+			errorMessage := &errorMessage{statusCode: statusCode}
 
 			err = json.NewDecoder(res.Body).Decode(errorMessage)
 			if err != nil {
 				err = fmt.Errorf("status: %v", statusCode)
 			} else {
-				err = fmt.Errorf("%s (status: %v)", errorMessage.Message, statusCode)
+				err = errorMessage
 			}
 		} else if resContent != nil {
 			err = json.NewDecoder(res.Body).Decode(resContent)
@@ -356,6 +395,34 @@ func (c *Client) do(ctx context.Context,
 	return
 }
 
+// GetServerStatus returns whether the server is writable and whether it replicates the changes.
+func (c *Client) GetServerStatus(ctx context.Context) (status *ServerStatus, httpStatusCode int, err error) {
+	return c.admin.getStatus(ctx)
+}
+
+// SetReadOnly puts the server into read-only mode, in which every push is rejected, if readOnly is true.
+// Otherwise, it makes the server writable again. It returns the updated status. Only an administrator can
+// change the status. For example, the writes can be drained before upgrading the server:
+//
+//	if _, _, err := client.SetReadOnly(ctx, true); err != nil {
+//	    panic(err)
+//	}
+//	defer client.SetReadOnly(ctx, false)
+func (c *Client) SetReadOnly(ctx context.Context,
+	readOnly bool) (status *ServerStatus, httpStatusCode int, err error) {
+	return c.admin.setWritable(ctx, !readOnly)
+}
+
+// CheckHealth returns whether the server is healthy and ready to serve the requests.
+func (c *Client) CheckHealth(ctx context.Context) (healthy bool, httpStatusCode int, err error) {
+	return c.admin.checkHealth(ctx)
+}
+
+// GetServerVersion returns the version of the server.
+func (c *Client) GetServerVersion(ctx context.Context) (version *ServerVersion, httpStatusCode int, err error) {
+	return c.admin.getVersion(ctx)
+}
+
 // CreateProject creates a project.
 func (c *Client) CreateProject(ctx context.Context, name string) (pro *Project, httpStatusCode int, err error) {
 	return c.project.create(ctx, name)
@@ -386,6 +453,88 @@ func (c *Client) ListRemovedProjects(ctx context.Context) (removedPros []*Projec
 	return c.project.listRemoved(ctx)
 }
 
+// GetProjectMetadata returns the metadata of a project, which contains its members and repositories.
+func (c *Client) GetProjectMetadata(ctx context.Context,
+	projectName string) (projectMetadata *ProjectMetadata, httpStatusCode int, err error) {
+	return c.metadata.getProjectMetadata(ctx, projectName)
+}
+
+// ListMembers returns the members of a project, sorted by their login IDs.
+func (c *Client) ListMembers(ctx context.Context,
+	projectName string) (members []*Member, httpStatusCode int, err error) {
+	return c.metadata.listMembers(ctx, projectName)
+}
+
+// AddMember adds a user to a project with the role.
+func (c *Client) AddMember(ctx context.Context,
+	projectName, login string, role ProjectRole) (httpStatusCode int, err error) {
+	return c.metadata.addMember(ctx, projectName, login, role)
+}
+
+// UpdateMemberRole changes the role of a member of a project.
+func (c *Client) UpdateMemberRole(ctx context.Context,
+	projectName, login string, role ProjectRole) (httpStatusCode int, err error) {
+	return c.metadata.updateMemberRole(ctx, projectName, login, role)
+}
+
+// RemoveMember removes a member from a project.
+func (c *Client) RemoveMember(ctx context.Context, projectName, login string) (httpStatusCode int, err error) {
+	return c.metadata.removeMember(ctx, projectName, login)
+}
+
+// CreateToken creates an application token. Only an administrator can create an admin token.
+// The Secret of the returned Token is used to access the server, e.g. with NewClientWithToken.
+func (c *Client) CreateToken(ctx context.Context,
+	appID string, admin bool) (token *Token, httpStatusCode int, err error) {
+	return c.token.create(ctx, appID, admin)
+}
+
+// ListTokens returns the list of application tokens.
+func (c *Client) ListTokens(ctx context.Context) (tokens []*Token, httpStatusCode int, err error) {
+	return c.token.list(ctx)
+}
+
+// DeactivateToken deactivates an application token. A deactivated token can be activated using ActivateToken.
+func (c *Client) DeactivateToken(ctx context.Context, appID string) (token *Token, httpStatusCode int, err error) {
+	return c.token.deactivate(ctx, appID)
+}
+
+// ActivateToken activates a deactivated application token.
+func (c *Client) ActivateToken(ctx context.Context, appID string) (token *Token, httpStatusCode int, err error) {
+	return c.token.activate(ctx, appID)
+}
+
+// DeleteToken deletes an application token.
+func (c *Client) DeleteToken(ctx context.Context, appID string) (httpStatusCode int, err error) {
+	return c.token.delete(ctx, appID)
+}
+
+// AddTokenToProject registers an application token to a project with the role.
+func (c *Client) AddTokenToProject(ctx context.Context,
+	projectName, appID string, role ProjectRole) (httpStatusCode int, err error) {
+	return c.token.addToProject(ctx, projectName, appID, role)
+}
+
+// RemoveTokenFromProject unregisters an application token from a project.
+func (c *Client) RemoveTokenFromProject(ctx context.Context,
+	projectName, appID string) (httpStatusCode int, err error) {
+	return c.token.removeFromProject(ctx, projectName, appID)
+}
+
+// GrantTokenRepositoryPermission grants the permissions on a repository to an application token
+// which is registered to the project.
+func (c *Client) GrantTokenRepositoryPermission(ctx context.Context,
+	projectName, repoName, appID string, permissions []Permission) (httpStatusCode int, err error) {
+	return c.token.grantRepositoryPermission(ctx, projectName, repoName, appID, permissions)
+}
+
+// RevokeTokenRepositoryPermission revokes the permissions on a repository which were granted to
+// an application token.
+func (c *Client) RevokeTokenRepositoryPermission(ctx context.Context,
+	projectName, repoName, appID string) (httpStatusCode int, err error) {
+	return c.token.revokeRepositoryPermission(ctx, projectName, repoName, appID)
+}
+
 // CreateRepository creates a repository.
 func (c *Client) CreateRepository(
 	ctx context.Context, projectName, repoName string) (repo *Repository, httpStatusCode int, err error) {
@@ -421,12 +570,102 @@ func (c *Client) ListRemovedRepositories(
 	return c.repository.listRemoved(ctx, projectName)
 }
 
+// GetRepositoryPermissions returns the permissions of the project roles, users and application tokens
+// on a repository.
+func (c *Client) GetRepositoryPermissions(ctx context.Context,
+	projectName, repoName string) (permissions *RepositoryPermissions, httpStatusCode int, err error) {
+	return c.repository.getPermissions(ctx, projectName, repoName)
+}
+
+// SetRolePermissions replaces the permissions of the project roles on a repository. For example,
+// the following grants nothing to the guests:
+//
+//	_, err := client.SetRolePermissions(ctx, "foo", "bar", PerRolePermissions{
+//	    Owner:  []Permission{PermissionRead, PermissionWrite},
+//	    Member: []Permission{PermissionRead},
+//	})
+func (c *Client) SetRolePermissions(ctx context.Context,
+	projectName, repoName string, permissions PerRolePermissions) (httpStatusCode int, err error) {
+	return c.repository.setRolePermissions(ctx, projectName, repoName, permissions)
+}
+
+// AddUserPermission grants the permissions on a repository to a user.
+func (c *Client) AddUserPermission(ctx context.Context,
+	projectName, repoName, login string, permissions []Permission) (httpStatusCode int, err error) {
+	return c.repository.addPermission(ctx, projectName, repoName, users, login, permissions)
+}
+
+// RemoveUserPermission revokes the permissions on a repository which were granted to a user.
+func (c *Client) RemoveUserPermission(ctx context.Context,
+	projectName, repoName, login string) (httpStatusCode int, err error) {
+	return c.repository.removePermission(ctx, projectName, repoName, users, login)
+}
+
+// ListMirrors returns the mirrors of a project, which are defined in the /mirrors.json of its meta repository.
+func (c *Client) ListMirrors(ctx context.Context,
+	projectName string) (mirrors []*Mirror, httpStatusCode int, err error) {
+	return c.mirror.listMirrors(ctx, projectName)
+}
+
+// CreateMirror validates a mirror and adds it to the /mirrors.json of the meta repository of a project.
+// The credential of the mirror, if specified, must exist.
+func (c *Client) CreateMirror(ctx context.Context,
+	projectName string, mirror *Mirror) (result *PushResult, httpStatusCode int, err error) {
+	return c.mirror.createMirror(ctx, projectName, mirror)
+}
+
+// UpdateMirror validates a mirror and replaces the mirror which has the same ID.
+func (c *Client) UpdateMirror(ctx context.Context,
+	projectName string, mirror *Mirror) (result *PushResult, httpStatusCode int, err error) {
+	return c.mirror.updateMirror(ctx, projectName, mirror)
+}
+
+// DeleteMirror deletes the mirror which has the ID from a project.
+func (c *Client) DeleteMirror(ctx context.Context,
+	projectName, id string) (result *PushResult, httpStatusCode int, err error) {
+	return c.mirror.deleteMirror(ctx, projectName, id)
+}
+
+// ListCredentials returns the credentials of a project, which are defined in the /credentials.json of
+// its meta repository.
+func (c *Client) ListCredentials(ctx context.Context,
+	projectName string) (credentials []*Credential, httpStatusCode int, err error) {
+	return c.mirror.listCredentials(ctx, projectName)
+}
+
+// CreateCredential validates a credential and adds it to the /credentials.json of the meta repository of
+// a project.
+func (c *Client) CreateCredential(ctx context.Context,
+	projectName string, credential *Credential) (result *PushResult, httpStatusCode int, err error) {
+	return c.mirror.createCredential(ctx, projectName, credential)
+}
+
+// UpdateCredential validates a credential and replaces the credential which has the same ID.
+func (c *Client) UpdateCredential(ctx context.Context,
+	projectName string, credential *Credential) (result *PushResult, httpStatusCode int, err error) {
+	return c.mirror.updateCredential(ctx, projectName, credential)
+}
+
+// DeleteCredential deletes the credential which has the ID from a project. A credential used by a mirror
+// cannot be deleted.
+func (c *Client) DeleteCredential(ctx context.Context,
+	projectName, id string) (result *PushResult, httpStatusCode int, err error) {
+	return c.mirror.deleteCredential(ctx, projectName, id)
+}
+
 // NormalizeRevision converts the relative revision number to the absolute revision number(e.g. -1 -> 3).
 func (c *Client) NormalizeRevision(
 	ctx context.Context, projectName, repoName, revision string) (normalizedRev int, httpStatusCode int, err error) {
 	return c.repository.normalizeRevision(ctx, projectName, repoName, revision)
 }
 
+// Normalize converts the relative Revision to the absolute Revision(e.g. -1 -> 3).
+func (c *Client) Normalize(ctx context.Context,
+	projectName, repoName string, revision Revision) (normalizedRev Revision, httpStatusCode int, err error) {
+	rev, httpStatusCode, err := c.repository.normalizeRevision(ctx, projectName, repoName, revisionArg(revision))
+	return Revision(rev), httpStatusCode, err
+}
+
 // ListFiles returns the list of files that match the given path pattern. A path pattern is a variant of glob:
 //
 //   - "/**": find all files recursively
@@ -439,6 +678,12 @@ func (c *Client) ListFiles(ctx context.Context,
 	return c.content.listFiles(ctx, projectName, repoName, revision, pathPattern)
 }
 
+// ListFilesAt is like ListFiles but takes the revision as a Revision.
+func (c *Client) ListFilesAt(ctx context.Context, projectName, repoName string, revision Revision,
+	pathPattern string) (entries []*Entry, httpStatusCode int, err error) {
+	return c.content.listFiles(ctx, projectName, repoName, revisionArg(revision), pathPattern)
+}
+
 // GetFile returns the file at the specified revision and path with the specified Query.
 func (c *Client) GetFile(
 	ctx context.Context, projectName, repoName, revision string, query *Query) (entry *Entry,
@@ -446,6 +691,12 @@ func (c *Client) GetFile(
 	return c.content.getFile(ctx, projectName, repoName, revision, query)
 }
 
+// GetFileAt is like GetFile but takes the revision as a Revision.
+func (c *Client) GetFileAt(ctx context.Context, projectName, repoName string, revision Revision,
+	query *Query) (entry *Entry, httpStatusCode int, err error) {
+	return c.content.getFile(ctx, projectName, repoName, revisionArg(revision), query)
+}
+
 // GetFiles returns the files that match the given path pattern. A path pattern is a variant of glob:
 //
 //   - "/**": find all files recursively
@@ -458,6 +709,30 @@ func (c *Client) GetFiles(ctx context.Context,
 	return c.content.getFiles(ctx, projectName, repoName, revision, pathPattern)
 }
 
+// GetFilesAt is like GetFiles but takes the revision as a Revision.
+func (c *Client) GetFilesAt(ctx context.Context, projectName, repoName string, revision Revision,
+	pathPattern string) (entries []*Entry, httpStatusCode int, err error) {
+	return c.content.getFiles(ctx, projectName, repoName, revisionArg(revision), pathPattern)
+}
+
+// Export writes the files that match the given path pattern at the specified revision to w as a tar.gz or zip
+// archive. The revision is normalized first, so that all files are fetched at the same absolute revision with
+// a single GetFiles request. The archive contains an ExportManifest named ExportManifestName at its root,
+// which records the absolute revision and the metadata of each file. The zero Revision exports the latest
+// revision, and an empty path pattern exports all files.
+func (c *Client) Export(ctx context.Context, projectName, repoName string, revision Revision, pathPattern string,
+	w io.Writer, format ExportFormat) (manifest *ExportManifest, httpStatusCode int, err error) {
+	return c.content.export(ctx, projectName, repoName, revisionArg(revision), pathPattern, w, format)
+}
+
+// ExportToDirectory writes the files that match the given path pattern at the specified revision to
+// the directory, like Export does. The existing files in the directory are overwritten, and the other
+// files are left as they are.
+func (c *Client) ExportToDirectory(ctx context.Context, projectName, repoName string, revision Revision,
+	pathPattern, dir string) (manifest *ExportManifest, httpStatusCode int, err error) {
+	return c.content.exportToDirectory(ctx, projectName, repoName, revisionArg(revision), pathPattern, dir)
+}
+
 // GetHistory returns the history of the files that match the given path pattern. A path pattern is
 // a variant of glob:
 //
@@ -474,6 +749,63 @@ func (c *Client) GetHistory(ctx context.Context,
 	return c.content.getHistory(ctx, projectName, repoName, from, to, pathPattern, maxCommits)
 }
 
+// GetHistoryBetween is like GetHistory but takes the revisions as Revisions. The zero Revisions are
+// not specified.
+func (c *Client) GetHistoryBetween(ctx context.Context, projectName, repoName string, from, to Revision,
+	pathPattern string, maxCommits int) (commits []*Commit, httpStatusCode int, err error) {
+	return c.content.getHistory(ctx, projectName, repoName, revisionArg(from), revisionArg(to),
+		pathPattern, maxCommits)
+}
+
+// GetCommit returns the commit at the specified revision with the changes made by the commit, i.e.
+// the diffs between the revision and its previous revision. The initial commit has no changes.
+// For example:
+//
+//	detail, _, err := client.GetCommit(ctx, "foo", "bar", centraldogma.Absolute(42))
+//	fmt.Println(detail.Commit.Author.Name, detail.PushedAt, detail.Commit.CommitMessage.Summary)
+//	for _, change := range detail.Changes {
+//	    fmt.Println(change.Type, change.Path)
+//	}
+func (c *Client) GetCommit(ctx context.Context,
+	projectName, repoName string, revision Revision) (detail *CommitDetail, httpStatusCode int, err error) {
+	return c.content.getCommit(ctx, projectName, repoName, revisionArg(revision))
+}
+
+// Blame returns which commit last modified each line of the text file or each value of the JSON file at
+// the specified revision. It walks the history of the file backward, fetching the diff made by each commit,
+// until every line or value is attributed to a commit. The diffs are cached by the client so that blaming
+// the file again only fetches the diffs of the new commits. At most 1000 commits are walked through;
+// the lines or values modified before them are attributed to no commit. For example:
+//
+//	blame, _, err := client.Blame(ctx, "foo", "bar", "/config.json", centraldogma.Head())
+//	for _, value := range blame.Values {
+//	    fmt.Println(value.Pointer, value.Value, value.Commit.Author.Name)
+//	}
+func (c *Client) Blame(ctx context.Context,
+	projectName, repoName, path string, revision Revision) (blame *Blame, httpStatusCode int, err error) {
+	return c.content.blame(ctx, projectName, repoName, path, revisionArg(revision))
+}
+
+// IterateHistory returns a HistoryIterator which iterates the commits of the repository that match
+// the opts, fetching opts.PageSize commits at a time. The history is iterated from the latest revision to
+// the initial revision if opts is nil. For example:
+//
+//	it := client.IterateHistory(ctx, "foo", "bar", &HistoryOptions{
+//	    PathPattern: "/*.json",
+//	    Since:       time.Now().Add(-24 * time.Hour),
+//	})
+//	for it.Next() {
+//	    commit := it.Commit()
+//	    fmt.Println(commit.Revision, commit.CommitMessage.Summary)
+//	}
+//	if err := it.Err(); err != nil {
+//	    panic(err)
+//	}
+func (c *Client) IterateHistory(ctx context.Context,
+	projectName, repoName string, opts *HistoryOptions) *HistoryIterator {
+	return c.content.iterateHistory(ctx, projectName, repoName, opts)
+}
+
 // GetDiff returns the diff of a file between two revisions. If the from and to are not specified, this will
 // return the diff from the init to the latest revision.
 func (c *Client) GetDiff(ctx context.Context,
@@ -481,6 +813,12 @@ func (c *Client) GetDiff(ctx context.Context,
 	return c.content.getDiff(ctx, projectName, repoName, from, to, query)
 }
 
+// GetDiffBetween is like GetDiff but takes the revisions as Revisions. The zero Revisions are not specified.
+func (c *Client) GetDiffBetween(ctx context.Context, projectName, repoName string, from, to Revision,
+	query *Query) (change *Change, httpStatusCode int, err error) {
+	return c.content.getDiff(ctx, projectName, repoName, revisionArg(from), revisionArg(to), query)
+}
+
 // GetDiffs returns the diffs of the files that match the given path pattern. A path pattern is
 // a variant of glob:
 //
@@ -496,24 +834,129 @@ func (c *Client) GetDiffs(ctx context.Context,
 	return c.content.getDiffs(ctx, projectName, repoName, from, to, pathPattern)
 }
 
+// GetDiffsBetween is like GetDiffs but takes the revisions as Revisions. The zero Revisions are not specified.
+func (c *Client) GetDiffsBetween(ctx context.Context, projectName, repoName string, from, to Revision,
+	pathPattern string) (changes []*Change, httpStatusCode int, err error) {
+	return c.content.getDiffs(ctx, projectName, repoName, revisionArg(from), revisionArg(to), pathPattern)
+}
+
+// MergeFiles returns the result of merging the JSON files specified in the MergeQuery at the specified
+// revision. For example:
+//
+//	mergeQuery := &MergeQuery{Sources: []*MergeSource{
+//	    {Path: "/base.json"},
+//	    {Path: "/prod.json"},
+//	    {Path: "/override.json", Optional: true},
+//	}}
+//	merged, _, err := client.MergeFiles(ctx, "foo", "bar", centraldogma.Head(), mergeQuery)
+func (c *Client) MergeFiles(ctx context.Context, projectName, repoName string, revision Revision,
+	mergeQuery *MergeQuery) (mergedEntry *MergedEntry, httpStatusCode int, err error) {
+	return c.content.mergeFiles(ctx, projectName, repoName, revisionArg(revision), mergeQuery)
+}
+
+// Revert pushes a commit which reverts the changes made by the commit at the specified revision: the
+// modified and removed files are restored, the added files are removed and the renamed files are renamed
+// back. ErrRevertConflict is returned if a later commit changed any of the files. If commitMessage is nil,
+// a commit message which refers to the reverted commit is used.
+func (c *Client) Revert(ctx context.Context, projectName, repoName string, revision Revision,
+	commitMessage *CommitMessage) (result *PushResult, httpStatusCode int, err error) {
+	return c.content.revert(ctx, projectName, repoName, revisionArg(revision), commitMessage)
+}
+
+// Rollback pushes a commit which restores the files that match the given path pattern to their state at
+// the specified revision. The files added after the revision are removed. ErrRevertConflict is returned if
+// a commit pushed while computing the changes changed any of the files. It returns a nil result if the files
+// have not been changed since the revision. For example:
+//
+//	result, _, err := client.Rollback(ctx, "foo", "bar", "/config/*.json", centraldogma.Absolute(42))
+func (c *Client) Rollback(ctx context.Context,
+	projectName, repoName, pathPattern string, toRevision Revision) (result *PushResult, httpStatusCode int,
+	err error) {
+	return c.content.rollback(ctx, projectName, repoName, pathPattern, revisionArg(toRevision))
+}
+
 // Push pushes the specified changes to the repository.
 func (c *Client) Push(ctx context.Context, projectName, repoName, baseRevision string,
 	commitMessage *CommitMessage, changes []*Change) (result *PushResult, httpStatusCode int, err error) {
 	return c.content.push(ctx, projectName, repoName, baseRevision, commitMessage, changes)
 }
 
-func (c *Client) watchWithWatcher(w *Watcher) (result <-chan WatchResult, closer func()) {
-	// setup watching channel
-	ch := make(chan WatchResult, DefaultChannelBuffer)
-	result = ch
-	_ = w.Watch(func(value WatchResult) {
-		ch <- value
-	})
+// PushAt is like Push but takes the base revision as a Revision.
+func (c *Client) PushAt(ctx context.Context, projectName, repoName string, baseRevision Revision,
+	commitMessage *CommitMessage, changes []*Change) (result *PushResult, httpStatusCode int, err error) {
+	return c.content.push(ctx, projectName, repoName, revisionArg(baseRevision), commitMessage, changes)
+}
 
-	// setup closer
-	closer = func() {
-		w.Close()
-	}
+// PlanImport compares the files with the files under the directory of the repository at the base revision,
+// and returns the plan which upserts the added and modified files, and removes the missing files if
+// ImportOptions.RemoveMissing is set. The files are keyed by their paths relative to the directory, e.g.
+// the ones returned by ReadImportFiles. The type of a file is JSON if its name ends with ".json", and text
+// otherwise. Nothing is pushed, so the plan can be shown as a dry run before calling Import.
+func (c *Client) PlanImport(ctx context.Context, projectName, repoName string, files map[string][]byte,
+	opts *ImportOptions) (plan *ImportPlan, httpStatusCode int, err error) {
+	return c.content.planImport(ctx, projectName, repoName, files, opts)
+}
+
+// Import pushes the changes of the plan as a single commit. The changes are pushed on top of the base revision
+// of the plan, so the push fails if the repository was changed after the plan was made. A default summary is
+// used if the commitMessage is nil.
+func (c *Client) Import(ctx context.Context, plan *ImportPlan,
+	commitMessage *CommitMessage) (result *PushResult, httpStatusCode int, err error) {
+	return c.content.importPlan(ctx, plan, commitMessage)
+}
+
+// PatchJSON pushes the JSON patch to the JSON file at the specified path as an APPLY_JSON_PATCH change.
+// Unlike pushing the whole content with UpsertJSON, the push fails if a "test" or "safeReplace" operation
+// does not match the content at the baseRevision. For example:
+//
+//	patch, err := jsonpatch.DiffJSON(oldContent, newContent, jsonpatch.Safe)
+//	if err != nil {
+//	    panic(err)
+//	}
+//	result, _, err := client.PatchJSON(ctx, "foo", "bar", centraldogma.Head(), commitMessage, "/a.json", patch)
+func (c *Client) PatchJSON(ctx context.Context, projectName, repoName string, baseRevision Revision,
+	commitMessage *CommitMessage, path string, patch jsonpatch.Patch) (result *PushResult, httpStatusCode int,
+	err error) {
+	return c.content.patchJSON(ctx, projectName, repoName, revisionArg(baseRevision), commitMessage, path, patch)
+}
+
+// PatchText pushes the unified diff to the text file at the specified path as an APPLY_TEXT_PATCH change.
+// Only the changed lines and their context are sent, and the push fails if the context does not match
+// the content at the baseRevision. For example:
+//
+//	patch := textdiff.Diff("/a.txt", oldContent, newContent)
+//	result, _, err := client.PatchText(ctx, "foo", "bar", centraldogma.Head(), commitMessage, "/a.txt", patch)
+func (c *Client) PatchText(ctx context.Context, projectName, repoName string, baseRevision Revision,
+	commitMessage *CommitMessage, path string, patch string) (result *PushResult, httpStatusCode int, err error) {
+	return c.content.patchText(ctx, projectName, repoName, revisionArg(baseRevision), commitMessage, path, patch)
+}
+
+// Update reads the file at the specified path of the latest revision, passes it to the updateFunc and pushes
+// the returned changes using the revision as the base revision. If another commit was pushed in the meantime,
+// the server rejects the push with a conflict and Update reads the file again and retries with a backoff
+// up to 5 times. The updateFunc receives nil if the file does not exist. If the updateFunc returns no changes,
+// nothing is pushed and the returned PushResult is nil, which is also the case if the server rejects
+// the changes as redundant because they do not change anything. For example:
+//
+//	commitMessage := &CommitMessage{Summary: "Increase the counter"}
+//	result, _, err := client.Update(ctx, "foo", "bar", "/a.json", commitMessage,
+//	    func(current *Entry) ([]*Change, error) {
+//	        var counter struct{ Count int `json:"count"` }
+//	        if current != nil {
+//	            if err := json.Unmarshal(current.Content, &counter); err != nil {
+//	                return nil, err
+//	            }
+//	        }
+//	        counter.Count++
+//	        return []*Change{{Path: "/a.json", Type: UpsertJSON, Content: counter}}, nil
+//	    })
+func (c *Client) Update(ctx context.Context, projectName, repoName, path string,
+	commitMessage *CommitMessage, updateFunc UpdateFunc) (result *PushResult, httpStatusCode int, err error) {
+	return c.content.update(ctx, projectName, repoName, path, commitMessage, updateFunc)
+}
+
+func (c *Client) watchWithWatcher(w *Watcher) (result <-chan WatchResult, closer func()) {
+	result, closer = WatchChannel(w)
 
 	// start watching
 	w.start()
@@ -653,6 +1096,27 @@ func (c *Client) RepoWatcher(projectName, repoName, pathPattern string) (*Watche
 	return rw, nil
 }
 
+// MergeWatcher returns a Watcher which notifies its listeners when the result of merging the JSON files
+// specified in the MergeQuery becomes available or changes. The Entry of a WatchResult holds the merged
+// content and its Path is the comma-separated paths of the merged files. For example:
+//
+//	mergeQuery := &MergeQuery{Sources: []*MergeSource{{Path: "/base.json"}, {Path: "/prod.json"}}}
+//	watcher, err := client.MergeWatcher("foo", "bar", mergeQuery)
+//
+//	myCh := make(chan interface{})
+//	watcher.Watch(func(result WatchResult) {
+//	    myCh <- result.Entry.Content
+//	})
+//	myValue := <-myCh
+func (c *Client) MergeWatcher(projectName, repoName string, mergeQuery *MergeQuery) (*Watcher, error) {
+	mw, err := c.watch.mergeWatcher(context.Background(), projectName, repoName, mergeQuery)
+	if err != nil {
+		return nil, err
+	}
+	mw.start()
+	return mw, nil
+}
+
 // SetMetricCollector sets metric collector for the client.
 // For example, with Prometheus:
 //
@@ -671,3 +1135,20 @@ func (c *Client) RepoWatcher(projectName, repoName, pathPattern string) (*Watche
 func (c *Client) SetMetricCollector(m *metrics.Metrics) {
 	c.metricCollector = m
 }
+
+// SetLocalJSONPathEvaluation sets whether the JSON path expressions of a JSONPath query are evaluated by
+// the client. If enabled, GetFile, WatchFile and FileWatcher fetch the whole JSON file and evaluate
+// the expressions with the jsonpath package, which follows the syntax of the server. A FileWatcher still
+// notifies its listeners only when the result of the expressions changes. It is disabled by default.
+func (c *Client) SetLocalJSONPathEvaluation(enabled bool) {
+	var value int32
+	if enabled {
+		value = 1
+	}
+	atomic.StoreInt32(&c.localJSONPath, value)
+}
+
+// evaluatesJSONPathLocally returns whether the JSON path expressions are evaluated by the client.
+func (c *Client) evaluatesJSONPathLocally() bool {
+	return atomic.LoadInt32(&c.localJSONPath) == 1
+}
@@ -0,0 +1,227 @@
+// Copyright 2026 LINE Corporation
+//
+// LINE Corporation licenses this file to you under the Apache License,
+// version 2.0 (the "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at:
+//
+//   https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+// WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+// License for the specific language governing permissions and limitations
+// under the License.
+
+// Package dogmamock provides a fake implementation of the interfaces of the centraldogma package, which
+// records the calls and returns scripted results, so that the code which depends on a centraldogma.Client
+// can be tested without a server. For example:
+//
+//	client := dogmamock.New()
+//	client.Return("GetFile", &centraldogma.Entry{Path: "/a.json", Content: []byte(`{"a":1}`)})
+//	client.Fail("Push", http.StatusConflict, errors.New("conflict"))
+//	client.AddWatchResults("/a.json", centraldogma.WatchResult{Revision: 2, Entry: entry})
+//
+//	runCodeUnderTest(client) // accepts a centraldogma.ContentReader, a ContentWriter, ...
+//
+//	for _, call := range client.Calls("Push") {
+//	    fmt.Println(call.Args...)
+//	}
+package dogmamock
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"path/filepath"
+	"reflect"
+	"runtime"
+	"sync"
+
+	"go.linecorp.com/centraldogma"
+)
+
+var (
+	_ centraldogma.ProjectManager = (*Client)(nil)
+	_ centraldogma.ContentReader  = (*Client)(nil)
+	_ centraldogma.ContentWriter  = (*Client)(nil)
+	_ centraldogma.Watching       = (*Client)(nil)
+)
+
+// ErrNotScripted is returned by a method whose result is not scripted.
+var ErrNotScripted = errors.New("dogmamock: the result is not scripted")
+
+// ErrInvalidScript is returned by a method whose scripted values do not match its results. The error
+// tells where the values were scripted.
+var ErrInvalidScript = errors.New("dogmamock: the scripted values do not match the results")
+
+// Call is a recorded call of a method.
+type Call struct {
+	Method string
+	// Args are the arguments of the call except the context.
+	Args []interface{}
+}
+
+type result struct {
+	values         []interface{}
+	httpStatusCode int
+	err            error
+	scriptedAt     string // the file and line of the Return or Fail call
+}
+
+// Client is a fake implementation of centraldogma.ProjectManager, ContentReader, ContentWriter and Watching.
+// It is safe for concurrent use.
+type Client struct {
+	mu      sync.Mutex
+	calls   []Call
+	results map[string][]*result
+
+	watchResults map[string][]centraldogma.WatchResult
+	// watchUpdated is closed and replaced whenever watch results are added, which wakes up the watchers.
+	watchUpdated chan struct{}
+}
+
+// New returns a new Client which has no scripted results.
+func New() *Client {
+	return &Client{
+		results:      make(map[string][]*result),
+		watchResults: make(map[string][]centraldogma.WatchResult),
+		watchUpdated: make(chan struct{}),
+	}
+}
+
+// Return scripts the result of the next call of the method, which returns the values with
+// http.StatusOK and no error. The values are the results of the method except the HTTP status code and
+// the error, e.g. an *Entry for GetFile and nothing for RemoveProject. The results are returned in
+// the order they are scripted, and the last one is returned repeatedly once the others are used up.
+// The method returns an error which wraps ErrInvalidScript if the values do not match its results.
+func (c *Client) Return(method string, values ...interface{}) {
+	c.script(method, &result{values: values, httpStatusCode: http.StatusOK})
+}
+
+// Fail scripts the next call of the method to fail with the HTTP status code and the error.
+func (c *Client) Fail(method string, httpStatusCode int, err error) {
+	c.script(method, &result{httpStatusCode: httpStatusCode, err: err})
+}
+
+func (c *Client) script(method string, r *result) {
+	if _, file, line, ok := runtime.Caller(2); ok {
+		r.scriptedAt = fmt.Sprintf("%s:%d", filepath.Base(file), line)
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.results[method] = append(c.results[method], r)
+}
+
+// AddWatchResults adds the results which the watchers of the path notify in order. The path is the path
+// of the Query for WatchFile and FileWatcher, the path pattern for WatchRepository and RepoWatcher, and
+// the comma-separated paths of the sources for MergeWatcher. The results can be added after the watchers
+// are created. A result whose HttpStatusCode is zero is notified with http.StatusOK.
+func (c *Client) AddWatchResults(path string, results ...centraldogma.WatchResult) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.watchResults[path] = append(c.watchResults[path], results...)
+	close(c.watchUpdated)
+	c.watchUpdated = make(chan struct{})
+}
+
+// Calls returns the recorded calls of the method in the order they were made. All calls are returned if
+// the method is empty.
+func (c *Client) Calls(method string) []Call {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	var calls []Call
+	for _, call := range c.calls {
+		if len(method) == 0 || call.Method == method {
+			calls = append(calls, call)
+		}
+	}
+	return calls
+}
+
+// call records the call of the method, and stores the scripted values to the out which are pointers to
+// the results of the method.
+func (c *Client) call(method string, args []interface{}, out ...interface{}) (int, error) {
+	c.record(method, args...)
+	r, err := c.next(method)
+	if err != nil {
+		return centraldogma.UnknownHttpStatusCode, err
+	}
+	if r.err != nil {
+		return r.httpStatusCode, r.err
+	}
+	if err := r.scan(method, out...); err != nil {
+		return centraldogma.UnknownHttpStatusCode, err
+	}
+	return r.httpStatusCode, nil
+}
+
+func (c *Client) record(method string, args ...interface{}) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.calls = append(c.calls, Call{Method: method, Args: args})
+}
+
+func (c *Client) next(method string) (*result, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	results := c.results[method]
+	if len(results) == 0 {
+		return nil, fmt.Errorf("%w: %s", ErrNotScripted, method)
+	}
+	if len(results) > 1 {
+		c.results[method] = results[1:]
+	}
+	return results[0], nil
+}
+
+// scan stores the scripted values to the out. Nothing is stored if the values do not match the out.
+func (r *result) scan(method string, out ...interface{}) error {
+	if len(r.values) != len(out) {
+		return fmt.Errorf("%w: %s returns %d values, but %d values are scripted at %s",
+			ErrInvalidScript, method, len(out), len(r.values), r.scriptedAt)
+	}
+	for i, value := range r.values {
+		if value == nil {
+			continue
+		}
+		dst, src := reflect.ValueOf(out[i]).Elem(), reflect.ValueOf(value)
+		if !src.Type().AssignableTo(dst.Type()) {
+			return fmt.Errorf("%w: %s returns %v, but %v is scripted at %s",
+				ErrInvalidScript, method, dst.Type(), src.Type(), r.scriptedAt)
+		}
+	}
+	for i, value := range r.values {
+		if value != nil {
+			reflect.ValueOf(out[i]).Elem().Set(reflect.ValueOf(value))
+		}
+	}
+	return nil
+}
+
+// watchFunc returns a centraldogma.WatchFunc which returns the watch results of the path in order, and
+// waits for new ones once they are used up.
+func (c *Client) watchFunc(path string) centraldogma.WatchFunc {
+	next := 0
+	return func(ctx context.Context, lastKnownRevision int) *centraldogma.WatchResult {
+		for {
+			c.mu.Lock()
+			results, updated := c.watchResults[path], c.watchUpdated
+			c.mu.Unlock()
+			if next < len(results) {
+				result := results[next]
+				next++
+				if result.HttpStatusCode == 0 {
+					result.HttpStatusCode = http.StatusOK
+				}
+				return &result
+			}
+
+			select {
+			case <-updated:
+			case <-ctx.Done():
+				return &centraldogma.WatchResult{Err: ctx.Err()}
+			}
+		}
+	}
+}
@@ -0,0 +1,176 @@
+// Copyright 2026 LINE Corporation
+//
+// LINE Corporation licenses this file to you under the Apache License,
+// version 2.0 (the "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at:
+//
+//   https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+// WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+// License for the specific language governing permissions and limitations
+// under the License.
+
+package dogmamock_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"go.linecorp.com/centraldogma"
+	"go.linecorp.com/centraldogma/dogmamock"
+)
+
+func TestReturnAndFail(t *testing.T) {
+	client := dogmamock.New()
+	ctx := context.Background()
+	query := &centraldogma.Query{Path: "/a.json", Type: centraldogma.Identity}
+
+	entry := &centraldogma.Entry{Path: "/a.json", Content: centraldogma.EntryContent(`{"a":1}`)}
+	client.Return("GetFile", entry)
+	conflict := errors.New("conflict")
+	client.Fail("GetFile", http.StatusConflict, conflict)
+
+	got, httpStatusCode, err := client.GetFile(ctx, "foo", "bar", "-1", query)
+	if err != nil || httpStatusCode != http.StatusOK || got != entry {
+		t.Errorf("GetFile() = %+v, %v, %v, want %+v", got, httpStatusCode, err, entry)
+	}
+	// The last scripted result is returned repeatedly.
+	for i := 0; i < 2; i++ {
+		if _, httpStatusCode, err := client.GetFile(ctx, "foo", "bar", "2", query); err != conflict ||
+			httpStatusCode != http.StatusConflict {
+			t.Errorf("GetFile() = %v, %v, want %v", httpStatusCode, err, conflict)
+		}
+	}
+
+	if _, _, err := client.ListProjects(ctx); !errors.Is(err, dogmamock.ErrNotScripted) {
+		t.Errorf("ListProjects() error = %v, want %v", err, dogmamock.ErrNotScripted)
+	}
+	client.Return("RemoveProject")
+	if httpStatusCode, err := client.RemoveProject(ctx, "foo"); err != nil || httpStatusCode != http.StatusOK {
+		t.Errorf("RemoveProject() = %v, %v, want 200", httpStatusCode, err)
+	}
+
+	calls := client.Calls("GetFile")
+	if len(calls) != 3 {
+		t.Fatalf("len(Calls(GetFile)) = %d, want 3", len(calls))
+	}
+	if want := []interface{}{"foo", "bar", "2", query}; !reflect.DeepEqual(calls[1].Args, want) {
+		t.Errorf("Calls(GetFile)[1].Args = %v, want %v", calls[1].Args, want)
+	}
+	if got := len(client.Calls("")); got != 5 {
+		t.Errorf("len(Calls()) = %d, want 5", got)
+	}
+}
+
+func TestInvalidScript(t *testing.T) {
+	client := dogmamock.New()
+	ctx := context.Background()
+	query := &centraldogma.Query{Path: "/a.json", Type: centraldogma.Identity}
+
+	client.Return("GetFile", &centraldogma.Entry{}, 1)
+	_, httpStatusCode, err := client.GetFile(ctx, "foo", "bar", "-1", query)
+	if !errors.Is(err, dogmamock.ErrInvalidScript) || httpStatusCode != centraldogma.UnknownHttpStatusCode {
+		t.Errorf("GetFile() = %v, %v, want %v", httpStatusCode, err, dogmamock.ErrInvalidScript)
+	}
+	if !strings.Contains(err.Error(), "client_test.go:") {
+		t.Errorf("GetFile() error = %v, want the location of the script", err)
+	}
+
+	client.Return("ListProjects", &centraldogma.Project{})
+	if pros, _, err := client.ListProjects(ctx); !errors.Is(err, dogmamock.ErrInvalidScript) || pros != nil {
+		t.Errorf("ListProjects() = %v, %v, want %v", pros, err, dogmamock.ErrInvalidScript)
+	}
+
+	client.Return("Update", &centraldogma.PushResult{})
+	_, _, err = client.Update(ctx, "foo", "bar", "/a.json", nil,
+		func(entry *centraldogma.Entry) ([]*centraldogma.Change, error) {
+			t.Error("updateFunc is called with the invalid script")
+			return nil, nil
+		})
+	if !errors.Is(err, dogmamock.ErrInvalidScript) {
+		t.Errorf("Update() error = %v, want %v", err, dogmamock.ErrInvalidScript)
+	}
+}
+
+func TestUpdate(t *testing.T) {
+	client := dogmamock.New()
+	current := &centraldogma.Entry{Path: "/a.json", Content: centraldogma.EntryContent(`{"count":1}`)}
+	pushResult := &centraldogma.PushResult{Revision: 3}
+	client.Return("Update", current, pushResult)
+
+	change := &centraldogma.Change{Path: "/a.json", Type: centraldogma.UpsertJSON, Content: `{"count":2}`}
+	var got *centraldogma.Entry
+	result, _, err := client.Update(context.Background(), "foo", "bar", "/a.json",
+		&centraldogma.CommitMessage{Summary: "Increase"}, func(entry *centraldogma.Entry) ([]*centraldogma.Change, error) {
+			got = entry
+			return []*centraldogma.Change{change}, nil
+		})
+	if err != nil || result != pushResult || got != current {
+		t.Errorf("Update() = %+v, %v, current %+v, want %+v with %+v", result, err, got, pushResult, current)
+	}
+	calls := client.Calls("Update")
+	if len(calls) != 1 || !reflect.DeepEqual(calls[0].Args[4], []*centraldogma.Change{change}) {
+		t.Errorf("Calls(Update) = %+v, want the change", calls)
+	}
+}
+
+func TestIterateHistory(t *testing.T) {
+	client := dogmamock.New()
+	ctx := context.Background()
+	client.Return("IterateHistory", []*centraldogma.Commit{{Revision: 1}, {Revision: 2}, {Revision: 3}})
+
+	it := client.IterateHistory(ctx, "foo", "bar", &centraldogma.HistoryOptions{To: 2, PageSize: 1})
+	var revisions []int
+	for it.Next() {
+		revisions = append(revisions, it.Commit().Revision)
+	}
+	if err := it.Err(); err != nil || !reflect.DeepEqual(revisions, []int{3, 2}) {
+		t.Errorf("IterateHistory() = %v, %v, want [3 2]", revisions, err)
+	}
+
+	client = dogmamock.New()
+	conflict := errors.New("conflict")
+	client.Fail("IterateHistory", http.StatusConflict, conflict)
+	it = client.IterateHistory(ctx, "foo", "bar", nil)
+	if it.Next() || it.Err() != conflict {
+		t.Errorf("IterateHistory() error = %v, want %v", it.Err(), conflict)
+	}
+}
+
+func TestWatchers(t *testing.T) {
+	client := dogmamock.New()
+	client.AddWatchResults("/a.json", centraldogma.WatchResult{Revision: 2})
+
+	watcher, err := client.FileWatcher("foo", "bar", &centraldogma.Query{Path: "/a.json", Type: centraldogma.Identity})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer watcher.Close()
+	if initial := watcher.AwaitInitialValueWith(time.Second); initial.Err != nil || initial.Revision != 2 {
+		t.Fatalf("AwaitInitialValue() = %+v, want the revision 2", initial)
+	}
+
+	changes, closer, err := client.WatchRepository(context.Background(), "foo", "bar", "/**", time.Minute)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer closer()
+	client.AddWatchResults("/**", centraldogma.WatchResult{Revision: 3}, centraldogma.WatchResult{Revision: 4})
+	for _, want := range []int{3, 4} {
+		select {
+		case result := <-changes:
+			if result.Revision != want || result.HttpStatusCode != http.StatusOK {
+				t.Errorf("watch result = %+v, want the revision %d", result, want)
+			}
+		case <-time.After(time.Second):
+			t.Fatalf("timed out waiting for the revision %d", want)
+		}
+	}
+}
@@ -0,0 +1,430 @@
+// Copyright 2026 LINE Corporation
+//
+// LINE Corporation licenses this file to you under the Apache License,
+// version 2.0 (the "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at:
+//
+//   https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+// WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+// License for the specific language governing permissions and limitations
+// under the License.
+
+package dogmamock
+
+import (
+	"context"
+	"path"
+	"sort"
+	"strings"
+	"time"
+
+	"go.linecorp.com/centraldogma"
+	"go.linecorp.com/centraldogma/jsonpatch"
+)
+
+// CreateProject records the call and returns the scripted *Project.
+func (c *Client) CreateProject(ctx context.Context,
+	name string) (pro *centraldogma.Project, httpStatusCode int, err error) {
+	httpStatusCode, err = c.call("CreateProject", []interface{}{name}, &pro)
+	return
+}
+
+// RemoveProject records the call and returns the scripted result.
+func (c *Client) RemoveProject(ctx context.Context, name string) (httpStatusCode int, err error) {
+	return c.call("RemoveProject", []interface{}{name})
+}
+
+// PurgeProject records the call and returns the scripted result.
+func (c *Client) PurgeProject(ctx context.Context, name string) (httpStatusCode int, err error) {
+	return c.call("PurgeProject", []interface{}{name})
+}
+
+// UnremoveProject records the call and returns the scripted *Project.
+func (c *Client) UnremoveProject(ctx context.Context,
+	name string) (pro *centraldogma.Project, httpStatusCode int, err error) {
+	httpStatusCode, err = c.call("UnremoveProject", []interface{}{name}, &pro)
+	return
+}
+
+// ListProjects records the call and returns the scripted []*Project.
+func (c *Client) ListProjects(ctx context.Context) (pros []*centraldogma.Project, httpStatusCode int, err error) {
+	httpStatusCode, err = c.call("ListProjects", nil, &pros)
+	return
+}
+
+// ListRemovedProjects records the call and returns the scripted []*Project.
+func (c *Client) ListRemovedProjects(ctx context.Context) (removedPros []*centraldogma.Project,
+	httpStatusCode int, err error) {
+	httpStatusCode, err = c.call("ListRemovedProjects", nil, &removedPros)
+	return
+}
+
+// CreateRepository records the call and returns the scripted *Repository.
+func (c *Client) CreateRepository(ctx context.Context,
+	projectName, repoName string) (repo *centraldogma.Repository, httpStatusCode int, err error) {
+	httpStatusCode, err = c.call("CreateRepository", []interface{}{projectName, repoName}, &repo)
+	return
+}
+
+// RemoveRepository records the call and returns the scripted result.
+func (c *Client) RemoveRepository(ctx context.Context, projectName, repoName string) (httpStatusCode int, err error) {
+	return c.call("RemoveRepository", []interface{}{projectName, repoName})
+}
+
+// PurgeRepository records the call and returns the scripted result.
+func (c *Client) PurgeRepository(ctx context.Context, projectName, repoName string) (httpStatusCode int, err error) {
+	return c.call("PurgeRepository", []interface{}{projectName, repoName})
+}
+
+// UnremoveRepository records the call and returns the scripted *Repository.
+func (c *Client) UnremoveRepository(ctx context.Context,
+	projectName, repoName string) (repo *centraldogma.Repository, httpStatusCode int, err error) {
+	httpStatusCode, err = c.call("UnremoveRepository", []interface{}{projectName, repoName}, &repo)
+	return
+}
+
+// ListRepositories records the call and returns the scripted []*Repository.
+func (c *Client) ListRepositories(ctx context.Context,
+	projectName string) (repos []*centraldogma.Repository, httpStatusCode int, err error) {
+	httpStatusCode, err = c.call("ListRepositories", []interface{}{projectName}, &repos)
+	return
+}
+
+// ListRemovedRepositories records the call and returns the scripted []*Repository.
+func (c *Client) ListRemovedRepositories(ctx context.Context,
+	projectName string) (removedRepos []*centraldogma.Repository, httpStatusCode int, err error) {
+	httpStatusCode, err = c.call("ListRemovedRepositories", []interface{}{projectName}, &removedRepos)
+	return
+}
+
+// NormalizeRevision records the call and returns the scripted int.
+func (c *Client) NormalizeRevision(ctx context.Context,
+	projectName, repoName, revision string) (normalizedRev int, httpStatusCode int, err error) {
+	httpStatusCode, err = c.call("NormalizeRevision", []interface{}{projectName, repoName, revision},
+		&normalizedRev)
+	return
+}
+
+// Normalize records the call and returns the scripted Revision.
+func (c *Client) Normalize(ctx context.Context, projectName, repoName string,
+	revision centraldogma.Revision) (normalizedRev centraldogma.Revision, httpStatusCode int, err error) {
+	httpStatusCode, err = c.call("Normalize", []interface{}{projectName, repoName, revision}, &normalizedRev)
+	return
+}
+
+// ListFiles records the call and returns the scripted []*Entry.
+func (c *Client) ListFiles(ctx context.Context, projectName, repoName, revision,
+	pathPattern string) (entries []*centraldogma.Entry, httpStatusCode int, err error) {
+	httpStatusCode, err = c.call("ListFiles", []interface{}{projectName, repoName, revision, pathPattern},
+		&entries)
+	return
+}
+
+// ListFilesAt records the call and returns the scripted []*Entry.
+func (c *Client) ListFilesAt(ctx context.Context, projectName, repoName string, revision centraldogma.Revision,
+	pathPattern string) (entries []*centraldogma.Entry, httpStatusCode int, err error) {
+	httpStatusCode, err = c.call("ListFilesAt", []interface{}{projectName, repoName, revision, pathPattern},
+		&entries)
+	return
+}
+
+// GetFile records the call and returns the scripted *Entry.
+func (c *Client) GetFile(ctx context.Context, projectName, repoName, revision string,
+	query *centraldogma.Query) (entry *centraldogma.Entry, httpStatusCode int, err error) {
+	httpStatusCode, err = c.call("GetFile", []interface{}{projectName, repoName, revision, query}, &entry)
+	return
+}
+
+// GetFileAt records the call and returns the scripted *Entry.
+func (c *Client) GetFileAt(ctx context.Context, projectName, repoName string, revision centraldogma.Revision,
+	query *centraldogma.Query) (entry *centraldogma.Entry, httpStatusCode int, err error) {
+	httpStatusCode, err = c.call("GetFileAt", []interface{}{projectName, repoName, revision, query}, &entry)
+	return
+}
+
+// GetFiles records the call and returns the scripted []*Entry.
+func (c *Client) GetFiles(ctx context.Context, projectName, repoName, revision,
+	pathPattern string) (entries []*centraldogma.Entry, httpStatusCode int, err error) {
+	httpStatusCode, err = c.call("GetFiles", []interface{}{projectName, repoName, revision, pathPattern},
+		&entries)
+	return
+}
+
+// GetFilesAt records the call and returns the scripted []*Entry.
+func (c *Client) GetFilesAt(ctx context.Context, projectName, repoName string, revision centraldogma.Revision,
+	pathPattern string) (entries []*centraldogma.Entry, httpStatusCode int, err error) {
+	httpStatusCode, err = c.call("GetFilesAt", []interface{}{projectName, repoName, revision, pathPattern},
+		&entries)
+	return
+}
+
+// GetHistory records the call and returns the scripted []*Commit.
+func (c *Client) GetHistory(ctx context.Context, projectName, repoName, from, to, pathPattern string,
+	maxCommits int) (commits []*centraldogma.Commit, httpStatusCode int, err error) {
+	httpStatusCode, err = c.call("GetHistory",
+		[]interface{}{projectName, repoName, from, to, pathPattern, maxCommits}, &commits)
+	return
+}
+
+// GetHistoryBetween records the call and returns the scripted []*Commit.
+func (c *Client) GetHistoryBetween(ctx context.Context, projectName, repoName string, from, to centraldogma.Revision,
+	pathPattern string, maxCommits int) (commits []*centraldogma.Commit, httpStatusCode int, err error) {
+	httpStatusCode, err = c.call("GetHistoryBetween",
+		[]interface{}{projectName, repoName, from, to, pathPattern, maxCommits}, &commits)
+	return
+}
+
+// IterateHistory records the call and returns the HistoryIterator of the scripted []*Commit, i.e. the history
+// is scripted with Return("IterateHistory", commits). The iterator returns the commits between the revisions
+// of the opts which match its author and time range, but it does not filter them by the path pattern.
+// The iterator fails with the error if the history is scripted with Fail.
+func (c *Client) IterateHistory(ctx context.Context, projectName, repoName string,
+	opts *centraldogma.HistoryOptions) *centraldogma.HistoryIterator {
+	var commits []*centraldogma.Commit
+	_, err := c.call("IterateHistory", []interface{}{projectName, repoName, opts}, &commits)
+	return centraldogma.NewHistoryIterator(ctx, opts, func(ctx context.Context, from, to centraldogma.Revision,
+		pathPattern string, maxCommits int) ([]*centraldogma.Commit, error) {
+		if err != nil {
+			return nil, err
+		}
+		return historyBetween(commits, from, to, maxCommits), nil
+	})
+}
+
+// GetCommit records the call and returns the scripted *CommitDetail.
+func (c *Client) GetCommit(ctx context.Context, projectName, repoName string,
+	revision centraldogma.Revision) (detail *centraldogma.CommitDetail, httpStatusCode int, err error) {
+	httpStatusCode, err = c.call("GetCommit", []interface{}{projectName, repoName, revision}, &detail)
+	return
+}
+
+// GetDiff records the call and returns the scripted *Change.
+func (c *Client) GetDiff(ctx context.Context, projectName, repoName, from, to string,
+	query *centraldogma.Query) (change *centraldogma.Change, httpStatusCode int, err error) {
+	httpStatusCode, err = c.call("GetDiff", []interface{}{projectName, repoName, from, to, query}, &change)
+	return
+}
+
+// GetDiffBetween records the call and returns the scripted *Change.
+func (c *Client) GetDiffBetween(ctx context.Context, projectName, repoName string, from, to centraldogma.Revision,
+	query *centraldogma.Query) (change *centraldogma.Change, httpStatusCode int, err error) {
+	httpStatusCode, err = c.call("GetDiffBetween", []interface{}{projectName, repoName, from, to, query}, &change)
+	return
+}
+
+// GetDiffs records the call and returns the scripted []*Change.
+func (c *Client) GetDiffs(ctx context.Context, projectName, repoName, from, to,
+	pathPattern string) (changes []*centraldogma.Change, httpStatusCode int, err error) {
+	httpStatusCode, err = c.call("GetDiffs", []interface{}{projectName, repoName, from, to, pathPattern},
+		&changes)
+	return
+}
+
+// GetDiffsBetween records the call and returns the scripted []*Change.
+func (c *Client) GetDiffsBetween(ctx context.Context, projectName, repoName string, from, to centraldogma.Revision,
+	pathPattern string) (changes []*centraldogma.Change, httpStatusCode int, err error) {
+	httpStatusCode, err = c.call("GetDiffsBetween", []interface{}{projectName, repoName, from, to, pathPattern},
+		&changes)
+	return
+}
+
+// Blame records the call and returns the scripted *Blame.
+func (c *Client) Blame(ctx context.Context, projectName, repoName, path string,
+	revision centraldogma.Revision) (blame *centraldogma.Blame, httpStatusCode int, err error) {
+	httpStatusCode, err = c.call("Blame", []interface{}{projectName, repoName, path, revision}, &blame)
+	return
+}
+
+// MergeFiles records the call and returns the scripted *MergedEntry.
+func (c *Client) MergeFiles(ctx context.Context, projectName, repoName string, revision centraldogma.Revision,
+	mergeQuery *centraldogma.MergeQuery) (mergedEntry *centraldogma.MergedEntry, httpStatusCode int, err error) {
+	httpStatusCode, err = c.call("MergeFiles", []interface{}{projectName, repoName, revision, mergeQuery},
+		&mergedEntry)
+	return
+}
+
+// Push records the call and returns the scripted *PushResult.
+func (c *Client) Push(ctx context.Context, projectName, repoName, baseRevision string,
+	commitMessage *centraldogma.CommitMessage,
+	changes []*centraldogma.Change) (result *centraldogma.PushResult, httpStatusCode int, err error) {
+	httpStatusCode, err = c.call("Push",
+		[]interface{}{projectName, repoName, baseRevision, commitMessage, changes}, &result)
+	return
+}
+
+// PushAt records the call and returns the scripted *PushResult.
+func (c *Client) PushAt(ctx context.Context, projectName, repoName string, baseRevision centraldogma.Revision,
+	commitMessage *centraldogma.CommitMessage,
+	changes []*centraldogma.Change) (result *centraldogma.PushResult, httpStatusCode int, err error) {
+	httpStatusCode, err = c.call("PushAt",
+		[]interface{}{projectName, repoName, baseRevision, commitMessage, changes}, &result)
+	return
+}
+
+// PatchJSON records the call and returns the scripted *PushResult.
+func (c *Client) PatchJSON(ctx context.Context, projectName, repoName string, baseRevision centraldogma.Revision,
+	commitMessage *centraldogma.CommitMessage, path string,
+	patch jsonpatch.Patch) (result *centraldogma.PushResult, httpStatusCode int, err error) {
+	httpStatusCode, err = c.call("PatchJSON",
+		[]interface{}{projectName, repoName, baseRevision, commitMessage, path, patch}, &result)
+	return
+}
+
+// PatchText records the call and returns the scripted *PushResult.
+func (c *Client) PatchText(ctx context.Context, projectName, repoName string, baseRevision centraldogma.Revision,
+	commitMessage *centraldogma.CommitMessage, path string,
+	patch string) (result *centraldogma.PushResult, httpStatusCode int, err error) {
+	httpStatusCode, err = c.call("PatchText",
+		[]interface{}{projectName, repoName, baseRevision, commitMessage, path, patch}, &result)
+	return
+}
+
+// Update passes the scripted current *Entry to the updateFunc and returns the scripted *PushResult, i.e.
+// the update is scripted with Return("Update", current, result). The call is recorded with the changes
+// returned by the updateFunc instead of the updateFunc itself. Like Client.Update, it returns a nil result
+// if the updateFunc returns no changes.
+func (c *Client) Update(ctx context.Context, projectName, repoName, path string,
+	commitMessage *centraldogma.CommitMessage,
+	updateFunc centraldogma.UpdateFunc) (result *centraldogma.PushResult, httpStatusCode int, err error) {
+	r, err := c.next("Update")
+	if err != nil {
+		c.record("Update", projectName, repoName, path, commitMessage, []*centraldogma.Change(nil))
+		return nil, centraldogma.UnknownHttpStatusCode, err
+	}
+	if r.err != nil {
+		c.record("Update", projectName, repoName, path, commitMessage, []*centraldogma.Change(nil))
+		return nil, r.httpStatusCode, r.err
+	}
+
+	var current *centraldogma.Entry
+	if err := r.scan("Update", &current, &result); err != nil {
+		c.record("Update", projectName, repoName, path, commitMessage, []*centraldogma.Change(nil))
+		return nil, centraldogma.UnknownHttpStatusCode, err
+	}
+	changes, err := updateFunc(current)
+	c.record("Update", projectName, repoName, path, commitMessage, changes)
+	if err != nil {
+		return nil, centraldogma.UnknownHttpStatusCode, err
+	}
+	if len(changes) == 0 {
+		return nil, r.httpStatusCode, nil
+	}
+	return result, r.httpStatusCode, nil
+}
+
+// Revert records the call and returns the scripted *PushResult.
+func (c *Client) Revert(ctx context.Context, projectName, repoName string, revision centraldogma.Revision,
+	commitMessage *centraldogma.CommitMessage) (result *centraldogma.PushResult, httpStatusCode int, err error) {
+	httpStatusCode, err = c.call("Revert", []interface{}{projectName, repoName, revision, commitMessage}, &result)
+	return
+}
+
+// Rollback records the call and returns the scripted *PushResult.
+func (c *Client) Rollback(ctx context.Context, projectName, repoName, pathPattern string,
+	toRevision centraldogma.Revision) (result *centraldogma.PushResult, httpStatusCode int, err error) {
+	httpStatusCode, err = c.call("Rollback", []interface{}{projectName, repoName, pathPattern, toRevision},
+		&result)
+	return
+}
+
+// WatchFile records the call and returns the channel which receives the watch results of the path of
+// the query added by AddWatchResults.
+func (c *Client) WatchFile(ctx context.Context, projectName, repoName string, query *centraldogma.Query,
+	timeout time.Duration) (result <-chan centraldogma.WatchResult, closer func(), err error) {
+	c.record("WatchFile", projectName, repoName, query, timeout)
+	if query == nil {
+		return nil, nil, centraldogma.ErrQueryMustBeSet
+	}
+	result, closer = centraldogma.WatchChannel(centraldogma.NewWatcher(ctx, projectName, repoName, query.Path,
+		c.watchFunc(query.Path)))
+	return result, closer, nil
+}
+
+// WatchRepository records the call and returns the channel which receives the watch results of
+// the pathPattern added by AddWatchResults.
+func (c *Client) WatchRepository(ctx context.Context, projectName, repoName, pathPattern string,
+	timeout time.Duration) (result <-chan centraldogma.WatchResult, closer func(), err error) {
+	c.record("WatchRepository", projectName, repoName, pathPattern, timeout)
+	result, closer = centraldogma.WatchChannel(centraldogma.NewWatcher(ctx, projectName, repoName, pathPattern,
+		c.watchFunc(pathPattern)))
+	return result, closer, nil
+}
+
+// FileWatcher records the call and returns a Watcher which notifies the watch results of the path of
+// the query added by AddWatchResults.
+func (c *Client) FileWatcher(projectName, repoName string, query *centraldogma.Query) (*centraldogma.Watcher, error) {
+	c.record("FileWatcher", projectName, repoName, query)
+	if query == nil {
+		return nil, centraldogma.ErrQueryMustBeSet
+	}
+	return centraldogma.NewWatcher(context.Background(), projectName, repoName, query.Path,
+		c.watchFunc(query.Path)), nil
+}
+
+// RepoWatcher records the call and returns a Watcher which notifies the watch results of the pathPattern
+// added by AddWatchResults.
+func (c *Client) RepoWatcher(projectName, repoName, pathPattern string) (*centraldogma.Watcher, error) {
+	c.record("RepoWatcher", projectName, repoName, pathPattern)
+	return centraldogma.NewWatcher(context.Background(), projectName, repoName, pathPattern,
+		c.watchFunc(pathPattern)), nil
+}
+
+// MergeWatcher records the call and returns a Watcher which notifies the watch results of
+// the comma-separated paths of the sources added by AddWatchResults, e.g. "/base.json,/prod.json".
+func (c *Client) MergeWatcher(projectName, repoName string,
+	mergeQuery *centraldogma.MergeQuery) (*centraldogma.Watcher, error) {
+	c.record("MergeWatcher", projectName, repoName, mergeQuery)
+	if mergeQuery == nil || len(mergeQuery.Sources) == 0 {
+		return nil, centraldogma.ErrQueryMustBeSet
+	}
+	paths := make([]string, len(mergeQuery.Sources))
+	for i, source := range mergeQuery.Sources {
+		paths[i] = path.Join("/", source.Path)
+	}
+	pathPattern := strings.Join(paths, ",")
+	return centraldogma.NewWatcher(context.Background(), projectName, repoName, pathPattern,
+		c.watchFunc(pathPattern)), nil
+}
+
+// historyBetween returns at most maxCommits commits from the from revision to the to revision, both inclusive.
+// The relative revisions are relative to the latest revision of the commits.
+func historyBetween(commits []*centraldogma.Commit, from, to centraldogma.Revision,
+	maxCommits int) []*centraldogma.Commit {
+	latest := 0
+	for _, commit := range commits {
+		if commit.Revision > latest {
+			latest = commit.Revision
+		}
+	}
+	absolute := func(revision centraldogma.Revision) int {
+		if revision.IsRelative() {
+			return latest + int(revision) + 1
+		}
+		return int(revision)
+	}
+	fromRev, toRev := absolute(from), absolute(to)
+	low, high := fromRev, toRev
+	if low > high {
+		low, high = high, low
+	}
+
+	var history []*centraldogma.Commit
+	for _, commit := range commits {
+		if commit.Revision >= low && commit.Revision <= high {
+			history = append(history, commit)
+		}
+	}
+	sort.Slice(history, func(i, j int) bool {
+		if fromRev > toRev {
+			return history[i].Revision > history[j].Revision
+		}
+		return history[i].Revision < history[j].Revision
+	})
+	if maxCommits > 0 && len(history) > maxCommits {
+		history = history[:maxCommits]
+	}
+	return history
+}
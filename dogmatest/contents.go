@@ -0,0 +1,486 @@
+// Copyright 2026 LINE Corporation
+//
+// LINE Corporation licenses this file to you under the Apache License,
+// version 2.0 (the "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at:
+//
+//   https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+// WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+// License for the specific language governing permissions and limitations
+// under the License.
+
+package dogmatest
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"go.linecorp.com/centraldogma"
+	"go.linecorp.com/centraldogma/internal/pathpattern"
+	"go.linecorp.com/centraldogma/jsonpath"
+)
+
+const (
+	defaultMaxCommits = 100
+	maxMaxCommits     = 1000
+)
+
+// entry is the JSON representation of an entry in a repository.
+type entry struct {
+	Path       string      `json:"path"`
+	Type       string      `json:"type"`
+	Content    interface{} `json:"content,omitempty"`
+	Revision   int         `json:"revision"`
+	URL        string      `json:"url"`
+	ModifiedAt string      `json:"modifiedAt,omitempty"`
+}
+
+type pushRequest struct {
+	CommitMessage *centraldogma.CommitMessage `json:"commitMessage"`
+	Changes       []*centraldogma.Change      `json:"changes"`
+}
+
+// serveContents serves the APIs of a repository, i.e. projects/{project}/repos/{repo}/{action}{rest}.
+func (s *Server) serveContents(r *http.Request,
+	projectName, repoName, action, rest string) (int, interface{}, error) {
+	if action == "contents" && r.Method == http.MethodGet && len(r.Header.Get("If-None-Match")) != 0 {
+		return s.watch(r, projectName, repoName, rest)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	repo, err := s.repository(projectName, repoName)
+	if err != nil {
+		return 0, nil, err
+	}
+	urlPrefix := pathPrefix + "projects/" + projectName + "/repos/" + repoName + "/contents"
+	q := r.URL.Query()
+
+	switch {
+	case action == "revision" && r.Method == http.MethodGet:
+		rev, err := repo.normalize(strings.TrimPrefix(rest, "/"))
+		if err != nil {
+			return 0, nil, err
+		}
+		return http.StatusOK, map[string]int{"revision": rev}, nil
+
+	case action == "list" && r.Method == http.MethodGet:
+		rev, err := repo.normalize(q.Get("revision"))
+		if err != nil {
+			return 0, nil, err
+		}
+		return listEntries(repo, rev, rest, urlPrefix)
+
+	case action == "contents" && r.Method == http.MethodGet:
+		rev, err := repo.normalize(q.Get("revision"))
+		if err != nil {
+			return 0, nil, err
+		}
+		if pathpattern.IsPattern(rest) || len(rest) == 0 {
+			return http.StatusOK, getEntries(repo, rev, rest, urlPrefix), nil
+		}
+		e, err := getEntry(repo, rev, rest, q["jsonpath"], urlPrefix)
+		if err != nil {
+			return 0, nil, err
+		}
+		return http.StatusOK, e, nil
+
+	case action == "contents" && r.Method == http.MethodPost:
+		return s.servePush(r, repo)
+
+	case action == "commits" && r.Method == http.MethodGet:
+		return serveHistory(repo, strings.TrimPrefix(rest, "/"), q)
+
+	case action == "compare" && r.Method == http.MethodGet:
+		return serveCompare(repo, q)
+
+	case action == "merge" && r.Method == http.MethodGet:
+		return serveMerge(repo, r.URL.RawQuery)
+	}
+	return 0, nil, methodNotAllowed(r)
+}
+
+func listEntries(repo *repository, rev int, pattern, urlPrefix string) (int, interface{}, error) {
+	if len(pattern) == 0 {
+		pattern = "/**"
+	}
+	p, err := pathpattern.Compile(pattern)
+	if err != nil {
+		return 0, nil, newHTTPError(http.StatusBadRequest, "IllegalArgumentException", "invalid pattern: %v", err)
+	}
+
+	files := repo.at(rev).files
+	entries := make([]*entry, 0)
+	for dir := range directories(files) {
+		if p.Match(dir) {
+			entries = append(entries, &entry{Path: dir, Type: "DIRECTORY", Revision: rev, URL: urlPrefix + dir})
+		}
+	}
+	for path, f := range files {
+		if p.Match(path) {
+			entries = append(entries, &entry{Path: path, Type: entryType(f), Revision: rev, URL: urlPrefix + path})
+		}
+	}
+	sort.Slice(entries, func(i, j int) bool { return entries[i].Path < entries[j].Path })
+	return http.StatusOK, entries, nil
+}
+
+func getEntries(repo *repository, rev int, pattern, urlPrefix string) []*entry {
+	p, err := pathpattern.Compile(pattern)
+	entries := make([]*entry, 0)
+	if err != nil {
+		return entries
+	}
+	files := repo.at(rev).files
+	for path, f := range files {
+		if p.Match(path) {
+			entries = append(entries, newEntry(repo, rev, path, f, f.content, urlPrefix))
+		}
+	}
+	sort.Slice(entries, func(i, j int) bool { return entries[i].Path < entries[j].Path })
+	return entries
+}
+
+// getEntry returns the entry of the file, whose content is the result of the JSON path expressions if any.
+func getEntry(repo *repository, rev int, path string, jsonPaths []string, urlPrefix string) (*entry, error) {
+	f := repo.at(rev).files[path]
+	if f == nil {
+		return nil, entryNotFound(path, rev)
+	}
+	content, err := query(path, f, jsonPaths)
+	if err != nil {
+		return nil, err
+	}
+	return newEntry(repo, rev, path, f, content, urlPrefix), nil
+}
+
+func query(path string, f *file, jsonPaths []string) (interface{}, error) {
+	if len(jsonPaths) == 0 {
+		return f.content, nil
+	}
+	if !f.isJSON {
+		return nil, newHTTPError(http.StatusBadRequest, "QueryExecutionException", "%s is not a JSON file", path)
+	}
+	result, err := jsonpath.Eval(f.content, jsonPaths...)
+	if err != nil {
+		return nil, newHTTPError(http.StatusBadRequest, "QueryExecutionException",
+			"failed to query %s: %v", path, err)
+	}
+	return result, nil
+}
+
+func newEntry(repo *repository, rev int, path string, f *file, content interface{}, urlPrefix string) *entry {
+	return &entry{
+		Path:       path,
+		Type:       entryType(f),
+		Content:    content,
+		Revision:   rev,
+		URL:        urlPrefix + path,
+		ModifiedAt: repo.lastModified(path, rev).PushedAt,
+	}
+}
+
+func entryType(f *file) string {
+	if f.isJSON {
+		return centraldogma.JSON.String()
+	}
+	return centraldogma.Text.String()
+}
+
+func (s *Server) servePush(r *http.Request, repo *repository) (int, interface{}, error) {
+	baseRevision, err := repo.normalize(r.URL.Query().Get("revision"))
+	if err != nil {
+		return 0, nil, err
+	}
+	var req pushRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		return 0, nil, newHTTPError(http.StatusBadRequest, "IllegalArgumentException", "invalid push: %v", err)
+	}
+	if req.CommitMessage == nil || len(req.CommitMessage.Summary) == 0 {
+		return 0, nil, newHTTPError(http.StatusBadRequest, "IllegalArgumentException",
+			"the summary of the commit message is empty")
+	}
+
+	c, err := s.push(repo, baseRevision, *req.CommitMessage, req.Changes)
+	if err != nil {
+		return 0, nil, err
+	}
+	return http.StatusOK, &centraldogma.PushResult{Revision: c.Revision, PushedAt: c.PushedAt}, nil
+}
+
+// serveHistory returns the commits like the server does:
+//   - If only the from is specified, the commit at the from is returned.
+//   - If only the to is specified, the commits from the latest revision to the to are returned.
+//   - If both are specified, the commits from the from to the to are returned.
+//   - If neither is specified, the commits from the latest revision to the initial revision are returned.
+func serveHistory(repo *repository, from string, q url.Values) (int, interface{}, error) {
+	to := q.Get("to")
+	if len(from) != 0 && len(to) == 0 {
+		to = from
+	} else if len(to) == 0 {
+		to = "1"
+	}
+	fromRev, err := repo.normalize(from)
+	if err != nil {
+		return 0, nil, err
+	}
+	toRev, err := repo.normalize(to)
+	if err != nil {
+		return 0, nil, err
+	}
+
+	maxCommits := defaultMaxCommits
+	if v := q.Get("maxCommits"); len(v) != 0 {
+		if maxCommits, err = strconv.Atoi(v); err != nil || maxCommits <= 0 {
+			return 0, nil, newHTTPError(http.StatusBadRequest, "IllegalArgumentException",
+				"invalid maxCommits: %q", v)
+		}
+		if maxCommits > maxMaxCommits {
+			maxCommits = maxMaxCommits
+		}
+	}
+	pattern, err := pathpattern.Compile(q.Get("path"))
+	if err != nil {
+		return 0, nil, newHTTPError(http.StatusBadRequest, "IllegalArgumentException", "invalid path: %v", err)
+	}
+	return http.StatusOK, repo.history(fromRev, toRev, pattern, maxCommits), nil
+}
+
+func serveCompare(repo *repository, q url.Values) (int, interface{}, error) {
+	from := q.Get("from")
+	if len(from) == 0 {
+		from = "1"
+	}
+	fromRev, err := repo.normalize(from)
+	if err != nil {
+		return 0, nil, err
+	}
+	toRev, err := repo.normalize(q.Get("to"))
+	if err != nil {
+		return 0, nil, err
+	}
+
+	if path := q.Get("path"); len(path) != 0 {
+		oldFile, newFile := repo.at(fromRev).files[path], repo.at(toRev).files[path]
+		if oldFile == nil && newFile == nil {
+			return 0, nil, entryNotFound(path, toRev)
+		}
+		if jsonPaths := q["jsonpath"]; len(jsonPaths) != 0 {
+			if oldFile, err = queryFile(path, oldFile, jsonPaths); err != nil {
+				return 0, nil, err
+			}
+			if newFile, err = queryFile(path, newFile, jsonPaths); err != nil {
+				return 0, nil, err
+			}
+		}
+		return http.StatusOK, diff(path, oldFile, newFile), nil
+	}
+
+	pattern, err := pathpattern.Compile(q.Get("pathPattern"))
+	if err != nil {
+		return 0, nil, newHTTPError(http.StatusBadRequest, "IllegalArgumentException",
+			"invalid pathPattern: %v", err)
+	}
+	return http.StatusOK, repo.diffs(fromRev, toRev, pattern), nil
+}
+
+// queryFile returns the JSON file whose content is the result of the JSON path expressions.
+func queryFile(path string, f *file, jsonPaths []string) (*file, error) {
+	if f == nil {
+		return nil, nil
+	}
+	content, err := query(path, f, jsonPaths)
+	if err != nil {
+		return nil, err
+	}
+	return &file{isJSON: true, content: content}, nil
+}
+
+// serveMerge merges the JSON files in the order of the "path" and "optional_path" params, so the raw query
+// is parsed here rather than with url.ParseQuery which loses the order.
+func serveMerge(repo *repository, rawQuery string) (int, interface{}, error) {
+	var (
+		sources   []string
+		optional  = make(map[string]bool)
+		jsonPaths []string
+		revision  string
+	)
+	for _, param := range strings.Split(rawQuery, "&") {
+		kv := strings.SplitN(param, "=", 2)
+		if len(kv) != 2 {
+			continue
+		}
+		value, err := url.QueryUnescape(kv[1])
+		if err != nil {
+			return 0, nil, newHTTPError(http.StatusBadRequest, "IllegalArgumentException", "invalid query: %v", err)
+		}
+		switch kv[0] {
+		case "path", "optional_path":
+			sources = append(sources, value)
+			optional[value] = kv[0] == "optional_path"
+		case "jsonpath":
+			jsonPaths = append(jsonPaths, value)
+		case "revision":
+			revision = value
+		}
+	}
+	if len(sources) == 0 {
+		return 0, nil, newHTTPError(http.StatusBadRequest, "IllegalArgumentException", "no paths to merge")
+	}
+	rev, err := repo.normalize(revision)
+	if err != nil {
+		return 0, nil, err
+	}
+
+	var merged interface{}
+	paths := make([]string, 0, len(sources))
+	for _, path := range sources {
+		f := repo.at(rev).files[path]
+		if f == nil {
+			if optional[path] {
+				continue
+			}
+			return 0, nil, entryNotFound(path, rev)
+		}
+		if !f.isJSON {
+			return 0, nil, newHTTPError(http.StatusBadRequest, "QueryExecutionException",
+				"%s is not a JSON file", path)
+		}
+		merged = mergeJSON(merged, f.content)
+		paths = append(paths, path)
+	}
+	if len(paths) == 0 {
+		return 0, nil, newHTTPError(http.StatusNotFound, "EntryNotFoundException",
+			"no files to merge at the revision %d", rev)
+	}
+
+	content, err := query(strings.Join(paths, ","), &file{isJSON: true, content: merged}, jsonPaths)
+	if err != nil {
+		return 0, nil, err
+	}
+	return http.StatusOK, map[string]interface{}{
+		"revision": rev, "type": centraldogma.JSON.String(), "content": content, "paths": paths,
+	}, nil
+}
+
+// mergeJSON merges the source into the target. The members of objects are merged recursively and the other
+// values of the target are replaced.
+func mergeJSON(target, source interface{}) interface{} {
+	targetObject, ok := target.(map[string]interface{})
+	sourceObject, ok2 := source.(map[string]interface{})
+	if !ok || !ok2 {
+		return source
+	}
+	merged := make(map[string]interface{}, len(targetObject)+len(sourceObject))
+	for k, v := range targetObject {
+		merged[k] = v
+	}
+	for k, v := range sourceObject {
+		merged[k] = mergeJSON(merged[k], v)
+	}
+	return merged
+}
+
+// watch waits until the file or the files which match the pattern are modified after the revision in
+// the If-None-Match header, for the duration in the Prefer header, e.g. "wait=60".
+func (s *Server) watch(r *http.Request, projectName, repoName, path string) (int, interface{}, error) {
+	timeout := defaultWatchTimeout
+	if prefer := r.Header.Get("Prefer"); strings.HasPrefix(prefer, "wait=") {
+		seconds, err := strconv.ParseFloat(strings.TrimPrefix(prefer, "wait="), 64)
+		if err != nil {
+			return 0, nil, newHTTPError(http.StatusBadRequest, "IllegalArgumentException",
+				"invalid Prefer header: %q", prefer)
+		}
+		timeout = time.Duration(seconds * float64(time.Second))
+	}
+	timer := time.NewTimer(timeout)
+	defer timer.Stop()
+
+	lastKnown, err := strconv.Atoi(r.Header.Get("If-None-Match"))
+	if err != nil {
+		return 0, nil, newHTTPError(http.StatusBadRequest, "IllegalArgumentException",
+			"invalid If-None-Match header: %q", r.Header.Get("If-None-Match"))
+	}
+	jsonPaths := r.URL.Query()["jsonpath"]
+	urlPrefix := pathPrefix + "projects/" + projectName + "/repos/" + repoName + "/contents"
+	for {
+		s.mu.Lock()
+		updated := s.updated
+		repo, err := s.repository(projectName, repoName)
+		var result interface{}
+		if err == nil {
+			result, err = checkWatch(repo, lastKnown, path, jsonPaths, urlPrefix)
+		}
+		s.mu.Unlock()
+		if err != nil {
+			return 0, nil, err
+		}
+		if result != nil {
+			return http.StatusOK, result, nil
+		}
+
+		select {
+		case <-updated:
+		case <-timer.C:
+			return http.StatusNotModified, nil, nil
+		case <-s.closed:
+			return http.StatusNotModified, nil, nil
+		case <-r.Context().Done():
+			return http.StatusNotModified, nil, nil
+		}
+	}
+}
+
+// checkWatch returns the result of the watch request if the file or the files which match the pattern were
+// modified after the last known revision. It returns nil otherwise.
+func checkWatch(repo *repository, lastKnown int, path string, jsonPaths []string,
+	urlPrefix string) (interface{}, error) {
+	head := repo.head()
+	if lastKnown < 0 {
+		lastKnown = head + lastKnown + 1
+	}
+	if lastKnown < 1 {
+		lastKnown = 1
+	}
+	if lastKnown >= head {
+		return nil, nil
+	}
+
+	if pathpattern.IsPattern(path) || len(path) == 0 {
+		pattern, err := pathpattern.Compile(path)
+		if err != nil {
+			return nil, newHTTPError(http.StatusBadRequest, "IllegalArgumentException", "invalid pattern: %v", err)
+		}
+		if !repo.modifiedSince(lastKnown, pattern) {
+			return nil, nil
+		}
+		return map[string]int{"revision": head}, nil
+	}
+
+	newFile := repo.at(head).files[path]
+	if newFile == nil {
+		return nil, nil
+	}
+	newContent, err := query(path, newFile, jsonPaths)
+	if err != nil {
+		return nil, err
+	}
+	if oldFile := repo.at(lastKnown).files[path]; oldFile != nil && oldFile.isJSON == newFile.isJSON {
+		if oldContent, err := query(path, oldFile, jsonPaths); err == nil && reflect.DeepEqual(oldContent, newContent) {
+			return nil, nil
+		}
+	}
+	return map[string]interface{}{
+		"revision": head,
+		"entry":    newEntry(repo, head, path, newFile, newContent, urlPrefix),
+	}, nil
+}
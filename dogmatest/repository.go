@@ -0,0 +1,367 @@
+// Copyright 2026 LINE Corporation
+//
+// LINE Corporation licenses this file to you under the Apache License,
+// version 2.0 (the "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at:
+//
+//   https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+// WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+// License for the specific language governing permissions and limitations
+// under the License.
+
+package dogmatest
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"go.linecorp.com/centraldogma"
+	"go.linecorp.com/centraldogma/internal/pathpattern"
+	"go.linecorp.com/centraldogma/jsonpatch"
+	"go.linecorp.com/centraldogma/textdiff"
+)
+
+type project struct {
+	name      string
+	createdAt time.Time
+	removed   bool
+	repos     map[string]*repository
+}
+
+type repository struct {
+	name      string
+	createdAt time.Time
+	removed   bool
+	commits   []*commit // commits[i] is the commit at the revision i+1
+}
+
+type commit struct {
+	centraldogma.Commit
+	files   map[string]*file // all files at the revision, which are never modified
+	changed []string         // the paths which the commit modified
+}
+
+// file is a JSON or text file. The content of a JSON file is a value decoded by encoding/json.
+type file struct {
+	isJSON  bool
+	content interface{}
+}
+
+// httpError is an error which is returned to the client with the HTTP status code.
+type httpError struct {
+	status    int
+	exception string
+	message   string
+}
+
+func (e *httpError) Error() string {
+	return e.message
+}
+
+func newHTTPError(status int, exception, format string, args ...interface{}) *httpError {
+	return &httpError{status: status, exception: exception, message: fmt.Sprintf(format, args...)}
+}
+
+func entryNotFound(path string, revision int) *httpError {
+	return newHTTPError(http.StatusNotFound, "EntryNotFoundException",
+		"%s does not exist at the revision %d", path, revision)
+}
+
+func changeConflict(format string, args ...interface{}) *httpError {
+	return newHTTPError(http.StatusConflict, "ChangeConflictException", format, args...)
+}
+
+func (r *repository) head() int {
+	return len(r.commits)
+}
+
+// normalize converts the revision string into an absolute revision. The latest revision is returned if
+// the revision is empty.
+func (r *repository) normalize(revision string) (int, error) {
+	if len(revision) == 0 {
+		return r.head(), nil
+	}
+	rev, err := strconv.Atoi(revision)
+	if err != nil || rev == 0 {
+		return 0, newHTTPError(http.StatusBadRequest, "IllegalArgumentException", "invalid revision: %q", revision)
+	}
+	if rev < 0 {
+		rev = r.head() + rev + 1
+	}
+	if rev < 1 || rev > r.head() {
+		return 0, newHTTPError(http.StatusNotFound, "RevisionNotFoundException",
+			"revision %s does not exist in %s", revision, r.name)
+	}
+	return rev, nil
+}
+
+func (r *repository) at(revision int) *commit {
+	return r.commits[revision-1]
+}
+
+func (r *repository) commit(author centraldogma.Author, message centraldogma.CommitMessage,
+	files map[string]*file, changed []string, now time.Time) *commit {
+	c := &commit{
+		Commit: centraldogma.Commit{
+			Revision:      r.head() + 1,
+			Author:        author,
+			CommitMessage: message,
+			PushedAt:      formatTime(now),
+		},
+		files:   files,
+		changed: changed,
+	}
+	r.commits = append(r.commits, c)
+	return c
+}
+
+// modifiedSince returns whether any path which matches the pattern was modified after the revision.
+func (r *repository) modifiedSince(revision int, pattern *pathpattern.Pattern) bool {
+	for _, c := range r.commits[revision:] {
+		for _, path := range c.changed {
+			if pattern.Match(path) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// push applies the changes to the files at the latest revision. It fails if a file which the changes touch
+// was modified after the base revision.
+func (r *repository) push(baseRevision int, changes []*centraldogma.Change) (map[string]*file, []string, error) {
+	if len(changes) == 0 {
+		return nil, nil, newHTTPError(http.StatusBadRequest, "IllegalArgumentException", "no changes")
+	}
+
+	files := make(map[string]*file, len(r.at(r.head()).files))
+	for path, f := range r.at(r.head()).files {
+		files[path] = f
+	}
+	var touched []string
+	for _, change := range changes {
+		if !strings.HasPrefix(change.Path, "/") {
+			return nil, nil, newHTTPError(http.StatusBadRequest, "IllegalArgumentException",
+				"invalid path: %q", change.Path)
+		}
+		touched = append(touched, change.Path)
+		if err := applyChange(files, change); err != nil {
+			return nil, nil, err
+		}
+		if change.Type == centraldogma.Rename {
+			touched = append(touched, fmt.Sprint(change.Content))
+		}
+	}
+
+	for _, path := range touched {
+		if r.modifiedSince(baseRevision, pathpattern.Exact(path)) {
+			return nil, nil, changeConflict("%s has been modified since the revision %d", path, baseRevision)
+		}
+	}
+
+	var changed []string
+	for path := range unionPaths(r.at(r.head()).files, files) {
+		if !reflect.DeepEqual(r.at(r.head()).files[path], files[path]) {
+			changed = append(changed, path)
+		}
+	}
+	if len(changed) == 0 {
+		return nil, nil, newHTTPError(http.StatusConflict, "RedundantChangeException",
+			"the changes do not modify any files")
+	}
+	sort.Strings(changed)
+	return files, changed, nil
+}
+
+func applyChange(files map[string]*file, change *centraldogma.Change) error {
+	current := files[change.Path]
+	switch change.Type {
+	case centraldogma.UpsertJSON:
+		content := change.Content
+		if s, ok := content.(string); ok {
+			if err := json.Unmarshal([]byte(s), &content); err != nil {
+				return newHTTPError(http.StatusBadRequest, "IllegalArgumentException",
+					"invalid JSON content of %s: %v", change.Path, err)
+			}
+		}
+		files[change.Path] = &file{isJSON: true, content: content}
+	case centraldogma.UpsertText:
+		text, ok := change.Content.(string)
+		if !ok {
+			return newHTTPError(http.StatusBadRequest, "IllegalArgumentException",
+				"the content of %s must be a string", change.Path)
+		}
+		files[change.Path] = &file{content: text}
+	case centraldogma.Remove:
+		if current == nil {
+			return changeConflict("%s does not exist", change.Path)
+		}
+		delete(files, change.Path)
+	case centraldogma.Rename:
+		target := fmt.Sprint(change.Content)
+		if current == nil {
+			return changeConflict("%s does not exist", change.Path)
+		}
+		if files[target] != nil {
+			return changeConflict("%s already exists", target)
+		}
+		delete(files, change.Path)
+		files[target] = current
+	case centraldogma.ApplyJSONPatch:
+		b, err := json.Marshal(change.Content)
+		if err != nil {
+			return err
+		}
+		patch, err := jsonpatch.ParsePatch(b)
+		if err != nil {
+			return newHTTPError(http.StatusBadRequest, "IllegalArgumentException",
+				"invalid JSON patch of %s: %v", change.Path, err)
+		}
+		var doc interface{}
+		if current != nil {
+			if !current.isJSON {
+				return changeConflict("%s is not a JSON file", change.Path)
+			}
+			doc = current.content
+		}
+		patched, err := patch.Apply(doc)
+		if err != nil {
+			return changeConflict("failed to apply the JSON patch to %s: %v", change.Path, err)
+		}
+		files[change.Path] = &file{isJSON: true, content: patched}
+	case centraldogma.ApplyTextPatch:
+		var text string
+		if current != nil {
+			if current.isJSON {
+				return changeConflict("%s is not a text file", change.Path)
+			}
+			text = current.content.(string)
+		}
+		patched, err := textdiff.Apply(text, fmt.Sprint(change.Content))
+		if err != nil {
+			return changeConflict("failed to apply the text patch to %s: %v", change.Path, err)
+		}
+		files[change.Path] = &file{content: patched}
+	default:
+		return newHTTPError(http.StatusBadRequest, "IllegalArgumentException",
+			"unknown change type of %s: %v", change.Path, change.Type)
+	}
+	return nil
+}
+
+// diff returns the change which transforms the old file into the new file at the path. It returns nil if
+// both are nil.
+func diff(path string, oldFile, newFile *file) *centraldogma.Change {
+	switch {
+	case newFile == nil && oldFile == nil:
+		return nil
+	case newFile == nil:
+		return &centraldogma.Change{Path: path, Type: centraldogma.Remove}
+	case oldFile == nil || oldFile.isJSON != newFile.isJSON:
+		if newFile.isJSON {
+			return &centraldogma.Change{Path: path, Type: centraldogma.UpsertJSON, Content: newFile.content}
+		}
+		return &centraldogma.Change{Path: path, Type: centraldogma.UpsertText, Content: newFile.content}
+	case newFile.isJSON:
+		return &centraldogma.Change{Path: path, Type: centraldogma.ApplyJSONPatch,
+			Content: jsonpatch.Diff(oldFile.content, newFile.content, jsonpatch.Safe)}
+	default:
+		return &centraldogma.Change{Path: path, Type: centraldogma.ApplyTextPatch,
+			Content: textdiff.Diff(path, oldFile.content.(string), newFile.content.(string))}
+	}
+}
+
+// diffs returns the changes of the files which match the pattern between the two revisions, sorted by path.
+func (r *repository) diffs(from, to int, pattern *pathpattern.Pattern) []*centraldogma.Change {
+	oldFiles, newFiles := r.at(from).files, r.at(to).files
+	paths := make([]string, 0)
+	for path := range unionPaths(oldFiles, newFiles) {
+		if pattern.Match(path) && !reflect.DeepEqual(oldFiles[path], newFiles[path]) {
+			paths = append(paths, path)
+		}
+	}
+	sort.Strings(paths)
+
+	changes := make([]*centraldogma.Change, 0, len(paths))
+	for _, path := range paths {
+		changes = append(changes, diff(path, oldFiles[path], newFiles[path]))
+	}
+	return changes
+}
+
+// history returns the commits between the two revisions which modified the files that match the pattern,
+// in the order from the from to the to.
+func (r *repository) history(from, to int, pattern *pathpattern.Pattern, maxCommits int) []*centraldogma.Commit {
+	step := 1
+	if from > to {
+		step = -1
+	}
+	commits := make([]*centraldogma.Commit, 0)
+	for rev := from; len(commits) < maxCommits; rev += step {
+		c := r.at(rev)
+		if pattern.MatchesAll() || r.touches(c, pattern) {
+			copied := c.Commit
+			commits = append(commits, &copied)
+		}
+		if rev == to {
+			break
+		}
+	}
+	return commits
+}
+
+func (r *repository) touches(c *commit, pattern *pathpattern.Pattern) bool {
+	for _, path := range c.changed {
+		if pattern.Match(path) {
+			return true
+		}
+	}
+	return false
+}
+
+// lastModified returns the commit which last modified the file at the path until the revision.
+func (r *repository) lastModified(path string, revision int) *commit {
+	for rev := revision; rev > 1; rev-- {
+		for _, changed := range r.at(rev).changed {
+			if changed == path {
+				return r.at(rev)
+			}
+		}
+	}
+	return r.at(1)
+}
+
+// directories returns the directories of the files.
+func directories(files map[string]*file) map[string]bool {
+	dirs := make(map[string]bool)
+	for path := range files {
+		for i := strings.LastIndex(path, "/"); i > 0; i = strings.LastIndex(path[:i], "/") {
+			dirs[path[:i]] = true
+		}
+	}
+	return dirs
+}
+
+func unionPaths(a, b map[string]*file) map[string]bool {
+	paths := make(map[string]bool, len(a)+len(b))
+	for path := range a {
+		paths[path] = true
+	}
+	for path := range b {
+		paths[path] = true
+	}
+	return paths
+}
+
+func formatTime(t time.Time) string {
+	return t.UTC().Format("2006-01-02T15:04:05.000Z")
+}
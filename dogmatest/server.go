@@ -0,0 +1,464 @@
+// Copyright 2026 LINE Corporation
+//
+// LINE Corporation licenses this file to you under the Apache License,
+// version 2.0 (the "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at:
+//
+//   https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+// WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+// License for the specific language governing permissions and limitations
+// under the License.
+
+// Package dogmatest provides an in-memory fake Central Dogma server for testing.
+//
+// The Server stores projects, repositories and revisioned files in memory and implements the parts of the
+// Central Dogma API which centraldogma.Client uses to read, push, diff and watch files, including the
+// long-polling watch requests and JSON path queries. For example:
+//
+//	server := dogmatest.NewServer()
+//	defer server.Close()
+//	server.CreateRepository("foo", "bar")
+//	server.UpsertFile("foo", "bar", "/a.json", `{"a":1}`)
+//
+//	client := server.Client()
+//	entry, _, err := client.GetFile(ctx, "foo", "bar", "-1", &centraldogma.Query{Path: "/a.json"})
+package dogmatest
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"go.linecorp.com/centraldogma"
+)
+
+const (
+	pathPrefix = "/api/v1/"
+
+	// metaRepo is the repository which is created along with a project.
+	metaRepo = "meta"
+
+	defaultWatchTimeout = 60 * time.Second
+)
+
+// Server is an in-memory fake Central Dogma server. It is safe for concurrent use.
+type Server struct {
+	// URL is the base URL of the server, e.g. "http://127.0.0.1:36651".
+	URL string
+
+	httpServer *httptest.Server
+
+	mu sync.Mutex
+	// author is the author of the commits pushed to the server.
+	author   centraldogma.Author
+	now      func() time.Time
+	projects map[string]*project
+	// updated is closed and replaced whenever a commit is pushed, which wakes up the watch requests.
+	updated chan struct{}
+
+	// closed is closed by Close, which answers the pending watch requests.
+	closed    chan struct{}
+	closeOnce sync.Once
+}
+
+// NewServer starts and returns a new Server which has no projects. The caller should call Close when
+// finished, to shut it down.
+func NewServer() *Server {
+	s := NewUnstartedServer()
+	s.Start()
+	return s
+}
+
+// NewUnstartedServer returns a new Server which is not started yet, so that it can be configured before
+// starting it with Start.
+func NewUnstartedServer() *Server {
+	s := &Server{
+		author:   centraldogma.Author{Name: "dogmatest", Email: "dogmatest@localhost.localdomain"},
+		now:      time.Now,
+		projects: make(map[string]*project),
+		updated:  make(chan struct{}),
+		closed:   make(chan struct{}),
+	}
+	s.httpServer = httptest.NewUnstartedServer(s)
+	return s
+}
+
+// SetAuthor sets the author of the commits pushed after this call.
+func (s *Server) SetAuthor(author centraldogma.Author) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.author = author
+}
+
+// SetClock sets the function which returns the current time, which is used as the time of the commits and
+// the creation time of the projects and repositories.
+func (s *Server) SetClock(now func() time.Time) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.now = now
+}
+
+// Start starts the server.
+func (s *Server) Start() {
+	s.httpServer.Start()
+	s.URL = s.httpServer.URL
+}
+
+// Close shuts down the server and blocks until all outstanding requests on this server have completed.
+// The pending watch requests are answered with 304 Not Modified.
+func (s *Server) Close() {
+	s.closeOnce.Do(func() {
+		close(s.closed)
+	})
+	s.httpServer.Close()
+}
+
+// Client returns a centraldogma.Client which communicates with the server.
+func (s *Server) Client() *centraldogma.Client {
+	c, err := centraldogma.NewClientWithToken(s.URL, "anonymous", http.DefaultTransport)
+	if err != nil {
+		panic(fmt.Sprintf("dogmatest: failed to create a client: %v", err))
+	}
+	return c
+}
+
+// CreateProject creates a project with its meta repository if it does not exist.
+func (s *Server) CreateProject(projectName string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.createProject(projectName)
+}
+
+// CreateRepository creates a repository, and the project if it does not exist.
+func (s *Server) CreateRepository(projectName, repoName string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	p := s.createProject(projectName)
+	if _, ok := p.repos[repoName]; !ok {
+		s.createRepository(p, repoName)
+	}
+}
+
+// UpsertFile pushes the file to the repository and returns the new revision. The content of a file whose
+// path ends with ".json" must be a JSON document. The repository is created if it does not exist.
+func (s *Server) UpsertFile(projectName, repoName, path, content string) (int, error) {
+	change := &centraldogma.Change{Path: path, Type: centraldogma.UpsertText, Content: content}
+	if strings.HasSuffix(strings.ToLower(path), ".json") {
+		change.Type = centraldogma.UpsertJSON
+	}
+	return s.Push(projectName, repoName, "Upsert "+path, change)
+}
+
+// Push pushes the changes to the repository and returns the new revision. The repository is created if it
+// does not exist.
+func (s *Server) Push(projectName, repoName, summary string, changes ...*centraldogma.Change) (int, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	p := s.createProject(projectName)
+	repo, ok := p.repos[repoName]
+	if !ok {
+		repo = s.createRepository(p, repoName)
+	}
+	c, err := s.push(repo, repo.head(), centraldogma.CommitMessage{Summary: summary}, changes)
+	if err != nil {
+		return 0, err
+	}
+	return c.Revision, nil
+}
+
+// Head returns the latest revision of the repository, or 0 if it does not exist.
+func (s *Server) Head(projectName, repoName string) int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if p, ok := s.projects[projectName]; ok {
+		if repo, ok := p.repos[repoName]; ok {
+			return repo.head()
+		}
+	}
+	return 0
+}
+
+func (s *Server) createProject(projectName string) *project {
+	if p, ok := s.projects[projectName]; ok {
+		return p
+	}
+	p := &project{name: projectName, createdAt: s.now(), repos: make(map[string]*repository)}
+	s.projects[projectName] = p
+	s.createRepository(p, metaRepo)
+	return p
+}
+
+func (s *Server) createRepository(p *project, repoName string) *repository {
+	repo := &repository{name: repoName, createdAt: s.now()}
+	repo.commit(s.author, centraldogma.CommitMessage{Summary: "Create a new repository"},
+		map[string]*file{}, nil, s.now())
+	p.repos[repoName] = repo
+	return repo
+}
+
+func (s *Server) push(repo *repository, baseRevision int,
+	message centraldogma.CommitMessage, changes []*centraldogma.Change) (*commit, error) {
+	files, changed, err := repo.push(baseRevision, changes)
+	if err != nil {
+		return nil, err
+	}
+	c := repo.commit(s.author, message, files, changed, s.now())
+	close(s.updated)
+	s.updated = make(chan struct{})
+	return c, nil
+}
+
+// ServeHTTP serves the Central Dogma API.
+func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	switch r.URL.Path {
+	case "/monitor/l7check":
+		w.WriteHeader(http.StatusOK)
+		return
+	case pathPrefix + "status":
+		writeJSON(w, http.StatusOK, map[string]bool{"writable": true, "replicating": false})
+		return
+	}
+	if !strings.HasPrefix(r.URL.Path, pathPrefix) {
+		writeError(w, newHTTPError(http.StatusNotFound, "NotFoundException", "no such API: %s", r.URL.Path))
+		return
+	}
+
+	// projects/{project}/repos/{repo}/{action}/{rest...}
+	segments := strings.SplitN(strings.TrimPrefix(r.URL.Path, pathPrefix), "/", 6)
+	if segments[0] != "projects" {
+		writeError(w, newHTTPError(http.StatusNotFound, "NotFoundException", "no such API: %s", r.URL.Path))
+		return
+	}
+
+	var (
+		status int
+		body   interface{}
+		err    error
+	)
+	switch {
+	case len(segments) == 1:
+		status, body, err = s.serveProjects(r)
+	case len(segments) == 2 || (len(segments) == 3 && segments[2] == "removed"):
+		status, body, err = s.serveProject(r, segments[1], len(segments) == 3)
+	case segments[2] != "repos":
+		err = newHTTPError(http.StatusNotFound, "NotFoundException", "no such API: %s", r.URL.Path)
+	case len(segments) == 3:
+		status, body, err = s.serveRepositories(r, segments[1])
+	case len(segments) == 4 || (len(segments) == 5 && segments[4] == "removed"):
+		status, body, err = s.serveRepository(r, segments[1], segments[3], len(segments) == 5)
+	default:
+		var rest string
+		if len(segments) == 6 {
+			rest = "/" + segments[5]
+		}
+		status, body, err = s.serveContents(r, segments[1], segments[3], segments[4], rest)
+	}
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	writeJSON(w, status, body)
+}
+
+func (s *Server) serveProjects(r *http.Request) (int, interface{}, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	switch r.Method {
+	case http.MethodGet:
+		removed := r.URL.Query().Get("status") == "removed"
+		projects := make([]*centraldogma.Project, 0)
+		for _, p := range sortedProjects(s.projects) {
+			if p.removed == removed {
+				projects = append(projects, s.projectJSON(p))
+			}
+		}
+		return http.StatusOK, projects, nil
+	case http.MethodPost:
+		var req struct {
+			Name string `json:"name"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil || len(req.Name) == 0 {
+			return 0, nil, newHTTPError(http.StatusBadRequest, "IllegalArgumentException", "invalid project")
+		}
+		if _, ok := s.projects[req.Name]; ok {
+			return 0, nil, newHTTPError(http.StatusConflict, "ProjectExistsException",
+				"project %s already exists", req.Name)
+		}
+		return http.StatusCreated, s.projectJSON(s.createProject(req.Name)), nil
+	}
+	return 0, nil, methodNotAllowed(r)
+}
+
+func (s *Server) serveProject(r *http.Request, projectName string, removed bool) (int, interface{}, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	p, ok := s.projects[projectName]
+	if !ok {
+		return 0, nil, newHTTPError(http.StatusNotFound, "ProjectNotFoundException",
+			"project %s does not exist", projectName)
+	}
+	switch {
+	case r.Method == http.MethodDelete && !removed && !p.removed:
+		p.removed = true
+		return http.StatusNoContent, nil, nil
+	case r.Method == http.MethodDelete && removed && p.removed:
+		delete(s.projects, projectName)
+		return http.StatusNoContent, nil, nil
+	case r.Method == http.MethodPatch && !removed && p.removed:
+		p.removed = false
+		return http.StatusOK, s.projectJSON(p), nil
+	}
+	return 0, nil, newHTTPError(http.StatusNotFound, "ProjectNotFoundException",
+		"project %s does not exist", projectName)
+}
+
+func (s *Server) serveRepositories(r *http.Request, projectName string) (int, interface{}, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	p, err := s.project(projectName)
+	if err != nil {
+		return 0, nil, err
+	}
+	switch r.Method {
+	case http.MethodGet:
+		removed := r.URL.Query().Get("status") == "removed"
+		repos := make([]*centraldogma.Repository, 0)
+		for _, repo := range sortedRepositories(p.repos) {
+			if repo.removed == removed {
+				repos = append(repos, s.repositoryJSON(p, repo))
+			}
+		}
+		return http.StatusOK, repos, nil
+	case http.MethodPost:
+		var req struct {
+			Name string `json:"name"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil || len(req.Name) == 0 {
+			return 0, nil, newHTTPError(http.StatusBadRequest, "IllegalArgumentException", "invalid repository")
+		}
+		if _, ok := p.repos[req.Name]; ok {
+			return 0, nil, newHTTPError(http.StatusConflict, "RepositoryExistsException",
+				"repository %s already exists", req.Name)
+		}
+		return http.StatusCreated, s.repositoryJSON(p, s.createRepository(p, req.Name)), nil
+	}
+	return 0, nil, methodNotAllowed(r)
+}
+
+func (s *Server) serveRepository(r *http.Request,
+	projectName, repoName string, removed bool) (int, interface{}, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	p, err := s.project(projectName)
+	if err != nil {
+		return 0, nil, err
+	}
+	repo, ok := p.repos[repoName]
+	if ok {
+		switch {
+		case r.Method == http.MethodDelete && !removed && !repo.removed:
+			repo.removed = true
+			return http.StatusNoContent, nil, nil
+		case r.Method == http.MethodDelete && removed && repo.removed:
+			delete(p.repos, repoName)
+			return http.StatusNoContent, nil, nil
+		case r.Method == http.MethodPatch && !removed && repo.removed:
+			repo.removed = false
+			return http.StatusOK, s.repositoryJSON(p, repo), nil
+		}
+	}
+	return 0, nil, newHTTPError(http.StatusNotFound, "RepositoryNotFoundException",
+		"repository %s/%s does not exist", projectName, repoName)
+}
+
+// project returns the project which is not removed.
+func (s *Server) project(projectName string) (*project, error) {
+	p, ok := s.projects[projectName]
+	if !ok || p.removed {
+		return nil, newHTTPError(http.StatusNotFound, "ProjectNotFoundException",
+			"project %s does not exist", projectName)
+	}
+	return p, nil
+}
+
+// repository returns the repository which is not removed.
+func (s *Server) repository(projectName, repoName string) (*repository, error) {
+	p, err := s.project(projectName)
+	if err != nil {
+		return nil, err
+	}
+	repo, ok := p.repos[repoName]
+	if !ok || repo.removed {
+		return nil, newHTTPError(http.StatusNotFound, "RepositoryNotFoundException",
+			"repository %s/%s does not exist", projectName, repoName)
+	}
+	return repo, nil
+}
+
+func (s *Server) projectJSON(p *project) *centraldogma.Project {
+	return &centraldogma.Project{
+		Name:      p.name,
+		Creator:   s.author,
+		URL:       pathPrefix + "projects/" + p.name,
+		CreatedAt: formatTime(p.createdAt),
+	}
+}
+
+func (s *Server) repositoryJSON(p *project, repo *repository) *centraldogma.Repository {
+	return &centraldogma.Repository{
+		Name:         repo.name,
+		Creator:      s.author,
+		HeadRevision: repo.head(),
+		URL:          pathPrefix + "projects/" + p.name + "/repos/" + repo.name,
+		CreatedAt:    formatTime(repo.createdAt),
+	}
+}
+
+func sortedProjects(projects map[string]*project) []*project {
+	sorted := make([]*project, 0, len(projects))
+	for _, p := range projects {
+		sorted = append(sorted, p)
+	}
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i].name < sorted[j].name })
+	return sorted
+}
+
+func sortedRepositories(repos map[string]*repository) []*repository {
+	sorted := make([]*repository, 0, len(repos))
+	for _, repo := range repos {
+		sorted = append(sorted, repo)
+	}
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i].name < sorted[j].name })
+	return sorted
+}
+
+func methodNotAllowed(r *http.Request) error {
+	return newHTTPError(http.StatusMethodNotAllowed, "MethodNotAllowedException",
+		"%s is not allowed for %s", r.Method, r.URL.Path)
+}
+
+func writeJSON(w http.ResponseWriter, status int, body interface{}) {
+	if body == nil {
+		w.WriteHeader(status)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(body)
+}
+
+func writeError(w http.ResponseWriter, err error) {
+	e, ok := err.(*httpError)
+	if !ok {
+		e = newHTTPError(http.StatusInternalServerError, "InternalServerErrorException", "%v", err)
+	}
+	writeJSON(w, e.status, map[string]string{"exception": e.exception, "message": e.message})
+}
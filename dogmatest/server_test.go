@@ -0,0 +1,304 @@
+// Copyright 2026 LINE Corporation
+//
+// LINE Corporation licenses this file to you under the Apache License,
+// version 2.0 (the "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at:
+//
+//   https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+// WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+// License for the specific language governing permissions and limitations
+// under the License.
+
+package dogmatest_test
+
+import (
+	"context"
+	"net/http"
+	"reflect"
+	"strconv"
+	"testing"
+	"time"
+
+	"go.linecorp.com/centraldogma"
+	"go.linecorp.com/centraldogma/dogmatest"
+)
+
+func TestProjectsAndRepositories(t *testing.T) {
+	server := dogmatest.NewServer()
+	defer server.Close()
+	client := server.Client()
+	ctx := context.Background()
+
+	if _, _, err := client.CreateProject(ctx, "foo"); err != nil {
+		t.Fatal(err)
+	}
+	if _, httpStatusCode, err := client.CreateProject(ctx, "foo"); err == nil || httpStatusCode != http.StatusConflict {
+		t.Errorf("CreateProject() = %v, %v, want a conflict", httpStatusCode, err)
+	}
+	if _, _, err := client.CreateRepository(ctx, "foo", "bar"); err != nil {
+		t.Fatal(err)
+	}
+
+	repos, _, err := client.ListRepositories(ctx, "foo")
+	if err != nil {
+		t.Fatal(err)
+	}
+	var names []string
+	for _, repo := range repos {
+		names = append(names, repo.Name)
+	}
+	if want := []string{"bar", "meta"}; !reflect.DeepEqual(names, want) {
+		t.Errorf("ListRepositories() = %v, want %v", names, want)
+	}
+
+	if _, err := client.RemoveRepository(ctx, "foo", "bar"); err != nil {
+		t.Fatal(err)
+	}
+	if _, httpStatusCode, err := client.GetFile(ctx, "foo", "bar", "-1",
+		&centraldogma.Query{Path: "/a.json", Type: centraldogma.Identity}); httpStatusCode != http.StatusNotFound {
+		t.Errorf("GetFile() = %v, %v, want 404", httpStatusCode, err)
+	}
+	if _, _, err := client.UnremoveRepository(ctx, "foo", "bar"); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestGetFile(t *testing.T) {
+	server := dogmatest.NewServer()
+	defer server.Close()
+	client := server.Client()
+	ctx := context.Background()
+
+	if _, err := server.UpsertFile("foo", "bar", "/a.json", `{"a":{"b":"c"}}`); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := server.UpsertFile("foo", "bar", "/dir/b.txt", "hello\n"); err != nil {
+		t.Fatal(err)
+	}
+
+	entry, _, err := client.GetFile(ctx, "foo", "bar", "-1",
+		&centraldogma.Query{Path: "/a.json", Type: centraldogma.Identity})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if entry.Type != centraldogma.JSON || string(entry.Content) != `{"a":{"b":"c"}}` || entry.Revision != 3 {
+		t.Errorf("GetFile() = %+v, want the JSON file at the revision 3", entry)
+	}
+
+	entry, _, err = client.GetFile(ctx, "foo", "bar", "-1",
+		&centraldogma.Query{Path: "/a.json", Type: centraldogma.JSONPath, Expressions: []string{"$.a"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(entry.Content) != `{"b":"c"}` {
+		t.Errorf("GetFile() with JSON path = %s, want {\"b\":\"c\"}", entry.Content)
+	}
+
+	entry, _, err = client.GetFile(ctx, "foo", "bar", "2",
+		&centraldogma.Query{Path: "/dir/b.txt", Type: centraldogma.Identity})
+	if err == nil {
+		t.Errorf("GetFile() at the revision 2 = %+v, want an error", entry)
+	}
+
+	entries, _, err := client.ListFiles(ctx, "foo", "bar", "-1", "/**")
+	if err != nil {
+		t.Fatal(err)
+	}
+	var paths []string
+	for _, entry := range entries {
+		paths = append(paths, entry.Path)
+	}
+	if want := []string{"/a.json", "/dir", "/dir/b.txt"}; !reflect.DeepEqual(paths, want) {
+		t.Errorf("ListFiles() = %v, want %v", paths, want)
+	}
+
+	entries, _, err = client.GetFiles(ctx, "foo", "bar", "-1", "*.txt")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(entries) != 1 || string(entries[0].Content) != "hello\n" {
+		t.Errorf("GetFiles() = %+v, want /dir/b.txt", entries)
+	}
+}
+
+func TestPushAndDiff(t *testing.T) {
+	server := dogmatest.NewServer()
+	defer server.Close()
+	server.CreateRepository("foo", "bar")
+	client := server.Client()
+	ctx := context.Background()
+
+	commitMessage := &centraldogma.CommitMessage{Summary: "Add a.json"}
+	changes := []*centraldogma.Change{{Path: "/a.json", Type: centraldogma.UpsertJSON,
+		Content: map[string]interface{}{"a": 1}}}
+	result, _, err := client.Push(ctx, "foo", "bar", "-1", commitMessage, changes)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if result.Revision != 2 {
+		t.Errorf("Push() revision = %d, want 2", result.Revision)
+	}
+
+	// Pushing against the old revision conflicts because a.json was modified after it.
+	changes[0].Content = map[string]interface{}{"a": 2}
+	if _, httpStatusCode, err := client.Push(ctx, "foo", "bar", "1", commitMessage, changes); err == nil ||
+		httpStatusCode != http.StatusConflict {
+		t.Errorf("Push() = %v, %v, want a conflict", httpStatusCode, err)
+	}
+	if _, _, err := client.Push(ctx, "foo", "bar", "-1", commitMessage, changes); err != nil {
+		t.Fatal(err)
+	}
+
+	change, _, err := client.GetDiff(ctx, "foo", "bar", "2", "3",
+		&centraldogma.Query{Path: "/a.json", Type: centraldogma.Identity})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if change.Type != centraldogma.ApplyJSONPatch {
+		t.Errorf("GetDiff() type = %v, want %v", change.Type, centraldogma.ApplyJSONPatch)
+	}
+
+	diffs, _, err := client.GetDiffs(ctx, "foo", "bar", "1", "-1", "/**")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(diffs) != 1 || diffs[0].Type != centraldogma.UpsertJSON {
+		t.Errorf("GetDiffs() = %+v, want an upsert of /a.json", diffs)
+	}
+
+	commits, _, err := client.GetHistory(ctx, "foo", "bar", "-1", "1", "/a.json", 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var revisions []int
+	for _, commit := range commits {
+		revisions = append(revisions, commit.Revision)
+	}
+	if want := []int{3, 2}; !reflect.DeepEqual(revisions, want) {
+		t.Errorf("GetHistory() revisions = %v, want %v", revisions, want)
+	}
+}
+
+func TestMergeFiles(t *testing.T) {
+	server := dogmatest.NewServer()
+	defer server.Close()
+	client := server.Client()
+	ctx := context.Background()
+
+	if _, err := server.UpsertFile("foo", "bar", "/base.json", `{"a":1,"b":{"c":2,"d":3}}`); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := server.UpsertFile("foo", "bar", "/prod.json", `{"b":{"c":4}}`); err != nil {
+		t.Fatal(err)
+	}
+
+	merged, _, err := client.MergeFiles(ctx, "foo", "bar", centraldogma.Head(), &centraldogma.MergeQuery{
+		Sources: []*centraldogma.MergeSource{
+			{Path: "/base.json"}, {Path: "/prod.json"}, {Path: "/missing.json", Optional: true},
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := `{"a":1,"b":{"c":4,"d":3}}`; string(merged.Content) != want {
+		t.Errorf("MergeFiles() = %s, want %s", merged.Content, want)
+	}
+	if want := []string{"/base.json", "/prod.json"}; !reflect.DeepEqual(merged.Paths, want) {
+		t.Errorf("MergeFiles() paths = %v, want %v", merged.Paths, want)
+	}
+}
+
+func TestWatch(t *testing.T) {
+	server := dogmatest.NewServer()
+	defer server.Close()
+	client := server.Client()
+	ctx := context.Background()
+
+	if _, err := server.UpsertFile("foo", "bar", "/a.json", `{"a":{"b":1}}`); err != nil {
+		t.Fatal(err)
+	}
+
+	watcher, err := client.FileWatcher("foo", "bar",
+		&centraldogma.Query{Path: "/a.json", Type: centraldogma.JSONPath, Expressions: []string{"$.a"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer watcher.Close()
+	initial := watcher.AwaitInitialValueWith(5 * time.Second)
+	if initial == nil || string(initial.Entry.Content) != `{"b":1}` {
+		t.Fatalf("AwaitInitialValue() = %+v, want {\"b\":1}", initial)
+	}
+
+	ch := make(chan centraldogma.WatchResult, 1)
+	if err := watcher.Watch(func(result centraldogma.WatchResult) {
+		if result.Revision > initial.Revision {
+			ch <- result
+		}
+	}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := server.UpsertFile("foo", "bar", "/a.json", `{"a":{"b":2}}`); err != nil {
+		t.Fatal(err)
+	}
+	select {
+	case result := <-ch:
+		if string(result.Entry.Content) != `{"b":2}` {
+			t.Errorf("watch result = %s, want {\"b\":2}", result.Entry.Content)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("timed out waiting for the change")
+	}
+
+	// The repository watch times out with 304 Not Modified if nothing matches.
+	changes, closer, err := client.WatchRepository(ctx, "foo", "bar", "*.txt", time.Second)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer closer()
+	select {
+	case result := <-changes:
+		t.Errorf("WatchRepository() = %+v, want no changes", result)
+	case <-time.After(1500 * time.Millisecond):
+	}
+}
+
+func TestClose(t *testing.T) {
+	server := dogmatest.NewServer()
+	revision, err := server.UpsertFile("foo", "bar", "/a.json", `{"a":1}`)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	req, err := http.NewRequest(http.MethodGet, server.URL+"/api/v1/projects/foo/repos/bar/contents/**", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	req.Header.Set("If-None-Match", strconv.Itoa(revision))
+	req.Header.Set("Prefer", "wait=60")
+	done := make(chan int, 1)
+	go func() {
+		res, err := http.DefaultClient.Do(req)
+		if err != nil {
+			t.Error(err)
+			done <- 0
+			return
+		}
+		res.Body.Close()
+		done <- res.StatusCode
+	}()
+
+	// The pending watch request is answered with 304 Not Modified instead of a connection error.
+	time.Sleep(100 * time.Millisecond)
+	server.Close()
+	select {
+	case statusCode := <-done:
+		if statusCode != http.StatusNotModified {
+			t.Errorf("watch status code = %d, want 304", statusCode)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("timed out waiting for the watch request")
+	}
+}
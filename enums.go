@@ -14,6 +14,12 @@
 
 package centraldogma
 
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
 type ChangeType int
 
 const (
@@ -67,3 +73,218 @@ func (c EntryType) String() string {
 	}
 	return "UNKNOWN"
 }
+
+// ProjectRole is the role of a user or an application token in a project.
+type ProjectRole int
+
+const (
+	RoleOwner ProjectRole = iota + 1
+	RoleMember
+	RoleGuest
+)
+
+var projectRoleMap = map[string]ProjectRole{
+	"OWNER":  RoleOwner,
+	"MEMBER": RoleMember,
+	"GUEST":  RoleGuest,
+}
+
+// String returns the string value of ProjectRole
+func (r ProjectRole) String() string {
+	for k, v := range projectRoleMap {
+		if v == r {
+			return k
+		}
+	}
+	return "UNKNOWN"
+}
+
+// MarshalJSON returns the role as a JSON string, e.g. "OWNER".
+func (r ProjectRole) MarshalJSON() ([]byte, error) {
+	return json.Marshal(r.String())
+}
+
+// UnmarshalJSON parses the role from a JSON string, e.g. "OWNER".
+func (r *ProjectRole) UnmarshalJSON(b []byte) error {
+	var role string
+	if err := json.Unmarshal(b, &role); err != nil {
+		return err
+	}
+	*r = projectRoleMap[role]
+	return nil
+}
+
+// Permission is a permission of a user or an application token on a repository.
+type Permission int
+
+const (
+	PermissionRead Permission = iota + 1
+	PermissionWrite
+)
+
+var permissionMap = map[string]Permission{
+	"READ":  PermissionRead,
+	"WRITE": PermissionWrite,
+}
+
+// String returns the string value of Permission
+func (p Permission) String() string {
+	for k, v := range permissionMap {
+		if v == p {
+			return k
+		}
+	}
+	return "UNKNOWN"
+}
+
+// MarshalJSON returns the permission as a JSON string, e.g. "READ".
+func (p Permission) MarshalJSON() ([]byte, error) {
+	return json.Marshal(p.String())
+}
+
+// UnmarshalJSON parses the permission from a JSON string, e.g. "READ".
+func (p *Permission) UnmarshalJSON(b []byte) error {
+	var permission string
+	if err := json.Unmarshal(b, &permission); err != nil {
+		return err
+	}
+	*p = permissionMap[permission]
+	return nil
+}
+
+// MirrorDirection is the direction of a mirror.
+type MirrorDirection int
+
+const (
+	RemoteToLocal MirrorDirection = iota + 1
+	LocalToRemote
+)
+
+var mirrorDirectionMap = map[string]MirrorDirection{
+	"REMOTE_TO_LOCAL": RemoteToLocal,
+	"LOCAL_TO_REMOTE": LocalToRemote,
+}
+
+// String returns the string value of MirrorDirection
+func (d MirrorDirection) String() string {
+	for k, v := range mirrorDirectionMap {
+		if v == d {
+			return k
+		}
+	}
+	return "UNKNOWN"
+}
+
+// MarshalJSON returns the direction as a JSON string, e.g. "REMOTE_TO_LOCAL".
+func (d MirrorDirection) MarshalJSON() ([]byte, error) {
+	return json.Marshal(d.String())
+}
+
+// UnmarshalJSON parses the direction from a JSON string, e.g. "REMOTE_TO_LOCAL".
+func (d *MirrorDirection) UnmarshalJSON(b []byte) error {
+	var direction string
+	if err := json.Unmarshal(b, &direction); err != nil {
+		return err
+	}
+	*d = mirrorDirectionMap[direction]
+	return nil
+}
+
+// CredentialType is the type of a credential which is used to access a remote Git repository.
+type CredentialType int
+
+const (
+	CredentialNone CredentialType = iota + 1
+	CredentialPassword
+	CredentialPublicKey
+	CredentialAccessToken
+)
+
+var credentialTypeMap = map[string]CredentialType{
+	"none":         CredentialNone,
+	"password":     CredentialPassword,
+	"public_key":   CredentialPublicKey,
+	"access_token": CredentialAccessToken,
+}
+
+// String returns the string value of CredentialType
+func (c CredentialType) String() string {
+	for k, v := range credentialTypeMap {
+		if v == c {
+			return k
+		}
+	}
+	return "UNKNOWN"
+}
+
+// MarshalJSON returns the type as a JSON string, e.g. "password".
+func (c CredentialType) MarshalJSON() ([]byte, error) {
+	return json.Marshal(c.String())
+}
+
+// UnmarshalJSON parses the type from a JSON string, e.g. "password".
+func (c *CredentialType) UnmarshalJSON(b []byte) error {
+	var credentialType string
+	if err := json.Unmarshal(b, &credentialType); err != nil {
+		return err
+	}
+	*c = credentialTypeMap[credentialType]
+	return nil
+}
+
+// ExportFormat is the format of an archive which Export writes.
+type ExportFormat int
+
+const (
+	ExportTarGz ExportFormat = iota + 1
+	ExportZip
+)
+
+var exportFormatMap = map[string]ExportFormat{
+	"tar.gz": ExportTarGz,
+	"zip":    ExportZip,
+}
+
+// String returns the string value of ExportFormat
+func (f ExportFormat) String() string {
+	for k, v := range exportFormatMap {
+		if v == f {
+			return k
+		}
+	}
+	return "UNKNOWN"
+}
+
+// ParseExportFormat parses the export format, i.e. "tar.gz" or "zip".
+func ParseExportFormat(format string) (ExportFormat, error) {
+	f, ok := exportFormatMap[strings.ToLower(format)]
+	if !ok {
+		return 0, fmt.Errorf("unknown export format: %q (expected tar.gz or zip)", format)
+	}
+	return f, nil
+}
+
+// ImportAction is the action which Import takes for a file.
+type ImportAction int
+
+const (
+	ImportAdded ImportAction = iota + 1
+	ImportModified
+	ImportRemoved
+)
+
+var importActionMap = map[string]ImportAction{
+	"ADDED":    ImportAdded,
+	"MODIFIED": ImportModified,
+	"REMOVED":  ImportRemoved,
+}
+
+// String returns the string value of ImportAction
+func (a ImportAction) String() string {
+	for k, v := range importActionMap {
+		if v == a {
+			return k
+		}
+	}
+	return "UNKNOWN"
+}
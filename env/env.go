@@ -0,0 +1,257 @@
+// Copyright 2026 LINE Corporation
+//
+// LINE Corporation licenses this file to you under the Apache License,
+// version 2.0 (the "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at:
+//
+//   https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+// WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+// License for the specific language governing permissions and limitations
+// under the License.
+
+// Package env flattens a JSON document into environment variables, for the tools which read their
+// configuration from the environment. For example, the document
+//
+//	{"db": {"host": "localhost", "ports": [3306, 3307]}, "debug": true}
+//
+// is flattened with the prefix "APP_" into
+//
+//	APP_DB_HOST=localhost
+//	APP_DB_PORTS_0=3306
+//	APP_DB_PORTS_1=3307
+//	APP_DEBUG=true
+package env
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// DefaultSeparator is the default separator of the keys of the nested values.
+const DefaultSeparator = "_"
+
+// Options specifies how a document is flattened.
+type Options struct {
+	// Prefix is prepended to the names of the variables as it is, e.g. "APP_". It consists of ASCII letters,
+	// digits and "_".
+	Prefix string
+	// Separator joins the keys of the nested values. DefaultSeparator is used if empty.
+	Separator string
+	// PreserveCase keeps the case of the keys. The keys are converted to upper case otherwise.
+	PreserveCase bool
+}
+
+// Variable is an environment variable.
+type Variable struct {
+	Name  string
+	Value string
+}
+
+// Flatten flattens the JSON document into the variables sorted by name. The names are the keys of the objects
+// and the indexes of the arrays joined with the separator, whose characters other than ASCII letters, digits
+// and "_" are replaced with "_". The strings are the values as they are, the null is an empty string, and
+// the other values are their JSON representations. If the document is not an object or an array,
+// the prefix without the trailing separator is its name.
+func Flatten(doc []byte, opts *Options) ([]Variable, error) {
+	dec := json.NewDecoder(bytes.NewReader(doc))
+	dec.UseNumber()
+	var v interface{}
+	if err := dec.Decode(&v); err != nil {
+		return nil, err
+	}
+	return FlattenValue(v, opts)
+}
+
+// FlattenValue flattens the value which is unmarshaled from JSON. See Flatten for the details.
+func FlattenValue(v interface{}, opts *Options) ([]Variable, error) {
+	if opts == nil {
+		opts = &Options{}
+	}
+	if sanitize(opts.Prefix, true) != opts.Prefix {
+		return nil, fmt.Errorf("invalid prefix %q: only ASCII letters, digits and \"_\" are allowed", opts.Prefix)
+	}
+	separator := opts.Separator
+	if len(separator) == 0 {
+		separator = DefaultSeparator
+	}
+
+	values := make(map[string]string)
+	keys := make(map[string]string) // the original keys of the names, to report the conflicts
+	var walk func(key string, v interface{}) error
+	walk = func(key string, v interface{}) error {
+		join := func(child string) string {
+			if len(key) == 0 {
+				return child
+			}
+			return key + separator + child
+		}
+		switch value := v.(type) {
+		case map[string]interface{}:
+			// The keys are sorted so that the same conflict is always reported.
+			ks := make([]string, 0, len(value))
+			for k := range value {
+				ks = append(ks, k)
+			}
+			sort.Strings(ks)
+			for _, k := range ks {
+				if err := walk(join(k), value[k]); err != nil {
+					return err
+				}
+			}
+			return nil
+		case []interface{}:
+			for i, child := range value {
+				if err := walk(join(strconv.Itoa(i)), child); err != nil {
+					return err
+				}
+			}
+			return nil
+		}
+
+		name := opts.Prefix + sanitize(key, opts.PreserveCase)
+		if len(key) == 0 {
+			name = strings.TrimSuffix(opts.Prefix, separator)
+		}
+		if len(name) == 0 {
+			return fmt.Errorf("the prefix should be specified to flatten a %T", v)
+		}
+		if name[0] >= '0' && name[0] <= '9' {
+			name = "_" + name
+		}
+		if k, ok := keys[name]; ok {
+			return fmt.Errorf("%q and %q are flattened into the same name %s", k, key, name)
+		}
+		s, err := stringValue(v)
+		if err != nil {
+			return err
+		}
+		keys[name] = key
+		values[name] = s
+		return nil
+	}
+	if err := walk("", v); err != nil {
+		return nil, err
+	}
+
+	vars := make([]Variable, 0, len(values))
+	for name, value := range values {
+		vars = append(vars, Variable{Name: name, Value: value})
+	}
+	sort.Slice(vars, func(i, j int) bool { return vars[i].Name < vars[j].Name })
+	return vars, nil
+}
+
+func sanitize(key string, preserveCase bool) string {
+	if !preserveCase {
+		key = strings.ToUpper(key)
+	}
+	return strings.Map(func(r rune) rune {
+		if r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z' || r >= '0' && r <= '9' || r == '_' {
+			return r
+		}
+		return '_'
+	}, key)
+}
+
+func stringValue(v interface{}) (string, error) {
+	switch value := v.(type) {
+	case nil:
+		return "", nil
+	case string:
+		return value, nil
+	case json.Number:
+		return value.String(), nil
+	default:
+		b, err := json.Marshal(value)
+		if err != nil {
+			return "", err
+		}
+		return string(b), nil
+	}
+}
+
+// Environ returns the variables in the form of "name=value", which can be appended to os.Environ().
+func Environ(vars []Variable) []string {
+	environ := make([]string, len(vars))
+	for i, v := range vars {
+		environ[i] = v.Name + "=" + v.Value
+	}
+	return environ
+}
+
+// Format is the output format of the variables.
+type Format int
+
+const (
+	// Shell writes the "export" lines which can be evaluated by a POSIX shell.
+	Shell Format = iota + 1
+	// Dotenv writes the lines of a .env file.
+	Dotenv
+	// JSON writes a JSON object whose keys are the names.
+	JSON
+)
+
+var formatMap = map[string]Format{
+	"shell":  Shell,
+	"dotenv": Dotenv,
+	"json":   JSON,
+}
+
+// String returns the string value of Format
+func (f Format) String() string {
+	for k, v := range formatMap {
+		if v == f {
+			return k
+		}
+	}
+	return "UNKNOWN"
+}
+
+// ParseFormat parses the format, i.e. "shell", "dotenv" or "json".
+func ParseFormat(format string) (Format, error) {
+	f, ok := formatMap[strings.ToLower(format)]
+	if !ok {
+		return 0, fmt.Errorf("unknown format: %q (expected shell, dotenv or json)", format)
+	}
+	return f, nil
+}
+
+// Write writes the variables in the format.
+func Write(w io.Writer, vars []Variable, format Format) error {
+	var buf bytes.Buffer
+	switch format {
+	case Shell:
+		for _, v := range vars {
+			// A single-quoted string has no escapes, so a single quote is written as '\''.
+			fmt.Fprintf(&buf, "export %s='%s'\n", v.Name, strings.ReplaceAll(v.Value, "'", `'\''`))
+		}
+	case Dotenv:
+		replacer := strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`, "\r", `\r`, "$", `\$`)
+		for _, v := range vars {
+			fmt.Fprintf(&buf, "%s=\"%s\"\n", v.Name, replacer.Replace(v.Value))
+		}
+	case JSON:
+		m := make(map[string]string, len(vars))
+		for _, v := range vars {
+			m[v.Name] = v.Value
+		}
+		b, err := json.MarshalIndent(m, "", "  ")
+		if err != nil {
+			return err
+		}
+		buf.Write(b)
+		buf.WriteString("\n")
+	default:
+		return fmt.Errorf("unknown format: %v", format)
+	}
+	_, err := w.Write(buf.Bytes())
+	return err
+}
@@ -0,0 +1,110 @@
+// Copyright 2026 LINE Corporation
+//
+// LINE Corporation licenses this file to you under the Apache License,
+// version 2.0 (the "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at:
+//
+//   https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+// WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+// License for the specific language governing permissions and limitations
+// under the License.
+
+package env
+
+import (
+	"bytes"
+	"reflect"
+	"testing"
+)
+
+func TestFlatten(t *testing.T) {
+	doc := []byte(`{"db": {"host": "localhost", "ports": [3306, 3307], "max-conns": 1e3},
+"debug": true, "name": null, "tags": {}, "opts": {"a": {"b": 1.50}}}`)
+
+	var tests = []struct {
+		opts *Options
+		want []Variable
+	}{
+		{nil, []Variable{
+			{"DB_HOST", "localhost"}, {"DB_MAX_CONNS", "1e3"}, {"DB_PORTS_0", "3306"}, {"DB_PORTS_1", "3307"},
+			{"DEBUG", "true"}, {"NAME", ""}, {"OPTS_A_B", "1.50"},
+		}},
+		{&Options{Prefix: "app_", Separator: ".", PreserveCase: true}, []Variable{
+			{"app_db_host", "localhost"}, {"app_db_max_conns", "1e3"}, {"app_db_ports_0", "3306"},
+			{"app_db_ports_1", "3307"}, {"app_debug", "true"}, {"app_name", ""}, {"app_opts_a_b", "1.50"},
+		}},
+	}
+	for _, test := range tests {
+		got, err := Flatten(doc, test.opts)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !reflect.DeepEqual(got, test.want) {
+			t.Errorf("Flatten(%+v) = %v, want %v", test.opts, got, test.want)
+		}
+	}
+}
+
+func TestFlatten_Scalar(t *testing.T) {
+	got, err := Flatten([]byte(`"value"`), &Options{Prefix: "APP_"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := []Variable{{"APP", "value"}}; !reflect.DeepEqual(got, want) {
+		t.Errorf("Flatten() = %v, want %v", got, want)
+	}
+
+	if _, err := Flatten([]byte(`1`), nil); err == nil {
+		t.Error("Flatten() of a number without the prefix should fail")
+	}
+	if got, _ := Flatten([]byte(`[1]`), nil); !reflect.DeepEqual(got, []Variable{{"_0", "1"}}) {
+		t.Errorf("Flatten() of an array = %v, want _0=1", got)
+	}
+	// The conflict is reported in the order of the keys.
+	want := `"a-b" and "a_b" are flattened into the same name A_B`
+	for i := 0; i < 10; i++ {
+		if _, err := Flatten([]byte(`{"a_b": 1, "a-b": 2}`), nil); err == nil || err.Error() != want {
+			t.Fatalf("Flatten() with the conflicting names = %v, want %s", err, want)
+		}
+	}
+}
+
+func TestFlatten_InvalidPrefix(t *testing.T) {
+	for _, prefix := range []string{"my-app", "app.", "APP "} {
+		if _, err := Flatten([]byte(`{"a": 1}`), &Options{Prefix: prefix}); err == nil {
+			t.Errorf("Flatten() with the prefix %q should fail", prefix)
+		}
+	}
+}
+
+func TestWrite(t *testing.T) {
+	vars := []Variable{{"A", `it's "$HOME"`}, {"B", "line 1\nline 2"}}
+
+	var tests = []struct {
+		format Format
+		want   string
+	}{
+		{Shell, "export A='it'\\''s \"$HOME\"'\nexport B='line 1\nline 2'\n"},
+		{Dotenv, "A=\"it's \\\"\\$HOME\\\"\"\nB=\"line 1\\nline 2\"\n"},
+		{JSON, "{\n  \"A\": \"it's \\\"$HOME\\\"\",\n  \"B\": \"line 1\\nline 2\"\n}\n"},
+	}
+	for _, test := range tests {
+		var buf bytes.Buffer
+		if err := Write(&buf, vars, test.format); err != nil {
+			t.Fatal(err)
+		}
+		if buf.String() != test.want {
+			t.Errorf("Write(%v) = %q, want %q", test.format, buf.String(), test.want)
+		}
+	}
+
+	if _, err := ParseFormat("yaml"); err == nil {
+		t.Error("ParseFormat(yaml) should fail")
+	}
+	if f, _ := ParseFormat("DOTENV"); f != Dotenv {
+		t.Errorf("ParseFormat(DOTENV) = %v, want dotenv", f)
+	}
+}
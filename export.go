@@ -0,0 +1,248 @@
+// Copyright 2026 LINE Corporation
+//
+// LINE Corporation licenses this file to you under the Apache License,
+// version 2.0 (the "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at:
+//
+//   https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+// WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+// License for the specific language governing permissions and limitations
+// under the License.
+
+package centraldogma
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"compress/gzip"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ExportManifestName is the name of the manifest file which is written at the root of an exported archive
+// or directory.
+const ExportManifestName = ".dogma-manifest.json"
+
+// ExportManifest describes the files exported from a repository.
+type ExportManifest struct {
+	Project     string          `json:"project"`
+	Repository  string          `json:"repository"`
+	Revision    int             `json:"revision"`
+	PathPattern string          `json:"pathPattern"`
+	Files       []*ExportedFile `json:"files"`
+}
+
+// ExportedFile describes a file exported from a repository.
+type ExportedFile struct {
+	Path       string `json:"path"`
+	Type       string `json:"type"` // "JSON" or "TEXT"
+	Size       int    `json:"size"`
+	SHA256     string `json:"sha256"`
+	ModifiedAt string `json:"modifiedAt,omitempty"`
+}
+
+func (con *contentService) export(ctx context.Context, projectName, repoName, revision, pathPattern string,
+	w io.Writer, format ExportFormat) (*ExportManifest, int, error) {
+	manifest, entries, httpStatusCode, err := con.exportEntries(ctx, projectName, repoName, revision, pathPattern)
+	if err != nil {
+		return nil, httpStatusCode, err
+	}
+
+	switch format {
+	case ExportTarGz:
+		err = writeTarGz(w, manifest, entries)
+	case ExportZip:
+		err = writeZip(w, manifest, entries)
+	default:
+		err = fmt.Errorf("unknown export format: %v", format)
+	}
+	if err != nil {
+		return nil, UnknownHttpStatusCode, err
+	}
+	return manifest, httpStatusCode, nil
+}
+
+func (con *contentService) exportToDirectory(ctx context.Context,
+	projectName, repoName, revision, pathPattern, dir string) (*ExportManifest, int, error) {
+	manifest, entries, httpStatusCode, err := con.exportEntries(ctx, projectName, repoName, revision, pathPattern)
+	if err != nil {
+		return nil, httpStatusCode, err
+	}
+
+	for _, entry := range entries {
+		name := filepath.Join(dir, filepath.FromSlash(strings.TrimPrefix(entry.Path, "/")))
+		if err := os.MkdirAll(filepath.Dir(name), 0755); err != nil {
+			return nil, UnknownHttpStatusCode, err
+		}
+		if err := ioutil.WriteFile(name, entry.Content, 0644); err != nil {
+			return nil, UnknownHttpStatusCode, err
+		}
+	}
+	b, err := marshalManifest(manifest)
+	if err != nil {
+		return nil, UnknownHttpStatusCode, err
+	}
+	if err := ioutil.WriteFile(filepath.Join(dir, ExportManifestName), b, 0644); err != nil {
+		return nil, UnknownHttpStatusCode, err
+	}
+	return manifest, httpStatusCode, nil
+}
+
+// exportEntries fetches the files which match the pathPattern at the revision with a single request, and
+// returns them sorted by path with their manifest.
+func (con *contentService) exportEntries(ctx context.Context,
+	projectName, repoName, revision, pathPattern string) (*ExportManifest, []*Entry, int, error) {
+	if len(revision) == 0 {
+		revision = "-1"
+	}
+	if len(pathPattern) == 0 {
+		pathPattern = "/**"
+	}
+	rev, httpStatusCode, err := con.client.repository.normalizeRevision(ctx, projectName, repoName, revision)
+	if err != nil {
+		return nil, nil, httpStatusCode, err
+	}
+	entries, httpStatusCode, err := con.getFiles(ctx, projectName, repoName, strconv.Itoa(rev), pathPattern)
+	if err != nil {
+		return nil, nil, httpStatusCode, err
+	}
+
+	files := make([]*Entry, 0, len(entries))
+	for _, entry := range entries {
+		if entry.Type == Directory {
+			continue
+		}
+		if cleaned := path.Clean("/" + entry.Path); cleaned != entry.Path {
+			return nil, nil, UnknownHttpStatusCode, fmt.Errorf("invalid path of the entry: %q", entry.Path)
+		}
+		files = append(files, entry)
+	}
+	sort.Slice(files, func(i, j int) bool { return files[i].Path < files[j].Path })
+
+	return NewExportManifest(projectName, repoName, rev, pathPattern, files), files, httpStatusCode, nil
+}
+
+// NewExportManifest returns the ExportManifest of the files at the revision, which are fetched with
+// the path pattern. The entries should not contain directories.
+func NewExportManifest(projectName, repoName string, revision int, pathPattern string,
+	entries []*Entry) *ExportManifest {
+	manifest := &ExportManifest{
+		Project:     projectName,
+		Repository:  repoName,
+		Revision:    revision,
+		PathPattern: pathPattern,
+		Files:       make([]*ExportedFile, len(entries)),
+	}
+	for i, entry := range entries {
+		sum := sha256.Sum256(entry.Content)
+		manifest.Files[i] = &ExportedFile{
+			Path:       entry.Path,
+			Type:       entry.Type.String(),
+			Size:       len(entry.Content),
+			SHA256:     hex.EncodeToString(sum[:]),
+			ModifiedAt: entry.ModifiedAt,
+		}
+	}
+	return manifest
+}
+
+func marshalManifest(manifest *ExportManifest) ([]byte, error) {
+	b, err := json.MarshalIndent(manifest, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	return append(b, '\n'), nil
+}
+
+// modTime returns the time when the entry was modified, or the zero time if it is unknown.
+func modTime(entry *Entry) time.Time {
+	t, err := entry.ModifiedTime()
+	if err != nil {
+		return time.Time{}
+	}
+	return t
+}
+
+// writeTarGz writes the manifest and the entries to the tar.gz archive. The manifest is written first, so that
+// a reader can see it before the files.
+func writeTarGz(w io.Writer, manifest *ExportManifest, entries []*Entry) error {
+	b, err := marshalManifest(manifest)
+	if err != nil {
+		return err
+	}
+	gw := gzip.NewWriter(w)
+	tw := tar.NewWriter(gw)
+	write := func(name string, content []byte, modTime time.Time) error {
+		header := &tar.Header{
+			Typeflag: tar.TypeReg,
+			Name:     name,
+			Size:     int64(len(content)),
+			Mode:     0644,
+			ModTime:  modTime,
+		}
+		if err := tw.WriteHeader(header); err != nil {
+			return err
+		}
+		_, err := tw.Write(content)
+		return err
+	}
+
+	if err := write(ExportManifestName, b, time.Time{}); err != nil {
+		return err
+	}
+	for _, entry := range entries {
+		if err := write(strings.TrimPrefix(entry.Path, "/"), entry.Content, modTime(entry)); err != nil {
+			return err
+		}
+	}
+	if err := tw.Close(); err != nil {
+		return err
+	}
+	return gw.Close()
+}
+
+// writeZip writes the manifest and the entries to the zip archive.
+func writeZip(w io.Writer, manifest *ExportManifest, entries []*Entry) error {
+	b, err := marshalManifest(manifest)
+	if err != nil {
+		return err
+	}
+	zw := zip.NewWriter(w)
+	write := func(name string, content []byte, modTime time.Time) error {
+		header := &zip.FileHeader{Name: name, Method: zip.Deflate}
+		if !modTime.IsZero() {
+			header.Modified = modTime
+		}
+		fw, err := zw.CreateHeader(header)
+		if err != nil {
+			return err
+		}
+		_, err = fw.Write(content)
+		return err
+	}
+
+	if err := write(ExportManifestName, b, time.Time{}); err != nil {
+		return err
+	}
+	for _, entry := range entries {
+		if err := write(strings.TrimPrefix(entry.Path, "/"), entry.Content, modTime(entry)); err != nil {
+			return err
+		}
+	}
+	return zw.Close()
+}
@@ -0,0 +1,169 @@
+// Copyright 2026 LINE Corporation
+//
+// LINE Corporation licenses this file to you under the Apache License,
+// version 2.0 (the "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at:
+//
+//   https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+// WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+// License for the specific language governing permissions and limitations
+// under the License.
+
+package centraldogma
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func setupExport(t *testing.T) (*Client, func()) {
+	c, mux, teardown := setup()
+
+	mux.HandleFunc("/api/v1/projects/foo/repos/bar/revision/-1", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, http.MethodGet)
+		fmt.Fprint(w, `{"revision":3}`)
+	})
+	mux.HandleFunc("/api/v1/projects/foo/repos/bar/contents/**", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, http.MethodGet)
+		testURLQuery(t, r, "revision", "3")
+		fmt.Fprint(w, `[{"path":"/b/c.txt", "type":"TEXT", "content":"hello", "modifiedAt":"2026-01-02T03:04:05Z"},
+{"path":"/b", "type":"DIRECTORY"},
+{"path":"/a.json", "type":"JSON", "content":{"a":1}}]`)
+	})
+	return c, teardown
+}
+
+func checkExportManifest(t *testing.T, manifest *ExportManifest) {
+	t.Helper()
+	if manifest.Revision != 3 || manifest.PathPattern != "/**" || len(manifest.Files) != 2 {
+		t.Fatalf("manifest = %+v, want 2 files at the revision 3", manifest)
+	}
+	want := &ExportedFile{
+		Path: "/a.json", Type: "JSON", Size: 7,
+		SHA256: "015abd7f5cc57a2dd94b7590f04ad8084273905ee33ec5cebeae62276a97f862",
+	}
+	if !reflect.DeepEqual(manifest.Files[0], want) {
+		t.Errorf("manifest.Files[0] = %+v, want %+v", manifest.Files[0], want)
+	}
+	if f := manifest.Files[1]; f.Path != "/b/c.txt" || f.Type != "TEXT" || f.Size != 5 ||
+		f.ModifiedAt != "2026-01-02T03:04:05Z" {
+		t.Errorf("manifest.Files[1] = %+v, want /b/c.txt", f)
+	}
+}
+
+func TestExportTarGz(t *testing.T) {
+	c, teardown := setupExport(t)
+	defer teardown()
+
+	var buf bytes.Buffer
+	manifest, _, err := c.Export(context.Background(), "foo", "bar", 0, "", &buf, ExportTarGz)
+	if err != nil {
+		t.Fatal(err)
+	}
+	checkExportManifest(t, manifest)
+
+	gr, err := gzip.NewReader(&buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	tr := tar.NewReader(gr)
+	files := make(map[string]string)
+	var names []string
+	for {
+		header, err := tr.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			t.Fatal(err)
+		}
+		b, err := ioutil.ReadAll(tr)
+		if err != nil {
+			t.Fatal(err)
+		}
+		names = append(names, header.Name)
+		files[header.Name] = string(b)
+	}
+	if want := []string{ExportManifestName, "a.json", "b/c.txt"}; !reflect.DeepEqual(names, want) {
+		t.Errorf("files in the archive = %v, want %v", names, want)
+	}
+	if files["a.json"] != `{"a":1}` || files["b/c.txt"] != "hello" {
+		t.Errorf("contents of the archive = %v", files)
+	}
+	var archived ExportManifest
+	if err := json.Unmarshal([]byte(files[ExportManifestName]), &archived); err != nil {
+		t.Fatal(err)
+	}
+	checkExportManifest(t, &archived)
+}
+
+func TestExportZip(t *testing.T) {
+	c, teardown := setupExport(t)
+	defer teardown()
+
+	var buf bytes.Buffer
+	if _, _, err := c.Export(context.Background(), "foo", "bar", Head(), "/**", &buf, ExportZip); err != nil {
+		t.Fatal(err)
+	}
+	zr, err := zip.NewReader(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	var names []string
+	for _, f := range zr.File {
+		names = append(names, f.Name)
+	}
+	if want := []string{ExportManifestName, "a.json", "b/c.txt"}; !reflect.DeepEqual(names, want) {
+		t.Errorf("files in the archive = %v, want %v", names, want)
+	}
+}
+
+func TestExportToDirectory(t *testing.T) {
+	c, teardown := setupExport(t)
+	defer teardown()
+
+	dir := t.TempDir()
+	manifest, _, err := c.ExportToDirectory(context.Background(), "foo", "bar", Head(), "", dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	checkExportManifest(t, manifest)
+
+	b, err := ioutil.ReadFile(filepath.Join(dir, "b", "c.txt"))
+	if err != nil || string(b) != "hello" {
+		t.Errorf("b/c.txt = %q, %v, want hello", b, err)
+	}
+	if _, err := ioutil.ReadFile(filepath.Join(dir, ExportManifestName)); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestExportInvalidPath(t *testing.T) {
+	c, mux, teardown := setup()
+	defer teardown()
+
+	mux.HandleFunc("/api/v1/projects/foo/repos/bar/revision/-1", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"revision":3}`)
+	})
+	mux.HandleFunc("/api/v1/projects/foo/repos/bar/contents/**", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `[{"path":"/../a.txt", "type":"TEXT", "content":"hello"}]`)
+	})
+
+	if _, _, err := c.ExportToDirectory(context.Background(), "foo", "bar", Head(), "", t.TempDir()); err == nil {
+		t.Error("ExportToDirectory() with an invalid path should fail")
+	}
+}
@@ -0,0 +1,246 @@
+// Copyright 2026 LINE Corporation
+//
+// LINE Corporation licenses this file to you under the Apache License,
+// version 2.0 (the "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at:
+//
+//   https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+// WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+// License for the specific language governing permissions and limitations
+// under the License.
+
+package centraldogma
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+const defaultHistoryPageSize = 100
+
+// HistoryOptions specifies the commits which a HistoryIterator returns.
+type HistoryOptions struct {
+	// From and To are the revisions to iterate between, both inclusive. The commits are returned from
+	// the From to the To, so the history is iterated backward if the From is newer than the To.
+	// From is Head() and To is Init() by default.
+	From, To Revision
+
+	// PathPattern filters the commits which touched the files that match it. All commits are
+	// returned by default.
+	PathPattern string
+
+	// Author filters the commits whose author has the name or the email.
+	Author string
+
+	// Since and Until filter the commits which were pushed in the time range, both inclusive.
+	// A zero value means the range is not bounded.
+	Since, Until time.Time
+
+	// PageSize is the maximum number of commits fetched with a request. 100 is used by default.
+	PageSize int
+}
+
+// HistoryIterator iterates the commits of a repository lazily, fetching a page of commits at a time.
+// For example:
+//
+//	it := client.IterateHistory(ctx, "foo", "bar", &HistoryOptions{Author: "minux"})
+//	for it.Next() {
+//	    fmt.Println(it.Commit().Revision)
+//	}
+//	if err := it.Err(); err != nil {
+//	    panic(err)
+//	}
+type HistoryIterator struct {
+	ctx         context.Context
+	opts        HistoryOptions
+	historyFunc HistoryFunc
+	normalize   func(ctx context.Context, revision Revision) (int, error)
+
+	initialized bool
+	next, end   int // the revisions to fetch
+	descending  bool
+
+	page   []*Commit
+	commit *Commit
+	done   bool
+	err    error
+}
+
+// HistoryFunc returns at most maxCommits commits from the from revision to the to revision, both inclusive,
+// which touched the files that match the pathPattern, like Client.GetHistoryBetween does. The revisions can be
+// relative.
+type HistoryFunc func(ctx context.Context, from, to Revision, pathPattern string, maxCommits int) ([]*Commit, error)
+
+// NewHistoryIterator returns a HistoryIterator which fetches the pages of commits with the historyFunc, which is
+// useful to substitute the HistoryIterator of a Client in tests. The relative revisions of the opts are
+// normalized by fetching the commits at them with the historyFunc.
+func NewHistoryIterator(ctx context.Context, opts *HistoryOptions, historyFunc HistoryFunc) *HistoryIterator {
+	normalize := func(ctx context.Context, revision Revision) (int, error) {
+		if revision.IsAbsolute() {
+			return int(revision), nil
+		}
+		commits, err := historyFunc(ctx, revision, revision, "", 1)
+		if err != nil {
+			return 0, err
+		}
+		if len(commits) == 0 {
+			return 0, fmt.Errorf("no commit at the revision %v", revision)
+		}
+		return commits[0].Revision, nil
+	}
+	return newHistoryIterator(ctx, opts, historyFunc, normalize)
+}
+
+func newHistoryIterator(ctx context.Context, opts *HistoryOptions, historyFunc HistoryFunc,
+	normalize func(ctx context.Context, revision Revision) (int, error)) *HistoryIterator {
+	it := &HistoryIterator{ctx: ctx, historyFunc: historyFunc, normalize: normalize}
+	if opts != nil {
+		it.opts = *opts
+	}
+	if it.opts.From == 0 {
+		it.opts.From = HeadRevision
+	}
+	if it.opts.To == 0 {
+		it.opts.To = InitRevision
+	}
+	if it.opts.PageSize <= 0 {
+		it.opts.PageSize = defaultHistoryPageSize
+	}
+	return it
+}
+
+func (con *contentService) iterateHistory(ctx context.Context,
+	projectName, repoName string, opts *HistoryOptions) *HistoryIterator {
+	historyFunc := func(ctx context.Context, from, to Revision, pathPattern string,
+		maxCommits int) ([]*Commit, error) {
+		commits, _, err := con.getHistory(ctx, projectName, repoName, from.String(), to.String(),
+			pathPattern, maxCommits)
+		return commits, err
+	}
+	normalize := func(ctx context.Context, revision Revision) (int, error) {
+		rev, _, err := con.client.repository.absoluteRevision(ctx, projectName, repoName, revision.String())
+		return rev, err
+	}
+	return newHistoryIterator(ctx, opts, historyFunc, normalize)
+}
+
+// Next advances the iterator to the next commit, which is available from Commit. It returns false when
+// there are no more commits or an error occurs, e.g. the context is done. Err returns the error.
+func (it *HistoryIterator) Next() bool {
+	if it.done {
+		return false
+	}
+	for {
+		if err := it.ctx.Err(); err != nil {
+			return it.fail(err)
+		}
+		if len(it.page) == 0 {
+			if !it.fetch() {
+				return false
+			}
+			continue
+		}
+
+		commit := it.page[0]
+		it.page = it.page[1:]
+		matched, stop, err := it.match(commit)
+		if err != nil {
+			return it.fail(err)
+		}
+		if stop {
+			it.done = true
+			it.commit = nil
+			return false
+		}
+		if matched {
+			it.commit = commit
+			return true
+		}
+	}
+}
+
+// Commit returns the current commit.
+func (it *HistoryIterator) Commit() *Commit {
+	return it.commit
+}
+
+// Err returns the error which stopped the iteration, if any.
+func (it *HistoryIterator) Err() error {
+	return it.err
+}
+
+func (it *HistoryIterator) fail(err error) bool {
+	it.err = err
+	it.done = true
+	it.commit = nil
+	return false
+}
+
+// fetch fetches the next page of commits. It returns false if there are no more commits.
+func (it *HistoryIterator) fetch() bool {
+	if !it.initialized {
+		from, err := it.normalize(it.ctx, it.opts.From)
+		if err != nil {
+			return it.fail(err)
+		}
+		to, err := it.normalize(it.ctx, it.opts.To)
+		if err != nil {
+			return it.fail(err)
+		}
+		it.next, it.end, it.descending = from, to, from > to
+		it.initialized = true
+	}
+
+	if (it.descending && it.next < it.end) || (!it.descending && it.next > it.end) {
+		it.done = true
+		it.commit = nil
+		return false
+	}
+
+	commits, err := it.historyFunc(it.ctx, Revision(it.next), Revision(it.end), it.opts.PathPattern, it.opts.PageSize)
+	if err != nil {
+		return it.fail(err)
+	}
+	if len(commits) == 0 {
+		it.done = true
+		it.commit = nil
+		return false
+	}
+
+	last := commits[len(commits)-1].Revision
+	if it.descending {
+		it.next = last - 1
+	} else {
+		it.next = last + 1
+	}
+	it.page = commits
+	return true
+}
+
+// match returns whether the commit matches the options and whether the iteration can stop because
+// the rest of the commits are out of the time range.
+func (it *HistoryIterator) match(commit *Commit) (matched bool, stop bool, err error) {
+	if !it.opts.Since.IsZero() || !it.opts.Until.IsZero() {
+		pushedAt, err := parseTimestamp(commit.PushedAt)
+		if err != nil {
+			return false, false, err
+		}
+		if !it.opts.Since.IsZero() && pushedAt.Before(it.opts.Since) {
+			// The older commits were pushed before the Since as well.
+			return false, it.descending, nil
+		}
+		if !it.opts.Until.IsZero() && pushedAt.After(it.opts.Until) {
+			return false, !it.descending, nil
+		}
+	}
+
+	if len(it.opts.Author) != 0 &&
+		commit.Author.Name != it.opts.Author && commit.Author.Email != it.opts.Author {
+		return false, false, nil
+	}
+	return true, false, nil
+}
@@ -0,0 +1,135 @@
+// Copyright 2026 LINE Corporation
+//
+// LINE Corporation licenses this file to you under the Apache License,
+// version 2.0 (the "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at:
+//
+//   https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+// WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+// License for the specific language governing permissions and limitations
+// under the License.
+
+package centraldogma
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"reflect"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+)
+
+// handleHistory serves the history of five commits pushed a minute apart by "alice" and "bob" in turn.
+func handleHistory(t *testing.T, mux *http.ServeMux) *int {
+	var numRequests int
+	mux.HandleFunc("/api/v1/projects/foo/repos/bar/revision/-1", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"revision":5}`)
+	})
+	mux.HandleFunc("/api/v1/projects/foo/repos/bar/commits/", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, http.MethodGet)
+		numRequests++
+		from, _ := strconv.Atoi(strings.TrimPrefix(r.URL.Path, "/api/v1/projects/foo/repos/bar/commits/"))
+		to, _ := strconv.Atoi(r.URL.Query().Get("to"))
+		maxCommits, _ := strconv.Atoi(r.URL.Query().Get("maxCommits"))
+
+		step := 1
+		if from > to {
+			step = -1
+		}
+		commits := []*Commit{}
+		for rev := from; len(commits) < maxCommits; rev += step {
+			author := "alice"
+			if rev%2 == 0 {
+				author = "bob"
+			}
+			pushedAt := time.Date(2026, 1, 1, 0, rev, 0, 0, time.UTC).Format(time.RFC3339)
+			commits = append(commits, &Commit{Revision: rev, Author: Author{Name: author}, PushedAt: pushedAt})
+			if rev == to {
+				break
+			}
+		}
+		_ = json.NewEncoder(w).Encode(commits)
+	})
+	return &numRequests
+}
+
+func iterateRevisions(t *testing.T, it *HistoryIterator) []int {
+	var revisions []int
+	for it.Next() {
+		revisions = append(revisions, it.Commit().Revision)
+	}
+	if err := it.Err(); err != nil {
+		t.Fatal(err)
+	}
+	return revisions
+}
+
+func TestIterateHistory(t *testing.T) {
+	c, mux, teardown := setup()
+	defer teardown()
+	numRequests := handleHistory(t, mux)
+
+	it := c.IterateHistory(context.Background(), "foo", "bar", &HistoryOptions{PageSize: 2})
+	if got, want := iterateRevisions(t, it), []int{5, 4, 3, 2, 1}; !reflect.DeepEqual(got, want) {
+		t.Errorf("IterateHistory returned %v, want %v", got, want)
+	}
+	if *numRequests != 3 {
+		t.Errorf("IterateHistory sent %v requests, want %v", *numRequests, 3)
+	}
+
+	it = c.IterateHistory(context.Background(), "foo", "bar", &HistoryOptions{From: Revision(2), To: Head(), PageSize: 3})
+	if got, want := iterateRevisions(t, it), []int{2, 3, 4, 5}; !reflect.DeepEqual(got, want) {
+		t.Errorf("IterateHistory returned %v, want %v", got, want)
+	}
+}
+
+func TestIterateHistory_Filter(t *testing.T) {
+	c, mux, teardown := setup()
+	defer teardown()
+	numRequests := handleHistory(t, mux)
+
+	it := c.IterateHistory(context.Background(), "foo", "bar", &HistoryOptions{Author: "alice"})
+	if got, want := iterateRevisions(t, it), []int{5, 3, 1}; !reflect.DeepEqual(got, want) {
+		t.Errorf("IterateHistory returned %v, want %v", got, want)
+	}
+
+	*numRequests = 0
+	it = c.IterateHistory(context.Background(), "foo", "bar", &HistoryOptions{
+		Since:    time.Date(2026, 1, 1, 0, 3, 0, 0, time.UTC),
+		Until:    time.Date(2026, 1, 1, 0, 4, 0, 0, time.UTC),
+		PageSize: 1,
+	})
+	if got, want := iterateRevisions(t, it), []int{4, 3}; !reflect.DeepEqual(got, want) {
+		t.Errorf("IterateHistory returned %v, want %v", got, want)
+	}
+	// The iteration stops at the revision 2 which was pushed before the Since.
+	if *numRequests != 4 {
+		t.Errorf("IterateHistory sent %v requests, want %v", *numRequests, 4)
+	}
+}
+
+func TestIterateHistory_ContextCanceled(t *testing.T) {
+	c, mux, teardown := setup()
+	defer teardown()
+	handleHistory(t, mux)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	it := c.IterateHistory(ctx, "foo", "bar", &HistoryOptions{PageSize: 2})
+	if !it.Next() {
+		t.Fatalf("Next returned false: %v", it.Err())
+	}
+	cancel()
+	if it.Next() {
+		t.Error("Next returned true after the context is canceled")
+	}
+	if it.Err() != context.Canceled {
+		t.Errorf("Err returned %v, want %v", it.Err(), context.Canceled)
+	}
+}
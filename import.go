@@ -0,0 +1,343 @@
+// Copyright 2026 LINE Corporation
+//
+// LINE Corporation licenses this file to you under the Apache License,
+// version 2.0 (the "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at:
+//
+//   https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+// WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+// License for the specific language governing permissions and limitations
+// under the License.
+
+package centraldogma
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"path"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// ImportOptions specifies how the files are imported into a repository.
+type ImportOptions struct {
+	// BaseRevision is the revision which the files are compared with. Head() is used by default.
+	BaseRevision Revision
+	// Path is the directory of the repository which the files are imported into. "/" is used if empty.
+	Path string
+	// RemoveMissing specifies whether to remove the files under the Path which do not exist in the imported
+	// files. The files and the directories whose names start with "." are not removed, because ReadImportFiles
+	// skips them.
+	RemoveMissing bool
+}
+
+// ImportPlan is the set of the changes which imports the files into a repository.
+type ImportPlan struct {
+	Project      string
+	Repository   string
+	BaseRevision int // the absolute revision which the files were compared with
+	Path         string
+	Files        []*ImportedFile // sorted by path
+	Changes      []*Change
+}
+
+// ImportedFile describes how a file is changed by an ImportPlan.
+type ImportedFile struct {
+	Path   string
+	Action ImportAction
+	Type   EntryType // the type of the new content, or the type of the removed file
+	// OldContent is the content at the BaseRevision, which is nil if the file is added.
+	OldContent EntryContent
+	// NewContent is the imported content, which is nil if the file is removed.
+	NewContent EntryContent
+}
+
+// Count returns the number of the files which are changed by the action.
+func (p *ImportPlan) Count(action ImportAction) int {
+	n := 0
+	for _, f := range p.Files {
+		if f.Action == action {
+			n++
+		}
+	}
+	return n
+}
+
+// ReadImportFiles reads the files to import from a directory or a tar.gz, .tgz or .zip archive, and returns
+// their contents keyed by their slash-separated paths relative to the root of the directory or the archive.
+// The files and the directories whose names start with ".", e.g. ".git" and ExportManifestName, are skipped.
+func ReadImportFiles(src string) (map[string][]byte, error) {
+	info, err := os.Stat(src)
+	if err != nil {
+		return nil, err
+	}
+	if info.IsDir() {
+		return readImportDirectory(src)
+	}
+
+	lower := strings.ToLower(src)
+	switch {
+	case strings.HasSuffix(lower, ".tar.gz") || strings.HasSuffix(lower, ".tgz"):
+		f, err := os.Open(src)
+		if err != nil {
+			return nil, err
+		}
+		defer f.Close()
+		return readImportTarGz(f)
+	case strings.HasSuffix(lower, ".zip"):
+		return readImportZip(src)
+	default:
+		return nil, fmt.Errorf("%s is neither a directory nor a tar.gz or zip archive", src)
+	}
+}
+
+func readImportDirectory(dir string) (map[string][]byte, error) {
+	files := make(map[string][]byte)
+	err := filepath.Walk(dir, func(p string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if strings.HasPrefix(info.Name(), ".") && p != dir {
+			if info.IsDir() {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if !info.Mode().IsRegular() {
+			return nil
+		}
+		rel, err := filepath.Rel(dir, p)
+		if err != nil {
+			return err
+		}
+		content, err := ioutil.ReadFile(p)
+		if err != nil {
+			return err
+		}
+		files[filepath.ToSlash(rel)] = content
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return files, nil
+}
+
+func readImportTarGz(r io.Reader) (map[string][]byte, error) {
+	gr, err := gzip.NewReader(r)
+	if err != nil {
+		return nil, err
+	}
+	tr := tar.NewReader(gr)
+	files := make(map[string][]byte)
+	for {
+		header, err := tr.Next()
+		if err == io.EOF {
+			return files, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+		if header.Typeflag != tar.TypeReg || skipImportPath(header.Name) {
+			continue
+		}
+		content, err := ioutil.ReadAll(tr)
+		if err != nil {
+			return nil, err
+		}
+		files[strings.TrimPrefix(header.Name, "./")] = content
+	}
+}
+
+func readImportZip(name string) (map[string][]byte, error) {
+	zr, err := zip.OpenReader(name)
+	if err != nil {
+		return nil, err
+	}
+	defer zr.Close()
+	files := make(map[string][]byte)
+	for _, f := range zr.File {
+		if f.FileInfo().IsDir() || skipImportPath(f.Name) {
+			continue
+		}
+		rc, err := f.Open()
+		if err != nil {
+			return nil, err
+		}
+		content, err := ioutil.ReadAll(rc)
+		rc.Close()
+		if err != nil {
+			return nil, err
+		}
+		files[strings.TrimPrefix(f.Name, "./")] = content
+	}
+	return files, nil
+}
+
+// skipImportPath returns whether the relative path has a file or a directory whose name starts with ".".
+func skipImportPath(name string) bool {
+	for _, s := range strings.Split(strings.TrimPrefix(name, "./"), "/") {
+		if strings.HasPrefix(s, ".") {
+			return true
+		}
+	}
+	return false
+}
+
+func (con *contentService) planImport(ctx context.Context, projectName, repoName string,
+	files map[string][]byte, opts *ImportOptions) (*ImportPlan, int, error) {
+	if opts == nil {
+		opts = &ImportOptions{}
+	}
+	baseRevision := opts.BaseRevision
+	if baseRevision == 0 {
+		baseRevision = HeadRevision
+	}
+	dir := path.Clean("/" + opts.Path)
+	if dir != "/" {
+		dir += "/"
+	}
+
+	rev, httpStatusCode, err := con.client.repository.normalizeRevision(ctx, projectName, repoName,
+		baseRevision.String())
+	if err != nil {
+		return nil, httpStatusCode, err
+	}
+	entries, httpStatusCode, err := con.getFiles(ctx, projectName, repoName, strconv.Itoa(rev), dir+"**")
+	if err != nil {
+		// The repository exists at the revision, so 404 means that no files are under the directory.
+		if httpStatusCode != http.StatusNotFound {
+			return nil, httpStatusCode, err
+		}
+		entries, httpStatusCode = nil, http.StatusOK
+	}
+	remote := make(map[string]*Entry, len(entries))
+	for _, entry := range entries {
+		if entry.Type != Directory {
+			remote[entry.Path] = entry
+		}
+	}
+
+	plan := &ImportPlan{Project: projectName, Repository: repoName, BaseRevision: rev, Path: dir}
+	imported := make(map[string]bool, len(files))
+	for name, content := range files {
+		p := path.Clean(dir + name)
+		if !strings.HasPrefix(p, dir) {
+			return nil, UnknownHttpStatusCode, fmt.Errorf("invalid path to import: %q", name)
+		}
+		imported[p] = true
+
+		f, err := newImportedFile(p, content, remote[p])
+		if err != nil {
+			return nil, UnknownHttpStatusCode, err
+		}
+		if f != nil {
+			plan.Files = append(plan.Files, f)
+		}
+	}
+	if opts.RemoveMissing {
+		for p, entry := range remote {
+			if !imported[p] && !skipImportPath(strings.TrimPrefix(p, dir)) {
+				plan.Files = append(plan.Files, &ImportedFile{
+					Path: p, Action: ImportRemoved, Type: entry.Type, OldContent: entry.Content,
+				})
+			}
+		}
+	}
+	sort.Slice(plan.Files, func(i, j int) bool { return plan.Files[i].Path < plan.Files[j].Path })
+
+	for _, f := range plan.Files {
+		change, err := f.change()
+		if err != nil {
+			return nil, UnknownHttpStatusCode, err
+		}
+		plan.Changes = append(plan.Changes, change)
+	}
+	return plan, httpStatusCode, nil
+}
+
+// newImportedFile returns the ImportedFile which upserts the content, or nil if the content is the same as
+// the remote entry. The type of the file is detected by its extension like the "dogma put" command does.
+func newImportedFile(p string, content []byte, remote *Entry) (*ImportedFile, error) {
+	f := &ImportedFile{Path: p, Action: ImportAdded, Type: Text, NewContent: EntryContent(content)}
+	if strings.HasSuffix(strings.ToLower(p), ".json") {
+		if !json.Valid(content) {
+			return nil, fmt.Errorf("not a valid JSON file: %s", p)
+		}
+		f.Type = JSON
+	}
+	if remote == nil {
+		return f, nil
+	}
+
+	f.Action = ImportModified
+	f.OldContent = remote.Content
+	if remote.Type != f.Type {
+		return f, nil
+	}
+	if f.Type == JSON {
+		if jsonEqual(remote.Content, content) {
+			return nil, nil
+		}
+	} else if normalizeText(string(remote.Content)) == normalizeText(string(content)) {
+		return nil, nil
+	}
+	return f, nil
+}
+
+func (f *ImportedFile) change() (*Change, error) {
+	switch {
+	case f.Action == ImportRemoved:
+		return &Change{Path: f.Path, Type: Remove}, nil
+	case f.Type == JSON:
+		var content interface{}
+		if err := json.Unmarshal(f.NewContent, &content); err != nil {
+			return nil, err
+		}
+		return &Change{Path: f.Path, Type: UpsertJSON, Content: content}, nil
+	default:
+		return &Change{Path: f.Path, Type: UpsertText, Content: string(f.NewContent)}, nil
+	}
+}
+
+func jsonEqual(a, b []byte) bool {
+	var x, y interface{}
+	if json.Unmarshal(a, &x) != nil || json.Unmarshal(b, &y) != nil {
+		return bytes.Equal(a, b)
+	}
+	return reflect.DeepEqual(x, y)
+}
+
+// normalizeText normalizes the text like the server does when a text file is pushed, i.e. converts CRLF into
+// LF and appends a newline at the end of the text.
+func normalizeText(text string) string {
+	text = strings.ReplaceAll(text, "\r\n", "\n")
+	if len(text) != 0 && !strings.HasSuffix(text, "\n") {
+		text += "\n"
+	}
+	return text
+}
+
+func (con *contentService) importPlan(ctx context.Context, plan *ImportPlan,
+	commitMessage *CommitMessage) (*PushResult, int, error) {
+	if commitMessage == nil {
+		commitMessage = &CommitMessage{Summary: fmt.Sprintf("Import %d files into %s", len(plan.Files), plan.Path)}
+	}
+	return con.push(ctx, plan.Project, plan.Repository, strconv.Itoa(plan.BaseRevision), commitMessage,
+		plan.Changes)
+}
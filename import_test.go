@@ -0,0 +1,204 @@
+// Copyright 2026 LINE Corporation
+//
+// LINE Corporation licenses this file to you under the Apache License,
+// version 2.0 (the "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at:
+//
+//   https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+// WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+// License for the specific language governing permissions and limitations
+// under the License.
+
+package centraldogma
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestPlanImport(t *testing.T) {
+	c, mux, teardown := setup()
+	defer teardown()
+
+	mux.HandleFunc("/api/v1/projects/foo/repos/bar/revision/-1", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"revision":3}`)
+	})
+	mux.HandleFunc("/api/v1/projects/foo/repos/bar/contents/conf/**", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, http.MethodGet)
+		testURLQuery(t, r, "revision", "3")
+		fmt.Fprint(w, `[{"path":"/conf/a.json", "type":"JSON", "content":{"a":1,"b":2}},
+{"path":"/conf/b.txt", "type":"TEXT", "content":"hello\n"},
+{"path":"/conf/c.txt", "type":"TEXT", "content":"old\n"},
+{"path":"/conf/d.txt", "type":"TEXT", "content":"removed\n"},
+{"path":"/conf/.hidden.json", "type":"JSON", "content":{}},
+{"path":"/conf/.git/config", "type":"TEXT", "content":"kept\n"},
+{"path":"/conf/e", "type":"DIRECTORY"}]`)
+	})
+
+	files := map[string][]byte{
+		"a.json":   []byte(`{"b": 2, "a": 1}`),
+		"b.txt":    []byte("hello"),
+		"c.txt":    []byte("new"),
+		"e/f.json": []byte(`{"f":true}`),
+	}
+	plan, _, err := c.PlanImport(context.Background(), "foo", "bar", files,
+		&ImportOptions{Path: "conf", RemoveMissing: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if plan.BaseRevision != 3 || plan.Path != "/conf/" {
+		t.Errorf("plan = %+v, want the base revision 3 and /conf/", plan)
+	}
+
+	var actions []string
+	for _, f := range plan.Files {
+		actions = append(actions, f.Action.String()+" "+f.Path)
+	}
+	want := []string{"MODIFIED /conf/c.txt", "REMOVED /conf/d.txt", "ADDED /conf/e/f.json"}
+	if !reflect.DeepEqual(actions, want) {
+		t.Errorf("plan.Files = %v, want %v", actions, want)
+	}
+	if plan.Count(ImportModified) != 1 || plan.Count(ImportAdded) != 1 || plan.Count(ImportRemoved) != 1 {
+		t.Errorf("Count() does not match the files: %v", actions)
+	}
+
+	wantChanges := []*Change{
+		{Path: "/conf/c.txt", Type: UpsertText, Content: "new"},
+		{Path: "/conf/d.txt", Type: Remove},
+		{Path: "/conf/e/f.json", Type: UpsertJSON, Content: map[string]interface{}{"f": true}},
+	}
+	if !reflect.DeepEqual(plan.Changes, wantChanges) {
+		t.Errorf("plan.Changes = %+v, want %+v", plan.Changes, wantChanges)
+	}
+
+	if _, _, err := c.PlanImport(context.Background(), "foo", "bar",
+		map[string][]byte{"x.json": []byte("{")}, &ImportOptions{Path: "conf"}); err == nil {
+		t.Error("PlanImport() with an invalid JSON file should fail")
+	}
+	if _, _, err := c.PlanImport(context.Background(), "foo", "bar",
+		map[string][]byte{"../x.txt": []byte("x")}, &ImportOptions{Path: "conf"}); err == nil {
+		t.Error("PlanImport() with a path outside of the directory should fail")
+	}
+}
+
+func TestPlanImport_EmptyDirectory(t *testing.T) {
+	c, mux, teardown := setup()
+	defer teardown()
+
+	mux.HandleFunc("/api/v1/projects/foo/repos/bar/revision/-1", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"revision":1}`)
+	})
+	mux.HandleFunc("/api/v1/projects/foo/repos/bar/contents/**", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+		fmt.Fprint(w, `{"exception":"com.linecorp.centraldogma.common.EntryNotFoundException"}`)
+	})
+
+	plan, httpStatusCode, err := c.PlanImport(context.Background(), "foo", "bar",
+		map[string][]byte{"a.txt": []byte("a")}, nil)
+	if err != nil || httpStatusCode != http.StatusOK {
+		t.Fatalf("PlanImport() = %v, %v, want no error", httpStatusCode, err)
+	}
+	if len(plan.Files) != 1 || plan.Files[0].Action != ImportAdded || plan.Files[0].Path != "/a.txt" {
+		t.Errorf("plan.Files = %+v, want /a.txt to be added", plan.Files)
+	}
+}
+
+func TestImport(t *testing.T) {
+	c, mux, teardown := setup()
+	defer teardown()
+
+	mux.HandleFunc("/api/v1/projects/foo/repos/bar/contents", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, http.MethodPost)
+		testURLQuery(t, r, "revision", "3")
+
+		var reqBody push
+		_ = json.NewDecoder(r.Body).Decode(&reqBody)
+		want := push{
+			CommitMessage: &CommitMessage{Summary: "Import 1 files into /conf/"},
+			Changes:       []*Change{{Path: "/conf/a.txt", Type: UpsertText, Content: "a"}},
+		}
+		if !reflect.DeepEqual(reqBody, want) {
+			t.Errorf("Import request body %+v, want %+v", reqBody, want)
+		}
+		fmt.Fprint(w, `{"revision":4, "pushedAt":"2017-05-22T00:00:00Z"}`)
+	})
+
+	plan := &ImportPlan{
+		Project: "foo", Repository: "bar", BaseRevision: 3, Path: "/conf/",
+		Files:   []*ImportedFile{{Path: "/conf/a.txt", Action: ImportAdded, Type: Text, NewContent: EntryContent("a")}},
+		Changes: []*Change{{Path: "/conf/a.txt", Type: UpsertText, Content: "a"}},
+	}
+	result, _, err := c.Import(context.Background(), plan, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if result.Revision != 4 {
+		t.Errorf("Import returned %+v, want the revision 4", result)
+	}
+}
+
+func TestReadImportFiles(t *testing.T) {
+	dir := t.TempDir()
+	for name, content := range map[string]string{
+		"a.json":      `{"a":1}`,
+		"b/c.txt":     "c",
+		".git/config": "ignored",
+	} {
+		p := filepath.Join(dir, filepath.FromSlash(name))
+		if err := os.MkdirAll(filepath.Dir(p), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := ioutil.WriteFile(p, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	want := map[string][]byte{"a.json": []byte(`{"a":1}`), "b/c.txt": []byte("c")}
+
+	files, err := ReadImportFiles(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(files, want) {
+		t.Errorf("ReadImportFiles(dir) = %v, want %v", files, want)
+	}
+
+	// The archives written by Export can be imported, without the manifest.
+	entries := []*Entry{
+		{Path: "/a.json", Type: JSON, Content: EntryContent(`{"a":1}`)},
+		{Path: "/b/c.txt", Type: Text, Content: EntryContent("c")},
+	}
+	for _, format := range []ExportFormat{ExportTarGz, ExportZip} {
+		var buf bytes.Buffer
+		var err error
+		if format == ExportTarGz {
+			err = writeTarGz(&buf, &ExportManifest{}, entries)
+		} else {
+			err = writeZip(&buf, &ExportManifest{}, entries)
+		}
+		if err != nil {
+			t.Fatal(err)
+		}
+		name := filepath.Join(t.TempDir(), "export."+format.String())
+		if err := ioutil.WriteFile(name, buf.Bytes(), 0644); err != nil {
+			t.Fatal(err)
+		}
+		files, err := ReadImportFiles(name)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !reflect.DeepEqual(files, want) {
+			t.Errorf("ReadImportFiles(%s) = %v, want %v", format, files, want)
+		}
+	}
+}
@@ -0,0 +1,119 @@
+// Copyright 2026 LINE Corporation
+//
+// LINE Corporation licenses this file to you under the Apache License,
+// version 2.0 (the "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at:
+//
+//   https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+// WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+// License for the specific language governing permissions and limitations
+// under the License.
+
+package centraldogma
+
+import (
+	"context"
+	"time"
+
+	"go.linecorp.com/centraldogma/jsonpatch"
+)
+
+// The interfaces below are implemented by *Client. Depend on the smallest one your code needs, so that
+// a fake such as dogmamock.Client can be substituted for the Client in tests.
+var (
+	_ ProjectManager = (*Client)(nil)
+	_ ContentReader  = (*Client)(nil)
+	_ ContentWriter  = (*Client)(nil)
+	_ Watching       = (*Client)(nil)
+)
+
+// ProjectManager creates, removes and lists projects and repositories.
+type ProjectManager interface {
+	CreateProject(ctx context.Context, name string) (pro *Project, httpStatusCode int, err error)
+	RemoveProject(ctx context.Context, name string) (httpStatusCode int, err error)
+	PurgeProject(ctx context.Context, name string) (httpStatusCode int, err error)
+	UnremoveProject(ctx context.Context, name string) (pro *Project, httpStatusCode int, err error)
+	ListProjects(ctx context.Context) (pros []*Project, httpStatusCode int, err error)
+	ListRemovedProjects(ctx context.Context) (removedPros []*Project, httpStatusCode int, err error)
+
+	CreateRepository(ctx context.Context,
+		projectName, repoName string) (repo *Repository, httpStatusCode int, err error)
+	RemoveRepository(ctx context.Context, projectName, repoName string) (httpStatusCode int, err error)
+	PurgeRepository(ctx context.Context, projectName, repoName string) (httpStatusCode int, err error)
+	UnremoveRepository(ctx context.Context,
+		projectName, repoName string) (repo *Repository, httpStatusCode int, err error)
+	ListRepositories(ctx context.Context, projectName string) (repos []*Repository, httpStatusCode int, err error)
+	ListRemovedRepositories(ctx context.Context,
+		projectName string) (removedRepos []*Repository, httpStatusCode int, err error)
+}
+
+// ContentReader reads the files, the history and the diffs of repositories.
+type ContentReader interface {
+	NormalizeRevision(ctx context.Context,
+		projectName, repoName, revision string) (normalizedRev int, httpStatusCode int, err error)
+	Normalize(ctx context.Context,
+		projectName, repoName string, revision Revision) (normalizedRev Revision, httpStatusCode int, err error)
+	ListFiles(ctx context.Context,
+		projectName, repoName, revision, pathPattern string) (entries []*Entry, httpStatusCode int, err error)
+	ListFilesAt(ctx context.Context, projectName, repoName string, revision Revision,
+		pathPattern string) (entries []*Entry, httpStatusCode int, err error)
+	GetFile(ctx context.Context,
+		projectName, repoName, revision string, query *Query) (entry *Entry, httpStatusCode int, err error)
+	GetFileAt(ctx context.Context, projectName, repoName string, revision Revision,
+		query *Query) (entry *Entry, httpStatusCode int, err error)
+	GetFiles(ctx context.Context,
+		projectName, repoName, revision, pathPattern string) (entries []*Entry, httpStatusCode int, err error)
+	GetFilesAt(ctx context.Context, projectName, repoName string, revision Revision,
+		pathPattern string) (entries []*Entry, httpStatusCode int, err error)
+	GetHistory(ctx context.Context, projectName, repoName, from, to, pathPattern string,
+		maxCommits int) (commits []*Commit, httpStatusCode int, err error)
+	GetHistoryBetween(ctx context.Context, projectName, repoName string, from, to Revision,
+		pathPattern string, maxCommits int) (commits []*Commit, httpStatusCode int, err error)
+	IterateHistory(ctx context.Context, projectName, repoName string, opts *HistoryOptions) *HistoryIterator
+	GetCommit(ctx context.Context,
+		projectName, repoName string, revision Revision) (detail *CommitDetail, httpStatusCode int, err error)
+	GetDiff(ctx context.Context,
+		projectName, repoName, from, to string, query *Query) (change *Change, httpStatusCode int, err error)
+	GetDiffBetween(ctx context.Context, projectName, repoName string, from, to Revision,
+		query *Query) (change *Change, httpStatusCode int, err error)
+	GetDiffs(ctx context.Context,
+		projectName, repoName, from, to, pathPattern string) (changes []*Change, httpStatusCode int, err error)
+	GetDiffsBetween(ctx context.Context, projectName, repoName string, from, to Revision,
+		pathPattern string) (changes []*Change, httpStatusCode int, err error)
+	Blame(ctx context.Context,
+		projectName, repoName, path string, revision Revision) (blame *Blame, httpStatusCode int, err error)
+	MergeFiles(ctx context.Context, projectName, repoName string, revision Revision,
+		mergeQuery *MergeQuery) (mergedEntry *MergedEntry, httpStatusCode int, err error)
+}
+
+// ContentWriter pushes changes to repositories.
+type ContentWriter interface {
+	Push(ctx context.Context, projectName, repoName, baseRevision string,
+		commitMessage *CommitMessage, changes []*Change) (result *PushResult, httpStatusCode int, err error)
+	PushAt(ctx context.Context, projectName, repoName string, baseRevision Revision,
+		commitMessage *CommitMessage, changes []*Change) (result *PushResult, httpStatusCode int, err error)
+	PatchJSON(ctx context.Context, projectName, repoName string, baseRevision Revision,
+		commitMessage *CommitMessage, path string, patch jsonpatch.Patch) (result *PushResult, httpStatusCode int, err error)
+	PatchText(ctx context.Context, projectName, repoName string, baseRevision Revision,
+		commitMessage *CommitMessage, path string, patch string) (result *PushResult, httpStatusCode int, err error)
+	Update(ctx context.Context, projectName, repoName, path string,
+		commitMessage *CommitMessage, updateFunc UpdateFunc) (result *PushResult, httpStatusCode int, err error)
+	Revert(ctx context.Context, projectName, repoName string, revision Revision,
+		commitMessage *CommitMessage) (result *PushResult, httpStatusCode int, err error)
+	Rollback(ctx context.Context, projectName, repoName, pathPattern string,
+		toRevision Revision) (result *PushResult, httpStatusCode int, err error)
+}
+
+// Watching watches the changes of files and repositories.
+type Watching interface {
+	WatchFile(ctx context.Context, projectName, repoName string, query *Query,
+		timeout time.Duration) (result <-chan WatchResult, closer func(), err error)
+	WatchRepository(ctx context.Context, projectName, repoName, pathPattern string,
+		timeout time.Duration) (result <-chan WatchResult, closer func(), err error)
+	FileWatcher(projectName, repoName string, query *Query) (*Watcher, error)
+	RepoWatcher(projectName, repoName, pathPattern string) (*Watcher, error)
+	MergeWatcher(projectName, repoName string, mergeQuery *MergeQuery) (*Watcher, error)
+}
@@ -0,0 +1,142 @@
+// Copyright 2026 LINE Corporation
+//
+// LINE Corporation licenses this file to you under the Apache License,
+// version 2.0 (the "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at:
+//
+//   https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+// WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+// License for the specific language governing permissions and limitations
+// under the License.
+
+package main
+
+import (
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"os"
+	"os/exec"
+	"os/signal"
+	"strconv"
+	"syscall"
+
+	"github.com/urfave/cli/v2"
+	"go.linecorp.com/centraldogma/syncagent"
+)
+
+// An agentCommand keeps a local directory in sync with the files which match the path pattern until it is
+// interrupted.
+type agentCommand struct {
+	out          io.Writer
+	repo         repositoryRequestInfo
+	pathPattern  string
+	dir          string
+	listenerFile string
+	httpAddr     string
+}
+
+func (ac *agentCommand) execute(c *cli.Context) error {
+	client, err := newDogmaClient(c, ac.repo.remoteURL)
+	if err != nil {
+		return err
+	}
+
+	agent, err := ac.newAgent(client)
+	if err != nil {
+		return err
+	}
+	if err := agent.Start(); err != nil {
+		return err
+	}
+	defer agent.Close()
+
+	errChan := make(chan error, 1)
+	if len(ac.httpAddr) != 0 {
+		listener, err := net.Listen("tcp", ac.httpAddr)
+		if err != nil {
+			return err
+		}
+		mux := http.NewServeMux()
+		mux.Handle("/health", agent)
+		server := &http.Server{Handler: mux}
+		defer server.Close()
+		go func() {
+			errChan <- server.Serve(listener)
+		}()
+		fmt.Fprintf(ac.out, "Serving the health of the agent at http://%s/health\n", listener.Addr())
+	}
+
+	signalChan := make(chan os.Signal, 1)
+	signal.Notify(signalChan, os.Interrupt, syscall.SIGTERM)
+	defer signal.Stop(signalChan)
+	select {
+	case <-signalChan:
+		fmt.Fprintln(ac.out, "\nReceived an interrupt, stopping agent...")
+		return nil
+	case err := <-errChan:
+		return err
+	}
+}
+
+func (ac *agentCommand) newAgent(client syncagent.Client) (*syncagent.Agent, error) {
+	repo := ac.repo
+	return syncagent.New(client, syncagent.Config{
+		Project:     repo.projName,
+		Repository:  repo.repoName,
+		PathPattern: ac.pathPattern,
+		Dir:         ac.dir,
+		OnSync:      ac.onSync,
+	})
+}
+
+// onSync prints the synced revision, and runs the listener if specified. The metadata of the sync are
+// available to the listener via environment variables.
+func (ac *agentCommand) onSync(status syncagent.Status) error {
+	fmt.Fprintf(ac.out, "Synced: %d files at the revision %d to %s\n", status.Files, status.Revision, status.Dir)
+	if len(ac.listenerFile) == 0 {
+		return nil
+	}
+
+	command := exec.Command(ac.listenerFile)
+	command.Env = append(os.Environ(),
+		"DOGMA_AGENT_PROJECT="+status.Project,
+		"DOGMA_AGENT_REPO="+status.Repository,
+		"DOGMA_AGENT_PATH_PATTERN="+status.PathPattern,
+		"DOGMA_AGENT_DIR="+status.Dir,
+		"DOGMA_AGENT_REV="+strconv.Itoa(status.Revision))
+	command.Stdout = ac.out
+	command.Stderr = os.Stderr
+	if err := command.Run(); err != nil {
+		return &listenerExecError{underlying: err, command: ac.listenerFile}
+	}
+	return nil
+}
+
+// newAgentCommand creates the agentCommand.
+func newAgentCommand(c *cli.Context, out io.Writer) (Command, error) {
+	repo, err := newRepositoryRequestInfo(c)
+	if err != nil {
+		return nil, err
+	}
+	if c.Args().Len() != 2 || len(c.Args().Get(1)) == 0 {
+		return nil, newCommandLineError(c)
+	}
+
+	pathPattern := repo.path
+	if pathPattern == "/" {
+		pathPattern = "/**"
+	}
+	return &agentCommand{
+		out:          out,
+		repo:         repo,
+		pathPattern:  pathPattern,
+		dir:          c.Args().Get(1),
+		listenerFile: c.String("listener"),
+		httpAddr:     c.String("http"),
+	}, nil
+}
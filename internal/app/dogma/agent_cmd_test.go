@@ -0,0 +1,92 @@
+// Copyright 2026 LINE Corporation
+//
+// LINE Corporation licenses this file to you under the Apache License,
+// version 2.0 (the "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at:
+//
+//   https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+// WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+// License for the specific language governing permissions and limitations
+// under the License.
+
+package main
+
+import (
+	"bytes"
+	"flag"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"reflect"
+	"runtime"
+	"testing"
+
+	"github.com/urfave/cli/v2"
+	"go.linecorp.com/centraldogma/syncagent"
+)
+
+func newAgentCmdContext(flagArguments []string, connectURL, listener string) *cli.Context {
+	parent := newParentContext(connectURL)
+
+	flags := flag.FlagSet{}
+	flags.Parse(flagArguments)
+	flags.String("revision", "", "")
+	flags.String("listener", listener, "")
+	flags.String("http", ":8080", "")
+	return cli.NewContext(nil, &flags, parent)
+}
+
+func TestNewAgentCommand(t *testing.T) {
+	defaultRemoteURL := "http://localhost:36462/"
+
+	c := newAgentCmdContext([]string{"foo/bar", "/etc/app"}, defaultRemoteURL, "./reload.sh")
+	got, err := newAgentCommand(c, os.Stdout)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := agentCommand{
+		out: os.Stdout,
+		repo: repositoryRequestInfo{
+			remoteURL: defaultRemoteURL, projName: "foo", repoName: "bar",
+			path: "/", revision: "-1"},
+		pathPattern: "/**", dir: "/etc/app", listenerFile: "./reload.sh", httpAddr: ":8080",
+	}
+	if got2 := *got.(*agentCommand); !reflect.DeepEqual(got2, want) {
+		t.Errorf("newAgentCommand() = %+v, want: %+v", got2, want)
+	}
+
+	c = newAgentCmdContext([]string{"foo/bar"}, defaultRemoteURL, "")
+	if _, err := newAgentCommand(c, os.Stdout); err == nil {
+		t.Error("newAgentCommand() without the directory should fail")
+	}
+}
+
+func TestAgentListener(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("the listener is a shell script")
+	}
+	dir := t.TempDir()
+	listener := filepath.Join(dir, "listener.sh")
+	script := "#!/bin/sh\necho \"$DOGMA_AGENT_PROJECT/$DOGMA_AGENT_REPO $DOGMA_AGENT_REV\"\n"
+	if err := ioutil.WriteFile(listener, []byte(script), 0755); err != nil {
+		t.Fatal(err)
+	}
+
+	var out bytes.Buffer
+	cmd := &agentCommand{out: &out, listenerFile: listener}
+	status := syncagent.Status{Project: "foo", Repository: "bar", Dir: "/etc/app", Revision: 3, Files: 2}
+	if err := cmd.onSync(status); err != nil {
+		t.Fatal(err)
+	}
+	if want := "Synced: 2 files at the revision 3 to /etc/app\nfoo/bar 3\n"; out.String() != want {
+		t.Errorf("output = %q, want %q", out.String(), want)
+	}
+
+	cmd.listenerFile = filepath.Join(dir, "missing.sh")
+	if err := cmd.onSync(status); err == nil {
+		t.Error("onSync() with a missing listener should fail")
+	}
+}
@@ -15,11 +15,14 @@
 package main
 
 import (
+	"errors"
 	"fmt"
 	"io"
 	"os"
+	"os/exec"
 
 	"github.com/urfave/cli/v2"
+	"go.linecorp.com/centraldogma/env"
 )
 
 var commitMessageFlag = &cli.StringFlag{
@@ -67,6 +70,83 @@ var listenerFlag = &cli.StringFlag{
 	Usage: "Specifies the `executable` path that handles watch events",
 }
 
+var exportFormatFlag = &cli.StringFlag{
+	Name:  "format, f",
+	Usage: "Specifies the format to export: tar.gz, zip or dir",
+	Value: "tar.gz",
+}
+
+var removeMissingFlag = &cli.BoolFlag{
+	Name:  "remove-missing",
+	Usage: "Specifies whether to remove the remote files which do not exist locally",
+}
+
+var dryRunFlag = &cli.BoolFlag{
+	Name:  "dry-run",
+	Usage: "Specifies whether to print the changes without pushing them",
+}
+
+var baseRevisionFlag = &cli.StringFlag{
+	Name:  "base-revision",
+	Usage: "Specifies the revision to compare with, which the changes are pushed on top of",
+}
+
+var checkFlag = &cli.BoolFlag{
+	Name:  "check",
+	Usage: "Specifies whether to exit with a non-zero status if there are differences, without syncing",
+}
+
+var pullFlag = &cli.BoolFlag{
+	Name:  "pull",
+	Usage: "Specifies whether to sync the local directory with the repository instead",
+}
+
+var httpAddrFlag = &cli.StringFlag{
+	Name:  "http",
+	Usage: "Specifies the `address` to serve the health of the agent at /health, e.g. :8080",
+}
+
+var renderK8sFlags = []cli.Flag{
+	&cli.StringFlag{
+		Name:  "kind",
+		Usage: "Specifies the kind of the resource: configmap or secret",
+		Value: "configmap",
+	},
+	&cli.StringFlag{
+		Name:  "name",
+		Usage: "Specifies the name of the resource, which is derived from the path by default",
+	},
+	&cli.StringFlag{
+		Name:  "namespace",
+		Usage: "Specifies the namespace of the resource",
+	},
+	&cli.StringSliceFlag{
+		Name:  "label",
+		Usage: "Specifies the labels of the resource as key=value",
+	},
+	&cli.StringFlag{
+		Name:  "key-rule",
+		Usage: "Specifies how the paths are mapped to the keys: basename or path",
+		Value: "basename",
+	},
+}
+
+var envFlags = []cli.Flag{
+	&cli.StringFlag{
+		Name:  "prefix",
+		Usage: "Specifies the prefix of the variable names, which consists of letters, digits and _, e.g. APP_",
+	},
+	&cli.StringFlag{
+		Name:  "separator",
+		Usage: "Specifies the separator of the keys of the nested values",
+		Value: env.DefaultSeparator,
+	},
+	&cli.BoolFlag{
+		Name:  "preserve-case",
+		Usage: "Specifies whether to keep the case of the keys instead of converting them to upper case",
+	},
+}
+
 var printFormatFlags = []cli.Flag{
 	&cli.BoolFlag{
 		Name:   "pretty",
@@ -217,6 +297,173 @@ func CLICommands() []*cli.Command {
 				return nil
 			},
 		},
+		{
+			Name:      "export",
+			Usage:     "Exports the files in the path to an archive or a directory",
+			ArgsUsage: "<project_name>/<repository_name>[/<path_pattern>] [<local_path>]",
+			Flags:     []cli.Flag{revisionFlag, exportFormatFlag},
+			Action: func(c *cli.Context) error {
+				command, err := newExportCommand(c, os.Stdout)
+				if err != nil {
+					return newCommandLineError(c)
+				}
+				err = command.execute(c)
+				if err != nil {
+					return cli.NewExitError(err, 1)
+				}
+				return nil
+			},
+		},
+		{
+			Name:      "import",
+			Usage:     "Imports the files in a local directory or archive into the path as a single commit",
+			ArgsUsage: "<project_name>/<repository_name>[/<path>] <directory_or_archive>",
+			Flags:     []cli.Flag{revisionFlag, commitMessageFlag, removeMissingFlag, dryRunFlag},
+			Action: func(c *cli.Context) error {
+				command, err := newImportCommand(c, os.Stdout)
+				if err != nil {
+					return newCommandLineError(c)
+				}
+				err = command.execute(c)
+				if err != nil {
+					return cli.NewExitError(err, 1)
+				}
+				return nil
+			},
+		},
+		{
+			Name:  "sync",
+			Usage: "Syncs the path with a local directory",
+			Description: `The differences between the local directory and the path are printed, and then pushed as
+   a single commit on top of the revision which they were compared with, so the push fails if the files
+   have been changed since. The remote files which do not exist locally are removed.
+
+   e.g.
+     # Fail if /pj/repo/conf is different from ./conf, e.g. in CI
+     dogma sync --check pj/repo/conf ./conf`,
+			ArgsUsage: "<project_name>/<repository_name>[/<path>] <directory>",
+			Flags:     []cli.Flag{baseRevisionFlag, commitMessageFlag, checkFlag, pullFlag, dryRunFlag},
+			Action: func(c *cli.Context) error {
+				command, err := newSyncCommand(c, os.Stdout)
+				if err != nil {
+					return newCommandLineError(c)
+				}
+				err = command.execute(c)
+				if err != nil {
+					return cli.NewExitError(err, 1)
+				}
+				return nil
+			},
+		},
+		{
+			Name:  "agent",
+			Usage: "Keeps a local directory in sync with the files in the path",
+			Description: `The files which match the path pattern are written to the directory whenever they change,
+   and the deleted ones are removed. You can reload your application by using --listener <executable> option,
+   which is executed after the files are changed. Other meta data about the sync are available via
+   environment variables below.
+
+     DOGMA_AGENT_PROJECT - The project of the files
+     DOGMA_AGENT_REPO - The repository of the files
+     DOGMA_AGENT_PATH_PATTERN - The path pattern of the files
+     DOGMA_AGENT_DIR - The directory which the files are written to
+     DOGMA_AGENT_REV - The revision which the files are synced at
+
+   e.g.
+     # Mirror /pj/repo/conf to /etc/myapp/conf and reload myapp
+     dogma agent --listener ./reload.sh --http :8080 pj/repo/conf/** /etc/myapp`,
+			ArgsUsage: "<project_name>/<repository_name>[/<path_pattern>] <directory>",
+			Flags:     []cli.Flag{listenerFlag, httpAddrFlag},
+			Action: func(c *cli.Context) error {
+				command, err := newAgentCommand(c, os.Stdout)
+				if err != nil {
+					return newCommandLineError(c)
+				}
+				err = command.execute(c)
+				if err != nil {
+					return cli.NewExitError(err, 1)
+				}
+				return nil
+			},
+		},
+		{
+			Name:  "render",
+			Usage: "Renders the files in the path as the manifests of other systems",
+			Subcommands: []*cli.Command{
+				{
+					Name:      "k8s",
+					Usage:     "Renders the files in the path as a Kubernetes ConfigMap or Secret",
+					ArgsUsage: "<project_name>/<repository_name>[/<path_pattern>]",
+					Flags:     append([]cli.Flag{revisionFlag}, renderK8sFlags...),
+					Action: func(c *cli.Context) error {
+						command, err := newRenderK8sCommand(c, os.Stdout)
+						if err != nil {
+							return newCommandLineError(c)
+						}
+						err = command.execute(c)
+						if err != nil {
+							return cli.NewExitError(err, 1)
+						}
+						return nil
+					},
+				},
+			},
+		},
+		{
+			Name:  "env",
+			Usage: "Prints a JSON file in the path as environment variables",
+			Description: `The JSON file is flattened into the variables whose names are the keys of the nested values
+   joined with the separator, e.g. {"db": {"host": "localhost"}} is printed as DB_HOST=localhost.
+
+   e.g.
+     # Load the variables into the current shell
+     eval "$(dogma env --prefix APP_ pj/repo/conf/app.json)"
+     # Write a .env file of the $.db object
+     dogma env --jsonpath '$.db' --format dotenv pj/repo/conf/app.json > .env`,
+			ArgsUsage: "<project_name>/<repository_name>/<path>",
+			Flags: append([]cli.Flag{revisionFlag, jsonPathFlag, &cli.StringFlag{
+				Name:  "format",
+				Usage: "Specifies the format to print: shell, dotenv or json",
+				Value: "shell",
+			}}, envFlags...),
+			Action: func(c *cli.Context) error {
+				command, err := newEnvCommand(c, os.Stdout)
+				if err != nil {
+					return newCommandLineError(c)
+				}
+				err = command.execute(c)
+				if err != nil {
+					return cli.NewExitError(err, 1)
+				}
+				return nil
+			},
+		},
+		{
+			Name:  "exec",
+			Usage: "Runs a command with the environment variables of a JSON file in the path",
+			Description: `The JSON file is flattened into the environment variables as the env command does,
+   which are added to the environment of the command. The command exits with the status of the command.
+
+   e.g.
+     dogma exec --prefix APP_ pj/repo/conf/app.json -- ./myapp --verbose`,
+			ArgsUsage: "<project_name>/<repository_name>/<path> <command> [<arguments>...]",
+			Flags:     append([]cli.Flag{revisionFlag, jsonPathFlag}, envFlags...),
+			Action: func(c *cli.Context) error {
+				command, err := newExecCommand(c, os.Stdout)
+				if err != nil {
+					return newCommandLineError(c)
+				}
+				err = command.execute(c)
+				var exitErr *exec.ExitError
+				if errors.As(err, &exitErr) {
+					return cli.NewExitError("", exitStatus(exitErr))
+				}
+				if err != nil {
+					return cli.NewExitError(err, 1)
+				}
+				return nil
+			},
+		},
 		{
 			Name:      "cat",
 			Usage:     "Prints a file in the path",
@@ -323,6 +570,27 @@ func CLICommands() []*cli.Command {
 				return nil
 			},
 		},
+		{
+			Name:      "show",
+			Usage:     "Shows the commit of a revision with its changes",
+			ArgsUsage: "<project_name>/<repository_name>",
+			Flags:     append(printFormatFlags, revisionFlag),
+			Action: func(c *cli.Context) error {
+				style, err := getPrintStyle(c)
+				if err != nil {
+					return err
+				}
+				command, err := newShowCommand(c, os.Stdout, style)
+				if err != nil {
+					return newCommandLineError(c)
+				}
+				err = command.execute(c)
+				if err != nil {
+					return cli.NewExitError(err, 1)
+				}
+				return nil
+			},
+		},
 		{
 			Name:      "normalize",
 			Usage:     "Normalizes a revision into an absolute revision",
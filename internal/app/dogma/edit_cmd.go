@@ -26,6 +26,7 @@ import (
 
 	"github.com/urfave/cli/v2"
 	"go.linecorp.com/centraldogma"
+	"go.linecorp.com/centraldogma/textdiff"
 )
 
 // An editFileCommand modifies the file of the specified path with the revision.
@@ -106,8 +107,13 @@ func editRemoteFileContent(out io.Writer, remote *centraldogma.Entry) (*centrald
 		}
 		change.Content = v
 	} else if remote.Type == centraldogma.Text {
-		change.Type = centraldogma.UpsertText
-		change.Content = string(buf)
+		// Push only the changed lines so that a concurrent edit of the other lines does not get lost.
+		patch := textdiff.Diff(remote.Path, string(remote.Content), string(buf))
+		if len(patch) == 0 {
+			return nil, fmt.Errorf("no changes to commit: %s", path.Base(remote.Path))
+		}
+		change.Type = centraldogma.ApplyTextPatch
+		change.Content = patch
 	}
 
 	return change, nil
@@ -15,9 +15,14 @@
 package main
 
 import (
+	"io/ioutil"
 	"os"
+	"path/filepath"
 	"reflect"
+	"runtime"
 	"testing"
+
+	"go.linecorp.com/centraldogma"
 )
 
 func TestNewEditCommand(t *testing.T) {
@@ -58,3 +63,29 @@ func TestNewEditCommand(t *testing.T) {
 		}
 	}
 }
+
+func TestEditRemoteFileContent_Text(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skipf("skipping %s due to a lack of sh", t.Name())
+	}
+
+	// An editor which replaces the second line.
+	editor := filepath.Join(t.TempDir(), "editor.sh")
+	script := "#!/bin/sh\nprintf 'a\\nB\\nc\\n' > \"$1\"\n"
+	if err := ioutil.WriteFile(editor, []byte(script), 0755); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv("EDITOR", editor)
+
+	remote := &centraldogma.Entry{Path: "/a.txt", Type: centraldogma.Text, Content: []byte("a\nb\nc\n")}
+	change, err := editRemoteFileContent(ioutil.Discard, remote)
+	if err != nil {
+		t.Fatalf("editRemoteFileContent returned an error: %v", err)
+	}
+
+	want := &centraldogma.Change{Path: "/a.txt", Type: centraldogma.ApplyTextPatch,
+		Content: "--- /a.txt\n+++ /a.txt\n@@ -1,3 +1,3 @@\n a\n-b\n+B\n c\n"}
+	if !reflect.DeepEqual(change, want) {
+		t.Errorf("editRemoteFileContent returned %+v, want %+v", change, want)
+	}
+}
@@ -0,0 +1,185 @@
+// Copyright 2026 LINE Corporation
+//
+// LINE Corporation licenses this file to you under the Apache License,
+// version 2.0 (the "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at:
+//
+//   https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+// WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+// License for the specific language governing permissions and limitations
+// under the License.
+
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"os/signal"
+	"syscall"
+
+	"github.com/urfave/cli/v2"
+	"go.linecorp.com/centraldogma"
+	"go.linecorp.com/centraldogma/env"
+)
+
+// An envCommand prints the JSON file in the specified path matched by the JSON path expressions
+// as environment variables.
+type envCommand struct {
+	out       io.Writer
+	repo      repositoryRequestInfo
+	jsonPaths []string
+	options   env.Options
+	format    env.Format
+}
+
+func (ec *envCommand) execute(c *cli.Context) error {
+	client, err := newDogmaClient(c, ec.repo.remoteURL)
+	if err != nil {
+		return err
+	}
+	return ec.executeWithDogmaClient(c, client)
+}
+
+func (ec *envCommand) executeWithDogmaClient(_ *cli.Context, client *centraldogma.Client) error {
+	vars, err := getRemoteVariables(client, &ec.repo, ec.jsonPaths, &ec.options)
+	if err != nil {
+		return err
+	}
+	return env.Write(ec.out, vars, ec.format)
+}
+
+// An execCommand runs the command with the environment variables of the JSON file in the specified path
+// matched by the JSON path expressions.
+type execCommand struct {
+	out       io.Writer
+	repo      repositoryRequestInfo
+	jsonPaths []string
+	options   env.Options
+	args      []string
+}
+
+func (ec *execCommand) execute(c *cli.Context) error {
+	client, err := newDogmaClient(c, ec.repo.remoteURL)
+	if err != nil {
+		return err
+	}
+	return ec.executeWithDogmaClient(c, client)
+}
+
+func (ec *execCommand) executeWithDogmaClient(_ *cli.Context, client *centraldogma.Client) error {
+	vars, err := getRemoteVariables(client, &ec.repo, ec.jsonPaths, &ec.options)
+	if err != nil {
+		return err
+	}
+
+	command := exec.Command(ec.args[0], ec.args[1:]...)
+	command.Env = append(os.Environ(), env.Environ(vars)...)
+	command.Stdin = os.Stdin
+	command.Stdout = ec.out
+	command.Stderr = os.Stderr
+
+	// Forward the signals which stop the command, so that it can shut down gracefully, e.g. when dogma exec
+	// is the entrypoint of a container.
+	signalChan := make(chan os.Signal, 1)
+	signal.Notify(signalChan, os.Interrupt, syscall.SIGTERM)
+	defer signal.Stop(signalChan)
+	if err := command.Start(); err != nil {
+		return err
+	}
+	done := make(chan struct{})
+	defer close(done)
+	go func() {
+		for {
+			select {
+			case sig := <-signalChan:
+				_ = command.Process.Signal(sig)
+			case <-done:
+				return
+			}
+		}
+	}()
+	return command.Wait()
+}
+
+// exitStatus returns the exit status of the command, which is 128 plus the signal number if the command was
+// killed by a signal, like a shell does.
+func exitStatus(err *exec.ExitError) int {
+	if status, ok := err.Sys().(syscall.WaitStatus); ok && status.Signaled() {
+		return 128 + int(status.Signal())
+	}
+	return err.ExitCode()
+}
+
+// getRemoteVariables downloads the JSON file and flattens it into the environment variables.
+func getRemoteVariables(client *centraldogma.Client,
+	repo *repositoryRequestInfo, jsonPaths []string, options *env.Options) ([]env.Variable, error) {
+	entry, err := getRemoteFileEntryWithDogmaClient(client,
+		repo.projName, repo.repoName, repo.path, repo.revision, jsonPaths)
+	if err != nil {
+		return nil, err
+	}
+	if entry.Type != centraldogma.JSON {
+		return nil, fmt.Errorf("/%s/%s%s is not a JSON file", repo.projName, repo.repoName, repo.path)
+	}
+
+	// The content is not a JSON but the string itself if the JSON path expressions select a string.
+	if !json.Valid(entry.Content) {
+		return env.FlattenValue(string(entry.Content), options)
+	}
+	return env.Flatten(entry.Content, options)
+}
+
+func newEnvOptions(c *cli.Context) env.Options {
+	return env.Options{
+		Prefix:       c.String("prefix"),
+		Separator:    c.String("separator"),
+		PreserveCase: c.Bool("preserve-case"),
+	}
+}
+
+// newEnvCommand creates the envCommand.
+func newEnvCommand(c *cli.Context, out io.Writer) (Command, error) {
+	repo, err := newRepositoryRequestInfo(c)
+	if err != nil {
+		return nil, err
+	}
+	if repo.path == "/" {
+		return nil, newCommandLineError(c)
+	}
+
+	format := env.Shell
+	if f := c.String("format"); len(f) != 0 {
+		if format, err = env.ParseFormat(f); err != nil {
+			return nil, err
+		}
+	}
+	return &envCommand{out: out, repo: repo, jsonPaths: c.StringSlice("jsonpath"),
+		options: newEnvOptions(c), format: format}, nil
+}
+
+// newExecCommand creates the execCommand.
+func newExecCommand(c *cli.Context, out io.Writer) (Command, error) {
+	repo, err := newRepositoryRequestInfo(c)
+	if err != nil {
+		return nil, err
+	}
+	if repo.path == "/" || c.Args().Len() < 2 {
+		return nil, newCommandLineError(c)
+	}
+
+	args := c.Args().Tail()
+	if args[0] == "--" {
+		args = args[1:]
+	}
+	if len(args) == 0 || len(args[0]) == 0 {
+		return nil, newCommandLineError(c)
+	}
+	return &execCommand{out: out, repo: repo, jsonPaths: c.StringSlice("jsonpath"),
+		options: newEnvOptions(c), args: args}, nil
+}
@@ -0,0 +1,200 @@
+// Copyright 2026 LINE Corporation
+//
+// LINE Corporation licenses this file to you under the Apache License,
+// version 2.0 (the "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at:
+//
+//   https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+// WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+// License for the specific language governing permissions and limitations
+// under the License.
+
+package main
+
+import (
+	"bytes"
+	"errors"
+	"flag"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"os/exec"
+	"reflect"
+	"runtime"
+	"syscall"
+	"testing"
+
+	"github.com/urfave/cli/v2"
+	"go.linecorp.com/centraldogma"
+	"go.linecorp.com/centraldogma/env"
+)
+
+func newEnvCmdContext(flagArguments []string, connectURL, format, jsonPath string) *cli.Context {
+	parent := newParentContext(connectURL)
+
+	flags := flag.FlagSet{}
+	flags.Parse(flagArguments)
+	flags.String("revision", "", "")
+	flags.String("format", format, "")
+	flags.String("prefix", "APP_", "")
+	flags.String("separator", "_", "")
+	flags.Bool("preserve-case", false, "")
+	jsonPaths := cli.NewStringSlice()
+	if len(jsonPath) != 0 {
+		jsonPaths.Set(jsonPath)
+	}
+	flags.Var(jsonPaths, "jsonpath", "")
+	return cli.NewContext(nil, &flags, parent)
+}
+
+func newEnvTestServer() *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/v1/projects/foo/repos/bar/contents/app.json" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		if r.URL.Query().Get("jsonpath") == "$.db" {
+			fmt.Fprint(w, `{"revision":2,"path":"/app.json","type":"JSON","content":{"host":"localhost","port":3306}}`)
+			return
+		}
+		fmt.Fprint(w, `{"revision":2,"path":"/app.json","type":"JSON",
+"content":{"db":{"host":"localhost","port":3306},"debug":true}}`)
+	}))
+}
+
+func TestNewEnvCommand(t *testing.T) {
+	defaultRemoteURL := "http://localhost:36462/"
+
+	c := newEnvCmdContext([]string{"foo/bar/app.json"}, defaultRemoteURL, "dotenv", "$.db")
+	got, err := newEnvCommand(c, os.Stdout)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := envCommand{
+		out: os.Stdout,
+		repo: repositoryRequestInfo{
+			remoteURL: defaultRemoteURL, projName: "foo", repoName: "bar", path: "/app.json", revision: "-1"},
+		jsonPaths: []string{"$.db"},
+		options:   env.Options{Prefix: "APP_", Separator: "_"},
+		format:    env.Dotenv,
+	}
+	if got2 := *got.(*envCommand); !reflect.DeepEqual(got2, want) {
+		t.Errorf("newEnvCommand() = %+v, want: %+v", got2, want)
+	}
+
+	c = newEnvCmdContext([]string{"foo/bar/app.json"}, defaultRemoteURL, "yaml", "")
+	if _, err := newEnvCommand(c, os.Stdout); err == nil {
+		t.Error("newEnvCommand() with an unknown format should fail")
+	}
+	c = newEnvCmdContext([]string{"foo/bar"}, defaultRemoteURL, "", "")
+	if _, err := newEnvCommand(c, os.Stdout); err == nil {
+		t.Error("newEnvCommand() without the path should fail")
+	}
+}
+
+func TestEnv(t *testing.T) {
+	server := newEnvTestServer()
+	defer server.Close()
+	client, err := centraldogma.NewClientWithToken(server.URL, "anonymous", server.Client().Transport)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var tests = []struct {
+		format, jsonPath string
+		want             string
+	}{
+		{"", "", "export APP_DB_HOST='localhost'\nexport APP_DB_PORT='3306'\nexport APP_DEBUG='true'\n"},
+		{"dotenv", "$.db", "APP_HOST=\"localhost\"\nAPP_PORT=\"3306\"\n"},
+	}
+	for _, test := range tests {
+		c := newEnvCmdContext([]string{"foo/bar/app.json"}, server.URL, test.format, test.jsonPath)
+		command, err := newEnvCommand(c, os.Stdout)
+		if err != nil {
+			t.Fatal(err)
+		}
+		var out bytes.Buffer
+		cmd := command.(*envCommand)
+		cmd.out = &out
+		if err := cmd.executeWithDogmaClient(c, client); err != nil {
+			t.Fatal(err)
+		}
+		if out.String() != test.want {
+			t.Errorf("output = %q, want %q", out.String(), test.want)
+		}
+	}
+}
+
+func TestExec(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("sh is not available")
+	}
+	server := newEnvTestServer()
+	defer server.Close()
+	client, err := centraldogma.NewClientWithToken(server.URL, "anonymous", server.Client().Transport)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	c := newEnvCmdContext([]string{"foo/bar/app.json", "--", "sh", "-c", "echo $APP_DB_HOST:$APP_DB_PORT"},
+		server.URL, "", "")
+	command, err := newExecCommand(c, os.Stdout)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var out bytes.Buffer
+	cmd := command.(*execCommand)
+	cmd.out = &out
+	if err := cmd.executeWithDogmaClient(c, client); err != nil {
+		t.Fatal(err)
+	}
+	if want := "localhost:3306\n"; out.String() != want {
+		t.Errorf("output = %q, want %q", out.String(), want)
+	}
+
+	c = newEnvCmdContext([]string{"foo/bar/app.json"}, server.URL, "", "")
+	if _, err := newExecCommand(c, os.Stdout); err == nil {
+		t.Error("newExecCommand() without the command should fail")
+	}
+}
+
+func TestExec_ExitStatus(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("sh is not available")
+	}
+	server := newEnvTestServer()
+	defer server.Close()
+	client, err := centraldogma.NewClientWithToken(server.URL, "anonymous", server.Client().Transport)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var tests = []struct {
+		script string
+		want   int
+	}{
+		{"exit 3", 3},
+		// SIGTERM sent to dogma is forwarded to the command.
+		{`trap 'kill $!; exit 7' TERM; kill -TERM $PPID; sleep 5 & wait`, 7},
+		{"kill -KILL $$", 128 + int(syscall.SIGKILL)},
+	}
+	for _, test := range tests {
+		c := newEnvCmdContext([]string{"foo/bar/app.json", "--", "sh", "-c", test.script}, server.URL, "", "")
+		command, err := newExecCommand(c, os.Stdout)
+		if err != nil {
+			t.Fatal(err)
+		}
+		err = command.(*execCommand).executeWithDogmaClient(c, client)
+		var exitErr *exec.ExitError
+		if !errors.As(err, &exitErr) {
+			t.Fatalf("execute(%q) = %v, want an exit error", test.script, err)
+		}
+		if got := exitStatus(exitErr); got != test.want {
+			t.Errorf("exitStatus(%q) = %d, want %d", test.script, got, test.want)
+		}
+	}
+}
@@ -0,0 +1,136 @@
+// Copyright 2026 LINE Corporation
+//
+// LINE Corporation licenses this file to you under the Apache License,
+// version 2.0 (the "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at:
+//
+//   https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+// WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+// License for the specific language governing permissions and limitations
+// under the License.
+
+package main
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+
+	"github.com/urfave/cli/v2"
+	"go.linecorp.com/centraldogma"
+)
+
+const exportFormatDirectory = "dir"
+
+// An exportCommand exports the files which match the path pattern at the specified revision to a tar.gz or
+// zip archive, or to a directory.
+type exportCommand struct {
+	out           io.Writer
+	repo          repositoryRequestInfo
+	pathPattern   string
+	format        string
+	localFilePath string
+}
+
+func (ec *exportCommand) execute(c *cli.Context) error {
+	client, err := newDogmaClient(c, ec.repo.remoteURL)
+	if err != nil {
+		return err
+	}
+	return ec.executeWithDogmaClient(c, client)
+}
+
+func (ec *exportCommand) executeWithDogmaClient(_ *cli.Context, client *centraldogma.Client) (err error) {
+	repo := ec.repo
+	ctx := context.Background()
+	revision, err := centraldogma.ParseRevision(repo.revision)
+	if err != nil {
+		return err
+	}
+
+	var manifest *centraldogma.ExportManifest
+	var httpStatusCode int
+	if ec.format == exportFormatDirectory {
+		if err := os.MkdirAll(ec.localFilePath, defaultPermMode); err != nil {
+			return err
+		}
+		manifest, httpStatusCode, err = client.ExportToDirectory(ctx, repo.projName, repo.repoName,
+			revision, ec.pathPattern, ec.localFilePath)
+	} else {
+		format, err := centraldogma.ParseExportFormat(ec.format)
+		if err != nil {
+			return err
+		}
+		fd, err := os.Create(ec.localFilePath)
+		if err != nil {
+			return err
+		}
+		manifest, httpStatusCode, err = client.Export(ctx, repo.projName, repo.repoName,
+			revision, ec.pathPattern, fd, format)
+		if closeErr := fd.Close(); err == nil {
+			err = closeErr
+		}
+		if err != nil {
+			_ = os.Remove(ec.localFilePath)
+			return err
+		}
+	}
+	if err != nil {
+		return err
+	}
+	if httpStatusCode != http.StatusOK {
+		return fmt.Errorf("failed to export /%s/%s%s revision: %q (status: %d)",
+			repo.projName, repo.repoName, ec.pathPattern, repo.revision, httpStatusCode)
+	}
+
+	fmt.Fprintf(ec.out, "Exported: %d files at the revision %d to %s\n",
+		len(manifest.Files), manifest.Revision, ec.localFilePath)
+	return nil
+}
+
+// newExportCommand creates the exportCommand. If the localFilePath is not specified, the name of
+// the repository with the extension of the format will be set by default.
+func newExportCommand(c *cli.Context, out io.Writer) (Command, error) {
+	repo, err := newRepositoryRequestInfo(c)
+	if err != nil {
+		return nil, err
+	}
+
+	format := c.String("format")
+	if len(format) == 0 {
+		format = centraldogma.ExportTarGz.String()
+	}
+	if format != exportFormatDirectory {
+		f, err := centraldogma.ParseExportFormat(format)
+		if err != nil {
+			return nil, err
+		}
+		format = f.String()
+	}
+
+	pathPattern := repo.path
+	if pathPattern == "/" {
+		pathPattern = "/**"
+	}
+
+	localFilePath := repo.repoName
+	if format != exportFormatDirectory {
+		localFilePath += "." + format
+	}
+	if c.Args().Len() == 2 && len(c.Args().Get(1)) != 0 {
+		localFilePath = c.Args().Get(1)
+	}
+
+	return &exportCommand{
+		out:           out,
+		repo:          repo,
+		pathPattern:   pathPattern,
+		format:        format,
+		localFilePath: localFilePath,
+	}, nil
+}
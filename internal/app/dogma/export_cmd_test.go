@@ -0,0 +1,132 @@
+// Copyright 2026 LINE Corporation
+//
+// LINE Corporation licenses this file to you under the Apache License,
+// version 2.0 (the "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at:
+//
+//   https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+// WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+// License for the specific language governing permissions and limitations
+// under the License.
+
+package main
+
+import (
+	"bytes"
+	"flag"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/urfave/cli/v2"
+	"go.linecorp.com/centraldogma"
+)
+
+func newExportCmdContext(flagArguments []string, connectURL, revision, format string) *cli.Context {
+	parent := newParentContext(connectURL)
+
+	flags := flag.FlagSet{}
+	flags.Parse(flagArguments)
+	flags.String("revision", revision, "")
+	flags.String("format", format, "")
+	return cli.NewContext(nil, &flags, parent)
+}
+
+func TestNewExportCommand(t *testing.T) {
+	defaultRemoteURL := "http://localhost:36462/"
+
+	var tests = []struct {
+		arguments []string
+		format    string
+		want      interface{}
+	}{
+		{[]string{"foo/bar"}, "",
+			exportCommand{
+				out: os.Stdout,
+				repo: repositoryRequestInfo{
+					remoteURL: defaultRemoteURL, projName: "foo", repoName: "bar",
+					path: "/", revision: "-1"},
+				pathPattern: "/**", format: "tar.gz", localFilePath: "bar.tar.gz"}},
+
+		{[]string{"foo/bar/a/*.json", "out.zip"}, "ZIP",
+			exportCommand{
+				out: os.Stdout,
+				repo: repositoryRequestInfo{
+					remoteURL: defaultRemoteURL, projName: "foo", repoName: "bar",
+					path: "/a/*.json", revision: "-1"},
+				pathPattern: "/a/*.json", format: "zip", localFilePath: "out.zip"}},
+
+		{[]string{"foo/bar"}, "dir",
+			exportCommand{
+				out: os.Stdout,
+				repo: repositoryRequestInfo{
+					remoteURL: defaultRemoteURL, projName: "foo", repoName: "bar",
+					path: "/", revision: "-1"},
+				pathPattern: "/**", format: "dir", localFilePath: "bar"}},
+	}
+
+	for _, test := range tests {
+		c := newExportCmdContext(test.arguments, defaultRemoteURL, "", test.format)
+		got, err := newExportCommand(c, os.Stdout)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got2 := *got.(*exportCommand); !reflect.DeepEqual(got2, test.want) {
+			t.Errorf("newExportCommand(%+v) = %+v, want: %+v", test.arguments, got2, test.want)
+		}
+	}
+
+	c := newExportCmdContext([]string{"foo/bar"}, defaultRemoteURL, "", "rar")
+	if _, err := newExportCommand(c, os.Stdout); err == nil {
+		t.Error("newExportCommand() with an unknown format should fail")
+	}
+}
+
+func TestExportToDirectory(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/api/v1/projects/foo/repos/bar/revision/-1":
+			fmt.Fprint(w, `{"revision":2}`)
+		case "/api/v1/projects/foo/repos/bar/contents/**":
+			fmt.Fprint(w, `[{"path":"/x/a.json","type":"JSON","content":{"a":1}},{"path":"/x","type":"DIRECTORY"}]`)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	dir := filepath.Join(t.TempDir(), "out")
+	c := newExportCmdContext([]string{"foo/bar", dir}, server.URL, "", "dir")
+	command, err := newExportCommand(c, os.Stdout)
+	if err != nil {
+		t.Fatal(err)
+	}
+	client, err := centraldogma.NewClientWithToken(server.URL, "anonymous", server.Client().Transport)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var out bytes.Buffer
+	cmd := command.(*exportCommand)
+	cmd.out = &out
+	if err := cmd.executeWithDogmaClient(c, client); err != nil {
+		t.Fatal(err)
+	}
+
+	b, err := os.ReadFile(filepath.Join(dir, "x", "a.json"))
+	if err != nil || string(b) != `{"a":1}` {
+		t.Errorf("x/a.json = %q, %v, want {\"a\":1}", b, err)
+	}
+	if _, err := os.Stat(filepath.Join(dir, centraldogma.ExportManifestName)); err != nil {
+		t.Error(err)
+	}
+	if want := "Exported: 1 files at the revision 2 to " + dir + "\n"; out.String() != want {
+		t.Errorf("output = %q, want %q", out.String(), want)
+	}
+}
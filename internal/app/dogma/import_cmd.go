@@ -0,0 +1,133 @@
+// Copyright 2026 LINE Corporation
+//
+// LINE Corporation licenses this file to you under the Apache License,
+// version 2.0 (the "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at:
+//
+//   https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+// WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+// License for the specific language governing permissions and limitations
+// under the License.
+
+package main
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+
+	"github.com/urfave/cli/v2"
+	"go.linecorp.com/centraldogma"
+)
+
+// An importCommand imports the files in a local directory or archive into the path of the repository
+// as a single commit.
+type importCommand struct {
+	out           io.Writer
+	repo          repositoryRequestInfo
+	source        string
+	removeMissing bool
+	dryRun        bool
+	message       string
+}
+
+func (ic *importCommand) execute(c *cli.Context) error {
+	client, err := newDogmaClient(c, ic.repo.remoteURL)
+	if err != nil {
+		return err
+	}
+	return ic.executeWithDogmaClient(c, client)
+}
+
+func (ic *importCommand) executeWithDogmaClient(_ *cli.Context, client *centraldogma.Client) error {
+	repo := ic.repo
+	files, err := centraldogma.ReadImportFiles(ic.source)
+	if err != nil {
+		return err
+	}
+
+	baseRevision, err := centraldogma.ParseRevision(repo.revision)
+	if err != nil {
+		return err
+	}
+
+	ctx := context.Background()
+	plan, httpStatusCode, err := client.PlanImport(ctx, repo.projName, repo.repoName, files,
+		&centraldogma.ImportOptions{BaseRevision: baseRevision, Path: repo.path, RemoveMissing: ic.removeMissing})
+	if err != nil {
+		return err
+	}
+	if httpStatusCode != http.StatusOK {
+		return fmt.Errorf("failed to compare the files with /%s/%s%s revision: %q (status: %d)",
+			repo.projName, repo.repoName, repo.path, repo.revision, httpStatusCode)
+	}
+
+	printImportPlan(ic.out, plan)
+	if ic.dryRun || len(plan.Changes) == 0 {
+		return nil
+	}
+
+	var commitMessage *centraldogma.CommitMessage
+	if len(ic.message) != 0 {
+		commitMessage = &centraldogma.CommitMessage{Summary: ic.message}
+	}
+	result, httpStatusCode, err := client.Import(ctx, plan, commitMessage)
+	if err != nil {
+		return err
+	}
+	if httpStatusCode != http.StatusOK {
+		return fmt.Errorf("failed to import the files into /%s/%s%s (status: %d)",
+			repo.projName, repo.repoName, plan.Path, httpStatusCode)
+	}
+	fmt.Fprintf(ic.out, "Imported: %d files at the revision %d\n", len(plan.Changes), result.Revision)
+	return nil
+}
+
+// printImportPlan prints the files changed by the plan, marking them with "A" (added), "M" (modified) or
+// "D" (removed) like git does.
+func printImportPlan(out io.Writer, plan *centraldogma.ImportPlan) {
+	if len(plan.Files) == 0 {
+		fmt.Fprintf(out, "No changes in /%s/%s%s at the revision %d\n",
+			plan.Project, plan.Repository, plan.Path, plan.BaseRevision)
+		return
+	}
+
+	fmt.Fprintf(out, "Changes in /%s/%s%s at the revision %d:\n",
+		plan.Project, plan.Repository, plan.Path, plan.BaseRevision)
+	for _, f := range plan.Files {
+		mark := "A"
+		switch f.Action {
+		case centraldogma.ImportModified:
+			mark = "M"
+		case centraldogma.ImportRemoved:
+			mark = "D"
+		}
+		fmt.Fprintf(out, "  %s %s\n", mark, f.Path)
+	}
+	fmt.Fprintf(out, "%d added, %d modified, %d removed\n", plan.Count(centraldogma.ImportAdded),
+		plan.Count(centraldogma.ImportModified), plan.Count(centraldogma.ImportRemoved))
+}
+
+// newImportCommand creates the importCommand.
+func newImportCommand(c *cli.Context, out io.Writer) (Command, error) {
+	repo, err := newRepositoryRequestInfo(c)
+	if err != nil {
+		return nil, err
+	}
+	if c.Args().Len() != 2 || len(c.Args().Get(1)) == 0 {
+		return nil, newCommandLineError(c)
+	}
+
+	return &importCommand{
+		out:           out,
+		repo:          repo,
+		source:        c.Args().Get(1),
+		removeMissing: c.Bool("remove-missing"),
+		dryRun:        c.Bool("dry-run"),
+		message:       c.String("message"),
+	}, nil
+}
@@ -0,0 +1,127 @@
+// Copyright 2026 LINE Corporation
+//
+// LINE Corporation licenses this file to you under the Apache License,
+// version 2.0 (the "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at:
+//
+//   https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+// WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+// License for the specific language governing permissions and limitations
+// under the License.
+
+package main
+
+import (
+	"bytes"
+	"flag"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/urfave/cli/v2"
+	"go.linecorp.com/centraldogma"
+)
+
+func newImportCmdContext(flagArguments []string, connectURL string, dryRun bool) *cli.Context {
+	parent := newParentContext(connectURL)
+
+	flags := flag.FlagSet{}
+	flags.Parse(flagArguments)
+	flags.String("revision", "", "")
+	flags.String("message", "", "")
+	flags.Bool("remove-missing", true, "")
+	flags.Bool("dry-run", dryRun, "")
+	return cli.NewContext(nil, &flags, parent)
+}
+
+func TestNewImportCommand(t *testing.T) {
+	defaultRemoteURL := "http://localhost:36462/"
+
+	c := newImportCmdContext([]string{"foo/bar/conf", "./conf"}, defaultRemoteURL, true)
+	got, err := newImportCommand(c, os.Stdout)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := importCommand{
+		out: os.Stdout,
+		repo: repositoryRequestInfo{
+			remoteURL: defaultRemoteURL, projName: "foo", repoName: "bar",
+			path: "/conf", revision: "-1"},
+		source: "./conf", removeMissing: true, dryRun: true,
+	}
+	if got2 := *got.(*importCommand); !reflect.DeepEqual(got2, want) {
+		t.Errorf("newImportCommand() = %+v, want: %+v", got2, want)
+	}
+
+	c = newImportCmdContext([]string{"foo/bar/conf"}, defaultRemoteURL, false)
+	if _, err := newImportCommand(c, os.Stdout); err == nil {
+		t.Error("newImportCommand() without the source should fail")
+	}
+}
+
+func TestImport(t *testing.T) {
+	pushed := false
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/api/v1/projects/foo/repos/bar/revision/-1":
+			fmt.Fprint(w, `{"revision":2}`)
+		case "/api/v1/projects/foo/repos/bar/contents/conf/**":
+			fmt.Fprint(w, `[{"path":"/conf/a.json","type":"JSON","content":{"a":1}},
+{"path":"/conf/b.txt","type":"TEXT","content":"b\n"}]`)
+		case "/api/v1/projects/foo/repos/bar/contents":
+			if r.URL.Query().Get("revision") != "2" {
+				t.Errorf("pushed at the revision %s, want 2", r.URL.Query().Get("revision"))
+			}
+			pushed = true
+			fmt.Fprint(w, `{"revision":3, "pushedAt":"2017-05-22T00:00:00Z"}`)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	dir := t.TempDir()
+	if err := ioutil.WriteFile(filepath.Join(dir, "a.json"), []byte(`{"a":2}`), 0644); err != nil {
+		t.Fatal(err)
+	}
+	client, err := centraldogma.NewClientWithToken(server.URL, "anonymous", server.Client().Transport)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, dryRun := range []bool{true, false} {
+		c := newImportCmdContext([]string{"foo/bar/conf", dir}, server.URL, dryRun)
+		command, err := newImportCommand(c, os.Stdout)
+		if err != nil {
+			t.Fatal(err)
+		}
+		var out bytes.Buffer
+		cmd := command.(*importCommand)
+		cmd.out = &out
+		if err := cmd.executeWithDogmaClient(c, client); err != nil {
+			t.Fatal(err)
+		}
+
+		want := "Changes in /foo/bar/conf/ at the revision 2:\n" +
+			"  M /conf/a.json\n" +
+			"  D /conf/b.txt\n" +
+			"0 added, 1 modified, 1 removed\n"
+		if !dryRun {
+			want += "Imported: 2 files at the revision 3\n"
+		}
+		if out.String() != want {
+			t.Errorf("output = %q, want %q", out.String(), want)
+		}
+		if pushed == dryRun {
+			t.Errorf("pushed = %v with dryRun = %v", pushed, dryRun)
+		}
+	}
+}
@@ -0,0 +1,98 @@
+// Copyright 2026 LINE Corporation
+//
+// LINE Corporation licenses this file to you under the Apache License,
+// version 2.0 (the "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at:
+//
+//   https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+// WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+// License for the specific language governing permissions and limitations
+// under the License.
+
+package main
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/urfave/cli/v2"
+	"go.linecorp.com/centraldogma"
+	"go.linecorp.com/centraldogma/k8s"
+)
+
+// A renderK8sCommand prints the files which match the path pattern at the specified revision as
+// a Kubernetes ConfigMap or Secret manifest.
+type renderK8sCommand struct {
+	out         io.Writer
+	repo        repositoryRequestInfo
+	pathPattern string
+	options     k8s.Options
+}
+
+func (rc *renderK8sCommand) execute(c *cli.Context) error {
+	client, err := newDogmaClient(c, rc.repo.remoteURL)
+	if err != nil {
+		return err
+	}
+	return rc.executeWithDogmaClient(c, client)
+}
+
+func (rc *renderK8sCommand) executeWithDogmaClient(_ *cli.Context, client centraldogma.ContentReader) error {
+	repo := rc.repo
+	options := rc.options
+	resource, httpStatusCode, err := k8s.Build(context.Background(), client,
+		repo.projName, repo.repoName, repo.revision, rc.pathPattern, &options)
+	if err != nil {
+		return err
+	}
+	if httpStatusCode != http.StatusOK {
+		return fmt.Errorf("failed to get the files of /%s/%s%s revision: %q (status: %d)",
+			repo.projName, repo.repoName, rc.pathPattern, repo.revision, httpStatusCode)
+	}
+	return resource.WriteYAML(rc.out)
+}
+
+// newRenderK8sCommand creates the renderK8sCommand.
+func newRenderK8sCommand(c *cli.Context, out io.Writer) (Command, error) {
+	repo, err := newRepositoryRequestInfo(c)
+	if err != nil {
+		return nil, err
+	}
+
+	options := k8s.Options{Name: c.String("name"), Namespace: c.String("namespace")}
+	switch strings.ToLower(c.String("kind")) {
+	case "", "configmap":
+		options.Kind = k8s.KindConfigMap
+	case "secret":
+		options.Kind = k8s.KindSecret
+	default:
+		return nil, fmt.Errorf("unknown kind: %q (expected configmap or secret)", c.String("kind"))
+	}
+	if rule := c.String("key-rule"); len(rule) != 0 {
+		if options.KeyRule, err = k8s.ParseKeyRule(rule); err != nil {
+			return nil, err
+		}
+	}
+	for _, label := range c.StringSlice("label") {
+		kv := strings.SplitN(label, "=", 2)
+		if len(kv) != 2 || len(kv[0]) == 0 {
+			return nil, fmt.Errorf("invalid label: %q (expected key=value)", label)
+		}
+		if options.Labels == nil {
+			options.Labels = make(map[string]string)
+		}
+		options.Labels[kv[0]] = kv[1]
+	}
+
+	pathPattern := repo.path
+	if pathPattern == "/" {
+		pathPattern = "/**"
+	}
+	return &renderK8sCommand{out: out, repo: repo, pathPattern: pathPattern, options: options}, nil
+}
@@ -0,0 +1,131 @@
+// Copyright 2026 LINE Corporation
+//
+// LINE Corporation licenses this file to you under the Apache License,
+// version 2.0 (the "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at:
+//
+//   https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+// WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+// License for the specific language governing permissions and limitations
+// under the License.
+
+package main
+
+import (
+	"bytes"
+	"flag"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"reflect"
+	"testing"
+
+	"github.com/urfave/cli/v2"
+	"go.linecorp.com/centraldogma"
+	"go.linecorp.com/centraldogma/k8s"
+)
+
+func newRenderK8sCmdContext(flagArguments []string, connectURL, kind, keyRule string, labels []string) *cli.Context {
+	parent := newParentContext(connectURL)
+
+	flags := flag.FlagSet{}
+	flags.Parse(flagArguments)
+	flags.String("revision", "", "")
+	flags.String("kind", kind, "")
+	flags.String("name", "", "")
+	flags.String("namespace", "app", "")
+	flags.String("key-rule", keyRule, "")
+	labelValues := cli.NewStringSlice(labels...)
+	flags.Var(labelValues, "label", "")
+	return cli.NewContext(nil, &flags, parent)
+}
+
+func TestNewRenderK8sCommand(t *testing.T) {
+	defaultRemoteURL := "http://localhost:36462/"
+
+	c := newRenderK8sCmdContext([]string{"foo/bar/conf/*.json"}, defaultRemoteURL, "secret", "path",
+		[]string{"team=infra"})
+	got, err := newRenderK8sCommand(c, os.Stdout)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := renderK8sCommand{
+		out: os.Stdout,
+		repo: repositoryRequestInfo{
+			remoteURL: defaultRemoteURL, projName: "foo", repoName: "bar",
+			path: "/conf/*.json", revision: "-1"},
+		pathPattern: "/conf/*.json",
+		options: k8s.Options{
+			Kind: k8s.KindSecret, Namespace: "app", Labels: map[string]string{"team": "infra"},
+			KeyRule: k8s.KeyPath,
+		},
+	}
+	if got2 := *got.(*renderK8sCommand); !reflect.DeepEqual(got2, want) {
+		t.Errorf("newRenderK8sCommand() = %+v, want: %+v", got2, want)
+	}
+
+	for _, test := range []struct {
+		kind, keyRule string
+		labels        []string
+	}{
+		{"deployment", "", nil},
+		{"", "dir", nil},
+		{"", "", []string{"team"}},
+	} {
+		c := newRenderK8sCmdContext([]string{"foo/bar"}, defaultRemoteURL, test.kind, test.keyRule, test.labels)
+		if _, err := newRenderK8sCommand(c, os.Stdout); err == nil {
+			t.Errorf("newRenderK8sCommand(%+v) should fail", test)
+		}
+	}
+}
+
+func TestRenderK8s(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/api/v1/projects/foo/repos/bar/revision/-1":
+			fmt.Fprint(w, `{"revision":7}`)
+		case "/api/v1/projects/foo/repos/bar/contents/**":
+			fmt.Fprint(w, `[{"path":"/a.txt","type":"TEXT","content":"hello"}]`)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	c := newRenderK8sCmdContext([]string{"foo/bar"}, server.URL, "", "", nil)
+	command, err := newRenderK8sCommand(c, os.Stdout)
+	if err != nil {
+		t.Fatal(err)
+	}
+	client, err := centraldogma.NewClientWithToken(server.URL, "anonymous", server.Client().Transport)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var out bytes.Buffer
+	cmd := command.(*renderK8sCommand)
+	cmd.out = &out
+	if err := cmd.executeWithDogmaClient(c, client); err != nil {
+		t.Fatal(err)
+	}
+
+	want := `apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: foo-bar
+  namespace: app
+  annotations:
+    centraldogma.linecorp.com/path-pattern: "/**"
+    centraldogma.linecorp.com/project: foo
+    centraldogma.linecorp.com/repository: bar
+    centraldogma.linecorp.com/revision: "7"
+data:
+  a.txt: hello
+`
+	if out.String() != want {
+		t.Errorf("output =\n%s\nwant\n%s", out.String(), want)
+	}
+}
@@ -0,0 +1,64 @@
+// Copyright 2026 LINE Corporation
+//
+// LINE Corporation licenses this file to you under the Apache License,
+// version 2.0 (the "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at:
+//
+//   https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+// WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+// License for the specific language governing permissions and limitations
+// under the License.
+
+package main
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+
+	"github.com/urfave/cli/v2"
+	"go.linecorp.com/centraldogma"
+)
+
+type showCommand struct {
+	out   io.Writer
+	repo  repositoryRequestInfo
+	style PrintStyle
+}
+
+func (s *showCommand) execute(c *cli.Context) error {
+	repo := s.repo
+	client, err := newDogmaClient(c, repo.remoteURL)
+	if err != nil {
+		return err
+	}
+
+	revision, err := centraldogma.ParseRevision(repo.revision)
+	if err != nil {
+		return err
+	}
+	detail, httpStatusCode, err := client.GetCommit(context.Background(), repo.projName, repo.repoName, revision)
+	if err != nil {
+		return err
+	}
+	if httpStatusCode != http.StatusOK {
+		return fmt.Errorf("failed to get the commit of /%s/%s revision: %q (status: %d)",
+			repo.projName, repo.repoName, repo.revision, httpStatusCode)
+	}
+
+	printWithStyle(s.out, detail, s.style)
+	return nil
+}
+
+// newShowCommand creates the showCommand.
+func newShowCommand(c *cli.Context, out io.Writer, style PrintStyle) (Command, error) {
+	repo, err := newRepositoryRequestInfo(c)
+	if err != nil {
+		return nil, err
+	}
+	return &showCommand{out: out, repo: repo, style: style}, nil
+}
@@ -0,0 +1,249 @@
+// Copyright 2026 LINE Corporation
+//
+// LINE Corporation licenses this file to you under the Apache License,
+// version 2.0 (the "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at:
+//
+//   https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+// WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+// License for the specific language governing permissions and limitations
+// under the License.
+
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/urfave/cli/v2"
+	"go.linecorp.com/centraldogma"
+	"go.linecorp.com/centraldogma/jsonpatch"
+	"go.linecorp.com/centraldogma/textdiff"
+)
+
+// A syncCommand makes the path of the repository the same as a local directory by pushing the differences
+// as a single commit, or makes the local directory the same as the path of the repository if pull is set.
+type syncCommand struct {
+	out     io.Writer
+	repo    repositoryRequestInfo
+	dir     string
+	check   bool
+	pull    bool
+	dryRun  bool
+	message string
+}
+
+func (sc *syncCommand) execute(c *cli.Context) error {
+	client, err := newDogmaClient(c, sc.repo.remoteURL)
+	if err != nil {
+		return err
+	}
+	return sc.executeWithDogmaClient(c, client)
+}
+
+func (sc *syncCommand) executeWithDogmaClient(_ *cli.Context, client *centraldogma.Client) error {
+	repo := sc.repo
+	if info, err := os.Stat(sc.dir); err != nil {
+		return err
+	} else if !info.IsDir() {
+		return fmt.Errorf("%s is not a directory", sc.dir)
+	}
+	files, err := centraldogma.ReadImportFiles(sc.dir)
+	if err != nil {
+		return err
+	}
+
+	baseRevision, err := centraldogma.ParseRevision(repo.revision)
+	if err != nil {
+		return err
+	}
+
+	ctx := context.Background()
+	plan, httpStatusCode, err := client.PlanImport(ctx, repo.projName, repo.repoName, files,
+		&centraldogma.ImportOptions{BaseRevision: baseRevision, Path: repo.path, RemoveMissing: true})
+	if err != nil {
+		return err
+	}
+	if httpStatusCode != http.StatusOK {
+		return fmt.Errorf("failed to compare %s with /%s/%s%s revision: %q (status: %d)",
+			sc.dir, repo.projName, repo.repoName, repo.path, repo.revision, httpStatusCode)
+	}
+
+	sc.printPlan(plan)
+	if len(plan.Files) == 0 {
+		return nil
+	}
+	if sc.check {
+		return fmt.Errorf("%d files differ between %s and /%s/%s%s", len(plan.Files),
+			sc.dir, repo.projName, repo.repoName, plan.Path)
+	}
+	if sc.dryRun {
+		return nil
+	}
+	if sc.pull {
+		return sc.pullFiles(plan)
+	}
+
+	commitMessage := &centraldogma.CommitMessage{Summary: sc.message}
+	if len(commitMessage.Summary) == 0 {
+		commitMessage.Summary = fmt.Sprintf("Sync %d files into %s", len(plan.Files), plan.Path)
+	}
+	result, httpStatusCode, err := client.Import(ctx, plan, commitMessage)
+	if err != nil {
+		return err
+	}
+	if httpStatusCode != http.StatusOK {
+		return fmt.Errorf("failed to push the changes to /%s/%s%s (status: %d)",
+			repo.projName, repo.repoName, plan.Path, httpStatusCode)
+	}
+	fmt.Fprintf(sc.out, "Pushed: %d files at the revision %d\n", len(plan.Files), result.Revision)
+	return nil
+}
+
+// pullFiles reverts the local changes in the plan, i.e. writes the remote contents and removes the files
+// which do not exist in the repository.
+func (sc *syncCommand) pullFiles(plan *centraldogma.ImportPlan) error {
+	for _, f := range plan.Files {
+		name := filepath.Join(sc.dir, filepath.FromSlash(strings.TrimPrefix(f.Path, plan.Path)))
+		if f.Action == centraldogma.ImportAdded {
+			if err := os.Remove(name); err != nil {
+				return err
+			}
+			continue
+		}
+
+		content := []byte(f.OldContent)
+		if f.Type == centraldogma.JSON {
+			content = append(safeMarshalIndent(content), '\n')
+		}
+		if err := os.MkdirAll(filepath.Dir(name), defaultPermMode); err != nil {
+			return err
+		}
+		if err := ioutil.WriteFile(name, content, 0644); err != nil {
+			return err
+		}
+	}
+	fmt.Fprintf(sc.out, "Pulled: %d files at the revision %d\n", len(plan.Files), plan.BaseRevision)
+	return nil
+}
+
+// printPlan prints the files which differ with their diffs from the point of view of the destination, i.e.
+// the repository, or the local directory if pull is set. The diffs of the modified JSON files are
+// the operations of their JSON patches, and the others are unified diffs.
+func (sc *syncCommand) printPlan(plan *centraldogma.ImportPlan) {
+	from, to := sc.dir, fmt.Sprintf("/%s/%s%s", plan.Project, plan.Repository, plan.Path)
+	if sc.pull {
+		from, to = to, from
+	}
+	if len(plan.Files) == 0 {
+		fmt.Fprintf(sc.out, "No differences between %s and %s at the revision %d\n", from, to, plan.BaseRevision)
+		return
+	}
+
+	fmt.Fprintf(sc.out, "Changes from %s to %s at the revision %d:\n", from, to, plan.BaseRevision)
+	added, modified, removed := 0, 0, 0
+	for _, f := range plan.Files {
+		// The contents of the repository and the directory.
+		remote, local := string(f.OldContent), string(f.NewContent)
+		oldText, newText := remote, local
+		action := f.Action
+		if sc.pull {
+			oldText, newText = local, remote
+			switch action {
+			case centraldogma.ImportAdded:
+				action = centraldogma.ImportRemoved
+			case centraldogma.ImportRemoved:
+				action = centraldogma.ImportAdded
+			}
+		}
+
+		switch action {
+		case centraldogma.ImportAdded:
+			added++
+			fmt.Fprintf(sc.out, "\nA %s\n", f.Path)
+		case centraldogma.ImportModified:
+			modified++
+			fmt.Fprintf(sc.out, "\nM %s\n", f.Path)
+		case centraldogma.ImportRemoved:
+			removed++
+			fmt.Fprintf(sc.out, "\nD %s\n", f.Path)
+		}
+		if action == centraldogma.ImportModified && f.Type == centraldogma.JSON {
+			if patch, err := jsonpatch.DiffJSON([]byte(oldText), []byte(newText), jsonpatch.Safe); err == nil {
+				printJSONPatch(sc.out, patch)
+				continue
+			}
+		}
+		if f.Type == centraldogma.JSON {
+			oldText, newText = indentJSON(oldText), indentJSON(newText)
+		}
+		fmt.Fprint(sc.out, textdiff.Diff(f.Path, oldText, newText))
+	}
+	fmt.Fprintf(sc.out, "\n%d added, %d modified, %d removed\n", added, modified, removed)
+}
+
+func printJSONPatch(out io.Writer, patch jsonpatch.Patch) {
+	for _, op := range patch {
+		switch op.Op {
+		case jsonpatch.Remove:
+			fmt.Fprintf(out, "  - %s\n", op.Path)
+		case jsonpatch.SafeReplace:
+			fmt.Fprintf(out, "  ~ %s: %s -> %s\n", op.Path, compactJSON(op.OldValue), compactJSON(op.Value))
+		default:
+			fmt.Fprintf(out, "  + %s: %s\n", op.Path, compactJSON(op.Value))
+		}
+	}
+}
+
+func compactJSON(v interface{}) string {
+	b, err := json.Marshal(v)
+	if err != nil {
+		return fmt.Sprint(v)
+	}
+	return string(b)
+}
+
+func indentJSON(text string) string {
+	if len(text) == 0 {
+		return text
+	}
+	return string(safeMarshalIndent([]byte(text))) + "\n"
+}
+
+// newSyncCommand creates the syncCommand.
+func newSyncCommand(c *cli.Context, out io.Writer) (Command, error) {
+	repo, err := newRepositoryRequestInfo(c)
+	if err != nil {
+		return nil, err
+	}
+	if c.Args().Len() != 2 || len(c.Args().Get(1)) == 0 {
+		return nil, newCommandLineError(c)
+	}
+	baseRevision := c.String("base-revision")
+	if len(baseRevision) != 0 {
+		if _, err := centraldogma.ParseRevision(baseRevision); err != nil {
+			return nil, err
+		}
+		repo.revision = baseRevision
+	}
+
+	return &syncCommand{
+		out:     out,
+		repo:    repo,
+		dir:     c.Args().Get(1),
+		check:   c.Bool("check"),
+		pull:    c.Bool("pull"),
+		dryRun:  c.Bool("dry-run"),
+		message: c.String("message"),
+	}, nil
+}
@@ -0,0 +1,192 @@
+// Copyright 2026 LINE Corporation
+//
+// LINE Corporation licenses this file to you under the Apache License,
+// version 2.0 (the "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at:
+//
+//   https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+// WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+// License for the specific language governing permissions and limitations
+// under the License.
+
+package main
+
+import (
+	"bytes"
+	"flag"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/urfave/cli/v2"
+	"go.linecorp.com/centraldogma"
+)
+
+func newSyncCmdContext(flagArguments []string, connectURL string, check, pull bool) *cli.Context {
+	parent := newParentContext(connectURL)
+
+	flags := flag.FlagSet{}
+	flags.Parse(flagArguments)
+	flags.String("revision", "", "")
+	flags.String("base-revision", "", "")
+	flags.String("message", "", "")
+	flags.Bool("check", check, "")
+	flags.Bool("pull", pull, "")
+	flags.Bool("dry-run", false, "")
+	return cli.NewContext(nil, &flags, parent)
+}
+
+func newSyncTestServer(t *testing.T, pushed *bool) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/api/v1/projects/foo/repos/bar/revision/-1":
+			fmt.Fprint(w, `{"revision":5}`)
+		case "/api/v1/projects/foo/repos/bar/contents/conf/**":
+			fmt.Fprint(w, `[{"path":"/conf/a.json","type":"JSON","content":{"a":1,"b":[1,2]}},
+{"path":"/conf/b.txt","type":"TEXT","content":"one\ntwo\n"},
+{"path":"/conf/c.txt","type":"TEXT","content":"same\n"},
+{"path":"/conf/d.txt","type":"TEXT","content":"remote\n"},
+{"path":"/conf/.hidden.json","type":"JSON","content":{"hidden":true}}]`)
+		case "/api/v1/projects/foo/repos/bar/contents":
+			if r.URL.Query().Get("revision") != "5" {
+				t.Errorf("pushed at the revision %s, want 5", r.URL.Query().Get("revision"))
+			}
+			*pushed = true
+			fmt.Fprint(w, `{"revision":6, "pushedAt":"2017-05-22T00:00:00Z"}`)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+}
+
+func newSyncTestDirectory(t *testing.T) string {
+	dir := t.TempDir()
+	for name, content := range map[string]string{
+		"a.json":  `{"a": 2, "b": [1, 2, 3]}`,
+		"b.txt":   "one\nthree\n",
+		"c.txt":   "same\n",
+		"e/f.txt": "local\n",
+	} {
+		p := filepath.Join(dir, filepath.FromSlash(name))
+		if err := os.MkdirAll(filepath.Dir(p), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := ioutil.WriteFile(p, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return dir
+}
+
+func runSyncCommand(t *testing.T, server *httptest.Server, dir string, check, pull bool) (string, error) {
+	c := newSyncCmdContext([]string{"foo/bar/conf", dir}, server.URL, check, pull)
+	command, err := newSyncCommand(c, os.Stdout)
+	if err != nil {
+		t.Fatal(err)
+	}
+	client, err := centraldogma.NewClientWithToken(server.URL, "anonymous", server.Client().Transport)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var out bytes.Buffer
+	cmd := command.(*syncCommand)
+	cmd.out = &out
+	err = cmd.executeWithDogmaClient(c, client)
+	return out.String(), err
+}
+
+func TestSyncCheck(t *testing.T) {
+	pushed := false
+	server := newSyncTestServer(t, &pushed)
+	defer server.Close()
+	dir := newSyncTestDirectory(t)
+
+	out, err := runSyncCommand(t, server, dir, true, false)
+	if err == nil {
+		t.Error("sync --check with the differences should fail")
+	}
+	if pushed {
+		t.Error("sync --check should not push the changes")
+	}
+	for _, want := range []string{
+		"Changes from " + dir + " to /foo/bar/conf/ at the revision 5:",
+		"M /conf/a.json\n  ~ /a: 1 -> 2\n  + /b/2: 3\n",
+		"M /conf/b.txt\n--- /conf/b.txt\n+++ /conf/b.txt\n@@ -1,2 +1,2 @@\n one\n-two\n+three\n",
+		"D /conf/d.txt\n",
+		"A /conf/e/f.txt\n",
+		"1 added, 2 modified, 1 removed",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("output does not contain %q:\n%s", want, out)
+		}
+	}
+	if strings.Contains(out, "/conf/c.txt") {
+		t.Errorf("output contains the unchanged file:\n%s", out)
+	}
+	// The remote dotfiles are not removed, because the local ones are not synced.
+	if strings.Contains(out, "/conf/.hidden.json") {
+		t.Errorf("output contains the remote dotfile:\n%s", out)
+	}
+}
+
+func TestSyncPush(t *testing.T) {
+	pushed := false
+	server := newSyncTestServer(t, &pushed)
+	defer server.Close()
+	dir := newSyncTestDirectory(t)
+
+	out, err := runSyncCommand(t, server, dir, false, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !pushed || !strings.HasSuffix(out, "Pushed: 4 files at the revision 6\n") {
+		t.Errorf("sync did not push the changes:\n%s", out)
+	}
+}
+
+func TestSyncPull(t *testing.T) {
+	pushed := false
+	server := newSyncTestServer(t, &pushed)
+	defer server.Close()
+	dir := newSyncTestDirectory(t)
+
+	out, err := runSyncCommand(t, server, dir, false, true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if pushed {
+		t.Error("sync --pull should not push the changes")
+	}
+	for _, want := range []string{
+		"Changes from /foo/bar/conf/ to " + dir + " at the revision 5:",
+		"A /conf/d.txt\n", "D /conf/e/f.txt\n", "Pulled: 4 files at the revision 5\n",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("output does not contain %q:\n%s", want, out)
+		}
+	}
+
+	if _, err := os.Stat(filepath.Join(dir, "e", "f.txt")); !os.IsNotExist(err) {
+		t.Errorf("e/f.txt should be removed: %v", err)
+	}
+	if b, _ := ioutil.ReadFile(filepath.Join(dir, "d.txt")); string(b) != "remote\n" {
+		t.Errorf("d.txt = %q, want remote", b)
+	}
+	if _, err := os.Stat(filepath.Join(dir, ".hidden.json")); !os.IsNotExist(err) {
+		t.Errorf(".hidden.json should not be pulled: %v", err)
+	}
+
+	// The directory is the same as the repository after pulling.
+	out, err = runSyncCommand(t, server, dir, true, false)
+	if err != nil {
+		t.Errorf("sync --check after pulling failed: %v\n%s", err, out)
+	}
+}
@@ -0,0 +1,110 @@
+// Copyright 2026 LINE Corporation
+//
+// LINE Corporation licenses this file to you under the Apache License,
+// version 2.0 (the "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at:
+//
+//   https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+// WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+// License for the specific language governing permissions and limitations
+// under the License.
+
+// Package pathpattern matches the paths in a repository with the path patterns of Central Dogma.
+package pathpattern
+
+import (
+	"regexp"
+	"strings"
+)
+
+// Pattern matches the paths in a repository. It is a comma-separated list of glob patterns such as
+// "/**/*.json,/a/*.txt". A pattern which does not start with "/" matches the files in any directory.
+type Pattern struct {
+	all     bool
+	regexps []*regexp.Regexp
+}
+
+// Compile parses the path pattern. An empty pattern matches all paths.
+func Compile(pattern string) (*Pattern, error) {
+	p := &Pattern{}
+	for _, glob := range strings.Split(pattern, ",") {
+		glob = strings.TrimSpace(glob)
+		if len(glob) == 0 {
+			continue
+		}
+		if !strings.HasPrefix(glob, "/") {
+			glob = "/**/" + glob
+		}
+		if glob == "/**" {
+			p.all = true
+		}
+		re, err := regexp.Compile(globToRegexp(glob))
+		if err != nil {
+			return nil, err
+		}
+		p.regexps = append(p.regexps, re)
+	}
+	if len(p.regexps) == 0 {
+		p.all = true
+	}
+	return p, nil
+}
+
+// Match returns whether the path matches the pattern.
+func (p *Pattern) Match(path string) bool {
+	if p.all {
+		return true
+	}
+	for _, re := range p.regexps {
+		if re.MatchString(path) {
+			return true
+		}
+	}
+	return false
+}
+
+// globToRegexp converts the glob pattern into a regular expression. "**" matches any number of directories,
+// "*" matches any characters except "/" and "?" matches a character except "/".
+func globToRegexp(glob string) string {
+	var b strings.Builder
+	b.WriteString("^")
+	for i := 0; i < len(glob); i++ {
+		switch c := glob[i]; {
+		case strings.HasPrefix(glob[i:], "/**/"):
+			b.WriteString("(/.*)?/")
+			i += 3
+		case strings.HasPrefix(glob[i:], "/**") && i+3 == len(glob):
+			b.WriteString("(/.*)?")
+			i += 2
+		case strings.HasPrefix(glob[i:], "**"):
+			b.WriteString(".*")
+			i++
+		case c == '*':
+			b.WriteString("[^/]*")
+		case c == '?':
+			b.WriteString("[^/]")
+		default:
+			b.WriteString(regexp.QuoteMeta(string(c)))
+		}
+	}
+	b.WriteString("$")
+	return b.String()
+}
+
+// MatchesAll returns whether the pattern matches all paths, e.g. "/**".
+func (p *Pattern) MatchesAll() bool {
+	return p.all
+}
+
+// IsPattern returns whether the path is a pattern rather than the path of a file.
+func IsPattern(path string) bool {
+	return strings.ContainsAny(path, "*?,")
+}
+
+// Exact returns the Pattern which matches only the path.
+func Exact(path string) *Pattern {
+	return &Pattern{regexps: []*regexp.Regexp{regexp.MustCompile("^" + regexp.QuoteMeta(path) + "$")}}
+}
@@ -0,0 +1,50 @@
+// Copyright 2026 LINE Corporation
+//
+// LINE Corporation licenses this file to you under the Apache License,
+// version 2.0 (the "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at:
+//
+//   https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+// WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+// License for the specific language governing permissions and limitations
+// under the License.
+
+package pathpattern
+
+import "testing"
+
+func TestMatch(t *testing.T) {
+	var tests = []struct {
+		pattern string
+		path    string
+		want    bool
+	}{
+		{"/**", "/a/b.json", true},
+		{"", "/a.txt", true},
+		{"*.json", "/a/b/c.json", true},
+		{"*.json", "/c.txt", false},
+		{"/a/*.json", "/a/b.json", true},
+		{"/a/*.json", "/a/b/c.json", false},
+		{"/a/**/c.json", "/a/c.json", true},
+		{"/a/**/c.json", "/a/b/d/c.json", true},
+		{"/?.txt", "/a.txt", true},
+		{"/?.txt", "/ab.txt", false},
+		{"/a.json,/b/*.txt", "/b/c.txt", true},
+	}
+	for _, test := range tests {
+		p, err := Compile(test.pattern)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got := p.Match(test.path); got != test.want {
+			t.Errorf("Compile(%q).Match(%q) = %v, want %v", test.pattern, test.path, got, test.want)
+		}
+	}
+
+	if !Exact("/a.json").Match("/a.json") || Exact("/a.json").Match("/a1json") {
+		t.Errorf("Exact(\"/a.json\") should match only /a.json")
+	}
+}
@@ -0,0 +1,294 @@
+// Copyright 2026 LINE Corporation
+//
+// LINE Corporation licenses this file to you under the Apache License,
+// version 2.0 (the "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at:
+//
+//   https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+// WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+// License for the specific language governing permissions and limitations
+// under the License.
+
+package jsonpatch
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// Apply applies the Patch to the document and returns the patched document. The document is not modified.
+// If an operation of "test", "testAbsence" or "safeReplace" does not match the document, the returned error
+// wraps ErrConflict.
+func (p Patch) Apply(doc interface{}) (interface{}, error) {
+	doc, err := clone(doc)
+	if err != nil {
+		return nil, err
+	}
+	for _, op := range p {
+		if doc, err = op.apply(doc); err != nil {
+			return nil, err
+		}
+	}
+	return doc, nil
+}
+
+// ApplyJSON applies the Patch to the JSON document and returns the patched JSON document.
+func (p Patch) ApplyJSON(doc []byte) ([]byte, error) {
+	var v interface{}
+	if err := json.Unmarshal(doc, &v); err != nil {
+		return nil, err
+	}
+	patched, err := p.Apply(v)
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(patched)
+}
+
+func (o *Operation) apply(doc interface{}) (interface{}, error) {
+	path, err := parsePointer(o.Path)
+	if err != nil {
+		return nil, err
+	}
+
+	switch o.Op {
+	case Add:
+		value, err := clone(o.Value)
+		if err != nil {
+			return nil, err
+		}
+		return add(doc, path, value)
+
+	case Remove:
+		return remove(doc, path)
+
+	case RemoveIfExists:
+		if _, ok := get(doc, path); !ok {
+			return doc, nil
+		}
+		return remove(doc, path)
+
+	case Replace:
+		value, err := clone(o.Value)
+		if err != nil {
+			return nil, err
+		}
+		return replace(doc, path, value)
+
+	case SafeReplace:
+		oldValue, err := clone(o.OldValue)
+		if err != nil {
+			return nil, err
+		}
+		if current, ok := get(doc, path); !ok || !reflect.DeepEqual(current, oldValue) {
+			return nil, fmt.Errorf("%w: the value at %q is not %v", ErrConflict, o.Path, o.OldValue)
+		}
+		value, err := clone(o.Value)
+		if err != nil {
+			return nil, err
+		}
+		return replace(doc, path, value)
+
+	case Test:
+		value, err := clone(o.Value)
+		if err != nil {
+			return nil, err
+		}
+		if current, ok := get(doc, path); !ok || !reflect.DeepEqual(current, value) {
+			return nil, fmt.Errorf("%w: the value at %q is not %v", ErrConflict, o.Path, o.Value)
+		}
+		return doc, nil
+
+	case TestAbsence:
+		if _, ok := get(doc, path); ok {
+			return nil, fmt.Errorf("%w: %q exists", ErrConflict, o.Path)
+		}
+		return doc, nil
+
+	case Move, Copy:
+		from, err := parsePointer(o.From)
+		if err != nil {
+			return nil, err
+		}
+		value, ok := get(doc, from)
+		if !ok {
+			return nil, fmt.Errorf("jsonpatch: %q does not exist", o.From)
+		}
+		if o.Op == Copy {
+			if value, err = clone(value); err != nil {
+				return nil, err
+			}
+			return add(doc, path, value)
+		}
+		if strings.HasPrefix(o.Path, o.From+"/") {
+			return nil, fmt.Errorf("jsonpatch: cannot move %q into its child %q", o.From, o.Path)
+		}
+		if doc, err = remove(doc, from); err != nil {
+			return nil, err
+		}
+		return add(doc, path, value)
+
+	default:
+		return nil, fmt.Errorf("jsonpatch: unknown op: %q", o.Op)
+	}
+}
+
+func get(doc interface{}, path pointer) (interface{}, bool) {
+	for _, token := range path {
+		switch d := doc.(type) {
+		case map[string]interface{}:
+			child, ok := d[token]
+			if !ok {
+				return nil, false
+			}
+			doc = child
+		case []interface{}:
+			i, err := arrayIndex(token, len(d)-1)
+			if err != nil {
+				return nil, false
+			}
+			doc = d[i]
+		default:
+			return nil, false
+		}
+	}
+	return doc, true
+}
+
+func add(doc interface{}, path pointer, value interface{}) (interface{}, error) {
+	if len(path) == 0 {
+		return value, nil
+	}
+	token := path[len(path)-1]
+	return update(doc, path[:len(path)-1], func(parent interface{}) (interface{}, error) {
+		switch p := parent.(type) {
+		case map[string]interface{}:
+			p[token] = value
+			return p, nil
+		case []interface{}:
+			if token == "-" {
+				return append(p, value), nil
+			}
+			i, err := arrayIndex(token, len(p))
+			if err != nil {
+				return nil, err
+			}
+			p = append(p, nil)
+			copy(p[i+1:], p[i:])
+			p[i] = value
+			return p, nil
+		default:
+			return nil, fmt.Errorf("jsonpatch: cannot add %q to a non-container value", path)
+		}
+	})
+}
+
+func remove(doc interface{}, path pointer) (interface{}, error) {
+	if len(path) == 0 {
+		return nil, fmt.Errorf("jsonpatch: cannot remove the root")
+	}
+	token := path[len(path)-1]
+	return update(doc, path[:len(path)-1], func(parent interface{}) (interface{}, error) {
+		switch p := parent.(type) {
+		case map[string]interface{}:
+			if _, ok := p[token]; !ok {
+				return nil, fmt.Errorf("jsonpatch: %q does not exist", path)
+			}
+			delete(p, token)
+			return p, nil
+		case []interface{}:
+			i, err := arrayIndex(token, len(p)-1)
+			if err != nil {
+				return nil, err
+			}
+			return append(p[:i], p[i+1:]...), nil
+		default:
+			return nil, fmt.Errorf("jsonpatch: %q does not exist", path)
+		}
+	})
+}
+
+func replace(doc interface{}, path pointer, value interface{}) (interface{}, error) {
+	if _, ok := get(doc, path); !ok {
+		return nil, fmt.Errorf("jsonpatch: %q does not exist", path)
+	}
+	if len(path) == 0 {
+		return value, nil
+	}
+	token := path[len(path)-1]
+	return update(doc, path[:len(path)-1], func(parent interface{}) (interface{}, error) {
+		switch p := parent.(type) {
+		case map[string]interface{}:
+			p[token] = value
+		case []interface{}:
+			i, _ := arrayIndex(token, len(p)-1)
+			p[i] = value
+		}
+		return parent, nil
+	})
+}
+
+// update replaces the value at the path with the result of the updateFunc and returns the updated document.
+func update(doc interface{}, path pointer,
+	updateFunc func(value interface{}) (interface{}, error)) (interface{}, error) {
+	if len(path) == 0 {
+		return updateFunc(doc)
+	}
+
+	token := path[0]
+	switch d := doc.(type) {
+	case map[string]interface{}:
+		child, ok := d[token]
+		if !ok {
+			return nil, fmt.Errorf("jsonpatch: %q does not exist", token)
+		}
+		updated, err := update(child, path[1:], updateFunc)
+		if err != nil {
+			return nil, err
+		}
+		d[token] = updated
+		return d, nil
+	case []interface{}:
+		i, err := arrayIndex(token, len(d)-1)
+		if err != nil {
+			return nil, err
+		}
+		updated, err := update(d[i], path[1:], updateFunc)
+		if err != nil {
+			return nil, err
+		}
+		d[i] = updated
+		return d, nil
+	default:
+		return nil, fmt.Errorf("jsonpatch: %q does not exist", token)
+	}
+}
+
+// arrayIndex parses the token as an array index which is not greater than the max.
+func arrayIndex(token string, max int) (int, error) {
+	i, err := strconv.Atoi(token)
+	if err != nil || i < 0 || i > max || (len(token) > 1 && token[0] == '0') {
+		return 0, fmt.Errorf("jsonpatch: invalid array index: %q", token)
+	}
+	return i, nil
+}
+
+// clone returns a deep copy of the value whose numbers are all float64 so that values
+// from different sources can be compared with reflect.DeepEqual.
+func clone(v interface{}) (interface{}, error) {
+	b, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+	var cloned interface{}
+	if err := json.Unmarshal(b, &cloned); err != nil {
+		return nil, err
+	}
+	return cloned, nil
+}
@@ -0,0 +1,91 @@
+// Copyright 2026 LINE Corporation
+//
+// LINE Corporation licenses this file to you under the Apache License,
+// version 2.0 (the "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at:
+//
+//   https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+// WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+// License for the specific language governing permissions and limitations
+// under the License.
+
+package jsonpatch
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestApplyJSON(t *testing.T) {
+	var tests = []struct {
+		doc   string
+		patch string
+		want  string
+	}{
+		{`{}`, `[{"op":"add","path":"/a","value":[1]}]`, `{"a":[1]}`},
+		{`{"a":[1,3]}`, `[{"op":"add","path":"/a/1","value":2}]`, `{"a":[1,2,3]}`},
+		{`{"a":[1]}`, `[{"op":"add","path":"/a/-","value":2}]`, `{"a":[1,2]}`},
+		{`{"a":1,"b":2}`, `[{"op":"remove","path":"/a"}]`, `{"b":2}`},
+		{`{"a":1}`, `[{"op":"removeIfExists","path":"/b"}]`, `{"a":1}`},
+		{`{"a":1}`, `[{"op":"replace","path":"","value":[]}]`, `[]`},
+		{`{"a":{"b":1}}`, `[{"op":"move","from":"/a/b","path":"/c"}]`, `{"a":{},"c":1}`},
+		{`{"a":{"b":1}}`, `[{"op":"copy","from":"/a","path":"/c"}]`, `{"a":{"b":1},"c":{"b":1}}`},
+		{`{"a":"bar"}`, `[{"op":"test","path":"/a","value":"bar"},{"op":"safeReplace","path":"/a",
+"oldValue":"bar","value":"baz"}]`, `{"a":"baz"}`},
+		{`{"a":1}`, `[{"op":"testAbsence","path":"/b"}]`, `{"a":1}`},
+	}
+
+	for _, test := range tests {
+		patch, err := ParsePatch([]byte(test.patch))
+		if err != nil {
+			t.Fatalf("ParsePatch(%s) returned an error: %v", test.patch, err)
+		}
+		got, err := patch.ApplyJSON([]byte(test.doc))
+		if err != nil {
+			t.Fatalf("ApplyJSON(%s, %s) returned an error: %v", test.doc, test.patch, err)
+		}
+		if string(got) != test.want {
+			t.Errorf("ApplyJSON(%s, %s) = %s, want %s", test.doc, test.patch, got, test.want)
+		}
+	}
+}
+
+func TestApplyJSON_Conflict(t *testing.T) {
+	var tests = []struct {
+		doc   string
+		patch Patch
+	}{
+		{`{"a":1}`, Patch{{Op: Test, Path: "/a", Value: 2}}},
+		{`{"a":1}`, Patch{{Op: SafeReplace, Path: "/a", OldValue: 2, Value: 3}}},
+		{`{"a":1}`, Patch{{Op: TestAbsence, Path: "/a"}}},
+	}
+
+	for _, test := range tests {
+		if _, err := test.patch.ApplyJSON([]byte(test.doc)); !errors.Is(err, ErrConflict) {
+			t.Errorf("ApplyJSON(%s, %+v) returned %v, want %v", test.doc, test.patch, err, ErrConflict)
+		}
+	}
+}
+
+func TestApplyJSON_Invalid(t *testing.T) {
+	var tests = []struct {
+		doc   string
+		patch Patch
+	}{
+		{`{"a":1}`, Patch{{Op: Remove, Path: "/b"}}},
+		{`{"a":1}`, Patch{{Op: Replace, Path: "/b", Value: 1}}},
+		{`{"a":[1]}`, Patch{{Op: Add, Path: "/a/3", Value: 1}}},
+		{`{"a":{"b":1}}`, Patch{{Op: Move, From: "/a", Path: "/a/b/c"}}},
+		{`{"a":1}`, Patch{{Op: "unknown", Path: "/a"}}},
+		{`{"a":1}`, Patch{{Op: Add, Path: "a", Value: 1}}},
+	}
+
+	for _, test := range tests {
+		if _, err := test.patch.ApplyJSON([]byte(test.doc)); err == nil {
+			t.Errorf("ApplyJSON(%s, %+v) returned no error", test.doc, test.patch)
+		}
+	}
+}
@@ -0,0 +1,116 @@
+// Copyright 2026 LINE Corporation
+//
+// LINE Corporation licenses this file to you under the Apache License,
+// version 2.0 (the "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at:
+//
+//   https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+// WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+// License for the specific language governing permissions and limitations
+// under the License.
+
+package jsonpatch
+
+import (
+	"encoding/json"
+	"reflect"
+	"sort"
+)
+
+// ReplaceMode specifies which operation Diff uses when a value is replaced.
+type ReplaceMode int
+
+const (
+	// RFC6902 generates "replace" operations.
+	RFC6902 ReplaceMode = iota
+	// Safe generates "safeReplace" operations which fail to apply if the old value has been changed.
+	Safe
+)
+
+// Diff returns the Patch which transforms the source into the target.
+func Diff(source, target interface{}, mode ReplaceMode) Patch {
+	patch := Patch{}
+	diff(&patch, "", source, target, mode)
+	return patch
+}
+
+// DiffJSON returns the Patch which transforms the source JSON document into the target JSON document.
+func DiffJSON(source, target []byte, mode ReplaceMode) (Patch, error) {
+	var src, dst interface{}
+	if err := json.Unmarshal(source, &src); err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(target, &dst); err != nil {
+		return nil, err
+	}
+	return Diff(src, dst, mode), nil
+}
+
+func diff(patch *Patch, path string, source, target interface{}, mode ReplaceMode) {
+	if reflect.DeepEqual(source, target) {
+		return
+	}
+
+	switch src := source.(type) {
+	case map[string]interface{}:
+		if dst, ok := target.(map[string]interface{}); ok {
+			diffObject(patch, path, src, dst, mode)
+			return
+		}
+	case []interface{}:
+		if dst, ok := target.([]interface{}); ok {
+			diffArray(patch, path, src, dst, mode)
+			return
+		}
+	}
+
+	if mode == Safe {
+		*patch = append(*patch, Operation{Op: SafeReplace, Path: path, OldValue: source, Value: target})
+	} else {
+		*patch = append(*patch, Operation{Op: Replace, Path: path, Value: target})
+	}
+}
+
+func diffObject(patch *Patch, path string, source, target map[string]interface{}, mode ReplaceMode) {
+	for _, key := range sortedKeys(source) {
+		if _, ok := target[key]; !ok {
+			*patch = append(*patch, Operation{Op: Remove, Path: appendPath(path, key)})
+		}
+	}
+	for _, key := range sortedKeys(target) {
+		if srcValue, ok := source[key]; ok {
+			diff(patch, appendPath(path, key), srcValue, target[key], mode)
+		} else {
+			*patch = append(*patch, Operation{Op: Add, Path: appendPath(path, key), Value: target[key]})
+		}
+	}
+}
+
+func diffArray(patch *Patch, path string, source, target []interface{}, mode ReplaceMode) {
+	common := len(source)
+	if len(target) < common {
+		common = len(target)
+	}
+	for i := 0; i < common; i++ {
+		diff(patch, appendIndex(path, i), source[i], target[i], mode)
+	}
+	// Remove from the end so that the indexes of the remaining elements do not change.
+	for i := len(source) - 1; i >= common; i-- {
+		*patch = append(*patch, Operation{Op: Remove, Path: appendIndex(path, i)})
+	}
+	for i := common; i < len(target); i++ {
+		*patch = append(*patch, Operation{Op: Add, Path: appendIndex(path, i), Value: target[i]})
+	}
+}
+
+func sortedKeys(m map[string]interface{}) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
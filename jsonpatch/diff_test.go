@@ -0,0 +1,67 @@
+// Copyright 2026 LINE Corporation
+//
+// LINE Corporation licenses this file to you under the Apache License,
+// version 2.0 (the "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at:
+//
+//   https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+// WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+// License for the specific language governing permissions and limitations
+// under the License.
+
+package jsonpatch
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+)
+
+func TestDiffJSON(t *testing.T) {
+	var tests = []struct {
+		source string
+		target string
+		mode   ReplaceMode
+		want   string
+	}{
+		{`{"a":1}`, `{"a":1}`, RFC6902, `[]`},
+		{`{"a":1}`, `{"a":2}`, RFC6902, `[{"op":"replace","path":"/a","value":2}]`},
+		{`{"a":1}`, `{"a":2}`, Safe, `[{"op":"safeReplace","path":"/a","oldValue":1,"value":2}]`},
+		{`{"a":1,"b":2}`, `{"b":2,"c":null}`, RFC6902,
+			`[{"op":"remove","path":"/a"},{"op":"add","path":"/c","value":null}]`},
+		{`{"a/b":{"c~d":1}}`, `{"a/b":{"c~d":true}}`, RFC6902,
+			`[{"op":"replace","path":"/a~1b/c~0d","value":true}]`},
+		{`[1,2,3]`, `[1,4]`, RFC6902,
+			`[{"op":"replace","path":"/1","value":4},{"op":"remove","path":"/2"}]`},
+		{`[1]`, `[1,2,3]`, RFC6902,
+			`[{"op":"add","path":"/1","value":2},{"op":"add","path":"/2","value":3}]`},
+		{`"bar"`, `"baz"`, Safe, `[{"op":"safeReplace","path":"","oldValue":"bar","value":"baz"}]`},
+		{`{"a":[1]}`, `{"a":{"b":1}}`, RFC6902, `[{"op":"replace","path":"/a","value":{"b":1}}]`},
+	}
+
+	for _, test := range tests {
+		patch, err := DiffJSON([]byte(test.source), []byte(test.target), test.mode)
+		if err != nil {
+			t.Fatalf("DiffJSON(%s, %s) returned an error: %v", test.source, test.target, err)
+		}
+		got, _ := json.Marshal(patch)
+		if string(got) != test.want {
+			t.Errorf("DiffJSON(%s, %s) = %s, want %s", test.source, test.target, got, test.want)
+		}
+
+		// The generated patch must transform the source into the target.
+		patched, err := patch.ApplyJSON([]byte(test.source))
+		if err != nil {
+			t.Fatalf("ApplyJSON(%s) returned an error: %v", test.source, err)
+		}
+		var gotTarget, wantTarget interface{}
+		_ = json.Unmarshal(patched, &gotTarget)
+		_ = json.Unmarshal([]byte(test.target), &wantTarget)
+		if !reflect.DeepEqual(gotTarget, wantTarget) {
+			t.Errorf("ApplyJSON(%s) = %s, want %s", test.source, patched, test.target)
+		}
+	}
+}
@@ -0,0 +1,140 @@
+// Copyright 2026 LINE Corporation
+//
+// LINE Corporation licenses this file to you under the Apache License,
+// version 2.0 (the "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at:
+//
+//   https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+// WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+// License for the specific language governing permissions and limitations
+// under the License.
+
+/*
+Package jsonpatch implements JSON Patch (RFC 6902) along with the extra operations understood by
+Central Dogma, such as "safeReplace", "testAbsence" and "removeIfExists".
+
+A Patch can be generated from two JSON documents and pushed as an APPLY_JSON_PATCH change:
+
+	patch, err := jsonpatch.DiffJSON(oldContent, newContent, jsonpatch.Safe)
+	if err != nil {
+	    panic(err)
+	}
+	result, _, err := client.PatchJSON(ctx, "foo", "bar", centraldogma.Head(), commitMessage, "/a.json", patch)
+
+The values in a Patch are the ones produced by encoding/json when decoding into an interface{},
+i.e. map[string]interface{}, []interface{}, float64, string, bool and nil.
+*/
+package jsonpatch
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// The operation names of a JSON patch.
+const (
+	Add            = "add"
+	Remove         = "remove"
+	Replace        = "replace"
+	Move           = "move"
+	Copy           = "copy"
+	Test           = "test"
+	SafeReplace    = "safeReplace"
+	TestAbsence    = "testAbsence"
+	RemoveIfExists = "removeIfExists"
+)
+
+// ErrConflict is returned when a "test", "testAbsence" or "safeReplace" operation does not match the document.
+var ErrConflict = errors.New("jsonpatch: conflict")
+
+// Operation represents an operation of a JSON patch.
+type Operation struct {
+	Op       string      `json:"op"`
+	Path     string      `json:"path"`
+	From     string      `json:"from,omitempty"`
+	Value    interface{} `json:"value,omitempty"`
+	OldValue interface{} `json:"oldValue,omitempty"`
+}
+
+// MarshalJSON always writes the value of the operations that require it, even if it is null.
+func (o Operation) MarshalJSON() ([]byte, error) {
+	switch o.Op {
+	case Add, Replace, Test:
+		return json.Marshal(&struct {
+			Op    string      `json:"op"`
+			Path  string      `json:"path"`
+			Value interface{} `json:"value"`
+		}{o.Op, o.Path, o.Value})
+	case SafeReplace:
+		return json.Marshal(&struct {
+			Op       string      `json:"op"`
+			Path     string      `json:"path"`
+			OldValue interface{} `json:"oldValue"`
+			Value    interface{} `json:"value"`
+		}{o.Op, o.Path, o.OldValue, o.Value})
+	default:
+		type Alias Operation
+		return json.Marshal((*Alias)(&o))
+	}
+}
+
+// Patch represents a JSON patch which is a series of operations.
+type Patch []Operation
+
+// ParsePatch parses the JSON representation of a JSON patch.
+func ParsePatch(b []byte) (Patch, error) {
+	var patch Patch
+	if err := json.Unmarshal(b, &patch); err != nil {
+		return nil, err
+	}
+	for i, op := range patch {
+		if len(op.Op) == 0 {
+			return nil, fmt.Errorf("jsonpatch: missing op at index %d", i)
+		}
+	}
+	return patch, nil
+}
+
+// pointer is a parsed JSON pointer (RFC 6901).
+type pointer []string
+
+func parsePointer(path string) (pointer, error) {
+	if len(path) == 0 {
+		return pointer{}, nil
+	}
+	if !strings.HasPrefix(path, "/") {
+		return nil, fmt.Errorf("jsonpatch: invalid JSON pointer: %q", path)
+	}
+	tokens := strings.Split(path[1:], "/")
+	for i, token := range tokens {
+		tokens[i] = strings.ReplaceAll(strings.ReplaceAll(token, "~1", "/"), "~0", "~")
+	}
+	return tokens, nil
+}
+
+func (p pointer) String() string {
+	var b strings.Builder
+	for _, token := range p {
+		b.WriteString("/")
+		b.WriteString(escapeToken(token))
+	}
+	return b.String()
+}
+
+func escapeToken(token string) string {
+	return strings.ReplaceAll(strings.ReplaceAll(token, "~", "~0"), "/", "~1")
+}
+
+func appendPath(path, token string) string {
+	return path + "/" + escapeToken(token)
+}
+
+func appendIndex(path string, index int) string {
+	return path + "/" + strconv.Itoa(index)
+}
@@ -0,0 +1,266 @@
+// Copyright 2026 LINE Corporation
+//
+// LINE Corporation licenses this file to you under the Apache License,
+// version 2.0 (the "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at:
+//
+//   https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+// WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+// License for the specific language governing permissions and limitations
+// under the License.
+
+package jsonpath
+
+import (
+	"encoding/json"
+	"regexp"
+)
+
+// filterExpr is the expression of a filter.
+type filterExpr interface {
+	match(root, current interface{}) (bool, error)
+}
+
+type orExpr struct {
+	left, right filterExpr
+}
+
+func (e *orExpr) match(root, current interface{}) (bool, error) {
+	matched, err := e.left.match(root, current)
+	if err != nil || matched {
+		return matched, err
+	}
+	return e.right.match(root, current)
+}
+
+type andExpr struct {
+	left, right filterExpr
+}
+
+func (e *andExpr) match(root, current interface{}) (bool, error) {
+	matched, err := e.left.match(root, current)
+	if err != nil || !matched {
+		return matched, err
+	}
+	return e.right.match(root, current)
+}
+
+type notExpr struct {
+	expr filterExpr
+}
+
+func (e *notExpr) match(root, current interface{}) (bool, error) {
+	matched, err := e.expr.match(root, current)
+	return !matched, err
+}
+
+// existsExpr matches if the path has a value.
+type existsExpr struct {
+	operand operand
+}
+
+func (e *existsExpr) match(root, current interface{}) (bool, error) {
+	v, found, err := e.operand.resolve(root, current)
+	if err != nil || !found {
+		return false, err
+	}
+	if list, ok := v.([]interface{}); ok && !e.operand.(*pathOperand).path.IsDefinite() {
+		return len(list) != 0, nil
+	}
+	return true, nil
+}
+
+type compareExpr struct {
+	op          string
+	left, right operand
+}
+
+func (e *compareExpr) match(root, current interface{}) (bool, error) {
+	left, leftFound, err := e.left.resolve(root, current)
+	if err != nil {
+		return false, err
+	}
+
+	if e.op == "=~" {
+		re, ok := e.right.(*regexOperand)
+		if !ok {
+			return false, nil
+		}
+		s, ok := left.(string)
+		return leftFound && ok && re.re.MatchString(s), nil
+	}
+
+	right, rightFound, err := e.right.resolve(root, current)
+	if err != nil {
+		return false, err
+	}
+	if !leftFound || !rightFound {
+		// An undefined value is different from any value.
+		return e.op == "!=" || e.op == "nin", nil
+	}
+
+	switch e.op {
+	case "==":
+		return equal(left, right), nil
+	case "!=":
+		return !equal(left, right), nil
+	case "in", "nin":
+		in := false
+		if list, ok := right.([]interface{}); ok {
+			for _, v := range list {
+				if equal(left, v) {
+					in = true
+					break
+				}
+			}
+		}
+		return in == (e.op == "in"), nil
+	}
+
+	// <, <=, > and >= compare numbers or strings.
+	var cmp int
+	if l, ok := toNumber(left); ok {
+		r, ok := toNumber(right)
+		if !ok {
+			return false, nil
+		}
+		cmp = compareFloat(l, r)
+	} else if l, ok := left.(string); ok {
+		r, ok := right.(string)
+		if !ok {
+			return false, nil
+		}
+		cmp = compareString(l, r)
+	} else {
+		return false, nil
+	}
+
+	switch e.op {
+	case "<":
+		return cmp < 0, nil
+	case "<=":
+		return cmp <= 0, nil
+	case ">":
+		return cmp > 0, nil
+	default: // ">="
+		return cmp >= 0, nil
+	}
+}
+
+func compareFloat(l, r float64) int {
+	switch {
+	case l < r:
+		return -1
+	case l > r:
+		return 1
+	}
+	return 0
+}
+
+func compareString(l, r string) int {
+	switch {
+	case l < r:
+		return -1
+	case l > r:
+		return 1
+	}
+	return 0
+}
+
+// operand is an operand of a filter expression.
+type operand interface {
+	// resolve returns the value of the operand and whether the value is defined.
+	resolve(root, current interface{}) (interface{}, bool, error)
+}
+
+type valueOperand struct {
+	value interface{}
+}
+
+func (o *valueOperand) resolve(root, current interface{}) (interface{}, bool, error) {
+	return o.value, true, nil
+}
+
+type pathOperand struct {
+	path     *Path
+	relative bool
+}
+
+func (o *pathOperand) resolve(root, current interface{}) (interface{}, bool, error) {
+	if o.relative {
+		return o.path.eval(root, current)
+	}
+	return o.path.eval(root, root)
+}
+
+type regexOperand struct {
+	re *regexp.Regexp
+}
+
+func (o *regexOperand) resolve(root, current interface{}) (interface{}, bool, error) {
+	return o.re.String(), true, nil
+}
+
+func toNumber(v interface{}) (float64, bool) {
+	switch n := v.(type) {
+	case float64:
+		return n, true
+	case json.Number:
+		f, err := n.Float64()
+		return f, err == nil
+	case int:
+		return float64(n), true
+	case int64:
+		return float64(n), true
+	}
+	return 0, false
+}
+
+// equal returns whether the two JSON values are equal. Numbers are compared by their values and
+// objects are compared regardless of the order of their members.
+func equal(a, b interface{}) bool {
+	if an, ok := toNumber(a); ok {
+		bn, ok := toNumber(b)
+		return ok && an == bn
+	}
+	switch av := a.(type) {
+	case nil:
+		return b == nil
+	case string:
+		bv, ok := b.(string)
+		return ok && av == bv
+	case bool:
+		bv, ok := b.(bool)
+		return ok && av == bv
+	case []interface{}:
+		bv, ok := b.([]interface{})
+		if !ok || len(av) != len(bv) {
+			return false
+		}
+		for i := range av {
+			if !equal(av[i], bv[i]) {
+				return false
+			}
+		}
+		return true
+	}
+
+	aKeys, aValues, ok := members(a)
+	if !ok {
+		return false
+	}
+	bKeys, bValues, ok := members(b)
+	if !ok || len(aKeys) != len(bKeys) {
+		return false
+	}
+	for _, k := range aKeys {
+		bv, ok := bValues[k]
+		if !ok || !equal(aValues[k], bv) {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,287 @@
+// Copyright 2026 LINE Corporation
+//
+// LINE Corporation licenses this file to you under the Apache License,
+// version 2.0 (the "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at:
+//
+//   https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+// WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+// License for the specific language governing permissions and limitations
+// under the License.
+
+/*
+Package jsonpath evaluates JSONPath expressions with the same syntax and semantics as Jayway JsonPath,
+which is used by the Central Dogma server to run JSON_PATH queries. It lets the client evaluate
+queries on the content it already has, e.g. the result of a repository watch or a cached entry:
+
+	content, err := jsonpath.EvalJSON(entry.Content, "$.servers[?(@.zone == 'east')].host")
+
+The following syntax is supported:
+
+	$                  the root object
+	@                  the current object in a filter
+	.name, ['name']    a child member
+	['a','b']          several child members
+	[n], [n,m]         array indexes, negative ones count from the end
+	[start:end]        an array slice
+	*, [*]             all members or elements
+	..                 a deep scan
+	[?(expression)]    a filter with ==, !=, <, <=, >, >=, =~ /regex/, in, nin, &&, || and !
+	.length()          a function at the end of a path; also size(), min(), max(), avg(), sum() and keys()
+
+A definite path, i.e. a path which consists only of member names and single indexes, evaluates to
+the single value it points to and fails with ErrNotFound when there is no such value. The other paths
+evaluate to the list of all the matching values.
+*/
+package jsonpath
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"strings"
+)
+
+// ErrNotFound is returned when a definite path does not match any value.
+var ErrNotFound = errors.New("jsonpath: no results")
+
+// Path is a compiled JSONPath expression.
+type Path struct {
+	expr     string
+	segments []segment
+	function string
+}
+
+// Compile parses the JSONPath expression.
+func Compile(expr string) (*Path, error) {
+	normalized := strings.TrimSpace(expr)
+	if len(normalized) == 0 {
+		return nil, fmt.Errorf("jsonpath: empty path")
+	}
+	if normalized[0] != '$' {
+		// Jayway JsonPath treats "a.b" and "[0]" as "$.a.b" and "$[0]".
+		if normalized[0] == '[' {
+			normalized = "$" + normalized
+		} else {
+			normalized = "$." + normalized
+		}
+	}
+
+	p := &parser{expr: normalized}
+	path, err := p.parsePath(false)
+	if err != nil {
+		return nil, err
+	}
+	if p.pos != len(p.expr) {
+		return nil, p.errorf("unexpected character %q", p.expr[p.pos])
+	}
+	if n := len(path.segments); n > 0 && len(path.function) == 0 {
+		if child, ok := path.segments[n-1].(*childSegment); ok && len(child.names) > 1 {
+			child.merge = true
+		}
+	}
+	path.expr = expr
+	return path, nil
+}
+
+// MustCompile is like Compile but panics if the expression cannot be parsed.
+func MustCompile(expr string) *Path {
+	path, err := Compile(expr)
+	if err != nil {
+		panic(err)
+	}
+	return path
+}
+
+// String returns the expression which the Path was compiled from.
+func (p *Path) String() string {
+	return p.expr
+}
+
+// IsDefinite returns whether the Path evaluates to a single value rather than a list of values.
+func (p *Path) IsDefinite() bool {
+	if len(p.function) != 0 {
+		return true
+	}
+	for _, seg := range p.segments {
+		if !seg.definite() {
+			return false
+		}
+	}
+	return true
+}
+
+// Eval evaluates the Path against the JSON document. The document is a value produced by encoding/json
+// when decoding into an interface{}, i.e. map[string]interface{}, []interface{}, float64, json.Number,
+// string, bool and nil.
+func (p *Path) Eval(doc interface{}) (interface{}, error) {
+	result, found, err := p.eval(doc, doc)
+	if err != nil {
+		return nil, err
+	}
+	if !found {
+		return nil, fmt.Errorf("%w for path: %s", ErrNotFound, p.expr)
+	}
+	return result, nil
+}
+
+// eval returns the result of the Path and whether it is found. An indefinite path is always found.
+func (p *Path) eval(root, current interface{}) (interface{}, bool, error) {
+	nodes := []interface{}{current}
+	for _, seg := range p.segments {
+		var next []interface{}
+		for _, node := range nodes {
+			var err error
+			next, err = seg.apply(root, node, next)
+			if err != nil {
+				return nil, false, err
+			}
+		}
+		nodes = next
+	}
+
+	var result interface{}
+	if p.isDefiniteWithoutFunction() {
+		if len(nodes) == 0 {
+			return nil, false, nil
+		}
+		result = nodes[0]
+	} else {
+		if nodes == nil {
+			nodes = []interface{}{}
+		}
+		result = nodes
+	}
+
+	if len(p.function) != 0 {
+		value, err := applyFunction(p.function, result)
+		if err != nil {
+			return nil, false, err
+		}
+		return value, true, nil
+	}
+	return result, true, nil
+}
+
+func (p *Path) isDefiniteWithoutFunction() bool {
+	for _, seg := range p.segments {
+		if !seg.definite() {
+			return false
+		}
+	}
+	return true
+}
+
+// Eval evaluates the JSONPath expressions against the JSON document in sequence; each expression is
+// evaluated against the result of the previous one, which is how the server evaluates the Expressions
+// of a JSON_PATH query.
+func Eval(doc interface{}, expressions ...string) (interface{}, error) {
+	result := doc
+	for _, expr := range expressions {
+		path, err := Compile(expr)
+		if err != nil {
+			return nil, err
+		}
+		if result, err = path.Eval(result); err != nil {
+			return nil, err
+		}
+	}
+	return result, nil
+}
+
+// EvalJSON is like Eval but the JSON document and the result are encoded in JSON. The order of the
+// object members and the representation of the numbers in the document are kept.
+func EvalJSON(doc []byte, expressions ...string) ([]byte, error) {
+	v, err := decode(doc)
+	if err != nil {
+		return nil, err
+	}
+	result, err := Eval(v, expressions...)
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(result)
+}
+
+func applyFunction(name string, value interface{}) (interface{}, error) {
+	switch name {
+	case "length", "size":
+		switch v := value.(type) {
+		case []interface{}:
+			return float64(len(v)), nil
+		case string:
+			return float64(len(v)), nil
+		}
+		if keys, _, ok := members(value); ok {
+			return float64(len(keys)), nil
+		}
+		return nil, fmt.Errorf("jsonpath: %s() is not applicable to %v", name, describe(value))
+	case "keys":
+		keys, _, ok := members(value)
+		if !ok {
+			return nil, fmt.Errorf("jsonpath: keys() is not applicable to %v", describe(value))
+		}
+		result := make([]interface{}, len(keys))
+		for i, k := range keys {
+			result[i] = k
+		}
+		return result, nil
+	case "min", "max", "avg", "sum":
+		elements, ok := value.([]interface{})
+		if !ok {
+			return nil, fmt.Errorf("jsonpath: %s() is not applicable to %v", name, describe(value))
+		}
+		var numbers []float64
+		for _, e := range elements {
+			if n, ok := toNumber(e); ok {
+				numbers = append(numbers, n)
+			}
+		}
+		if len(numbers) == 0 {
+			return nil, fmt.Errorf("jsonpath: %s() is not applicable to an array without numbers", name)
+		}
+		result := numbers[0]
+		sum := 0.0
+		for _, n := range numbers {
+			sum += n
+			switch name {
+			case "min":
+				result = math.Min(result, n)
+			case "max":
+				result = math.Max(result, n)
+			}
+		}
+		switch name {
+		case "avg":
+			result = sum / float64(len(numbers))
+		case "sum":
+			result = sum
+		}
+		return result, nil
+	}
+	return nil, fmt.Errorf("jsonpath: unknown function: %s()", name)
+}
+
+func describe(v interface{}) string {
+	switch v.(type) {
+	case nil:
+		return "null"
+	case []interface{}:
+		return "an array"
+	case string:
+		return "a string"
+	case bool:
+		return "a boolean"
+	}
+	if _, ok := toNumber(v); ok {
+		return "a number"
+	}
+	if _, _, ok := members(v); ok {
+		return "an object"
+	}
+	return fmt.Sprintf("%T", v)
+}
@@ -0,0 +1,171 @@
+// Copyright 2026 LINE Corporation
+//
+// LINE Corporation licenses this file to you under the Apache License,
+// version 2.0 (the "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at:
+//
+//   https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+// WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+// License for the specific language governing permissions and limitations
+// under the License.
+
+package jsonpath
+
+import (
+	"encoding/json"
+	"errors"
+	"reflect"
+	"testing"
+)
+
+var store = `{
+  "store": {
+    "book": [
+      {"category": "reference", "author": "Nigel Rees", "title": "Sayings of the Century", "price": 8.95},
+      {"category": "fiction", "author": "Evelyn Waugh", "title": "Sword of Honour", "price": 12.99},
+      {"category": "fiction", "author": "Herman Melville", "title": "Moby Dick", "isbn": "0-553-21311-3",
+       "price": 8.99},
+      {"category": "fiction", "author": "J. R. R. Tolkien", "title": "The Lord of the Rings",
+       "isbn": "0-395-19395-8", "price": 22.99}
+    ],
+    "bicycle": {"color": "red", "price": 19.95}
+  },
+  "expensive": 10
+}`
+
+func TestEvalJSON(t *testing.T) {
+	var tests = []struct {
+		expr string
+		want string
+	}{
+		{"$", `{"store":{"book":[{"category":"reference","author":"Nigel Rees","title":"Sayings of the Century","price":8.95},{"category":"fiction","author":"Evelyn Waugh","title":"Sword of Honour","price":12.99},{"category":"fiction","author":"Herman Melville","title":"Moby Dick","isbn":"0-553-21311-3","price":8.99},{"category":"fiction","author":"J. R. R. Tolkien","title":"The Lord of the Rings","isbn":"0-395-19395-8","price":22.99}],"bicycle":{"color":"red","price":19.95}},"expensive":10}`},
+		{"$.expensive", `10`},
+		{"expensive", `10`},
+		{"$.store.bicycle", `{"color":"red","price":19.95}`},
+		{"$['store']['bicycle'].color", `"red"`},
+		{`$["store"].bicycle['color', 'price']`, `{"color":"red","price":19.95}`},
+		{"$.store.book[0].author", `"Nigel Rees"`},
+		{"$.store.book[-1].author", `"J. R. R. Tolkien"`},
+		{"$.store.book[*].author", `["Nigel Rees","Evelyn Waugh","Herman Melville","J. R. R. Tolkien"]`},
+		{"$.store.book[0,1].price", `[8.95,12.99]`},
+		{"$.store.book[:2].price", `[8.95,12.99]`},
+		{"$.store.book[1:2].price", `[12.99]`},
+		{"$.store.book[-2:].price", `[8.99,22.99]`},
+		{"$.store.book[2:10].price", `[8.99,22.99]`},
+		{"$..author", `["Nigel Rees","Evelyn Waugh","Herman Melville","J. R. R. Tolkien"]`},
+		{"$.store.*", `[[{"category":"reference","author":"Nigel Rees","title":"Sayings of the Century","price":8.95},{"category":"fiction","author":"Evelyn Waugh","title":"Sword of Honour","price":12.99},{"category":"fiction","author":"Herman Melville","title":"Moby Dick","isbn":"0-553-21311-3","price":8.99},{"category":"fiction","author":"J. R. R. Tolkien","title":"The Lord of the Rings","isbn":"0-395-19395-8","price":22.99}],{"color":"red","price":19.95}]`},
+		{"$.store..price", `[8.95,12.99,8.99,22.99,19.95]`},
+		{"$..book[2].title", `["Moby Dick"]`},
+		{"$..book[?(@.isbn)].title", `["Moby Dick","The Lord of the Rings"]`},
+		{"$..book[?(!@.isbn)].title", `["Sayings of the Century","Sword of Honour"]`},
+		{"$.store.book[?(@.price < 10)].title", `["Sayings of the Century","Moby Dick"]`},
+		{"$.store.book[?(@.price <= $.expensive)].price", `[8.95,8.99]`},
+		{"$.store.book[?(@.price > 10 && @.category == 'fiction')].title", `["Sword of Honour","The Lord of the Rings"]`},
+		{"$.store.book[?(@.price > 20 || @.category == \"reference\")].title", `["Sayings of the Century","The Lord of the Rings"]`},
+		{"$.store.book[?(!(@.price > 10) && @.isbn)].title", `["Moby Dick"]`},
+		{"$.store.book[?(@.author =~ /.*REES/i)].author", `["Nigel Rees"]`},
+		{"$.store.book[?(@.author =~ /Rees/)].author", `[]`},
+		{"$.store.book[?(@.category in ['reference', 'poetry'])].title", `["Sayings of the Century"]`},
+		{"$.store.book[?(@.category nin ['reference'])].price", `[12.99,8.99,22.99]`},
+		{"$.store.book[?(@.isbn != '0-553-21311-3')].price", `[8.95,12.99,22.99]`},
+		{"$..[?(@.color)].price", `[19.95]`},
+		{"$..*.color", `["red"]`},
+		{"$.store.book.length()", `4`},
+		{"$.store.book[*].price.max()", `22.99`},
+		{"$.store.bicycle.keys()", `["color","price"]`},
+		{"$.store.book[?(@.title.length() > 15)].price", `[8.95,22.99]`},
+		{"$.store.book[?(@.missing == 1)]", `[]`},
+		{"$.store.bicycle[?(@.color == 'red')].price", `[19.95]`},
+	}
+
+	for _, test := range tests {
+		got, err := EvalJSON([]byte(store), test.expr)
+		if err != nil {
+			t.Errorf("EvalJSON(%q) failed: %v", test.expr, err)
+			continue
+		}
+		if string(got) != test.want {
+			t.Errorf("EvalJSON(%q) returned %s, want %s", test.expr, got, test.want)
+		}
+	}
+}
+
+func TestEvalJSON_Sequence(t *testing.T) {
+	got, err := EvalJSON([]byte(store), "$.store.book[?(@.isbn)]", "$[0].title")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(got) != `"Moby Dick"` {
+		t.Errorf("EvalJSON returned %s, want %s", got, `"Moby Dick"`)
+	}
+}
+
+func TestEvalJSON_NotFound(t *testing.T) {
+	exprs := []string{"$.missing", "$.store.book[10]", "$.store.bicycle.color.name", "$.store.book.title"}
+	for _, expr := range exprs {
+		if _, err := EvalJSON([]byte(store), expr); !errors.Is(err, ErrNotFound) {
+			t.Errorf("EvalJSON(%q) returned %v, want %v", expr, err, ErrNotFound)
+		}
+	}
+}
+
+func TestCompile_Invalid(t *testing.T) {
+	exprs := []string{"", "$.", "$[", "$['a'", "$[?(@.a == )]", "$[?(@.a == 1]", "$[a]", "$[1:2:3]",
+		"$.a.length().b", "$[?(@.a =~ /(/)]", "$[?('a')]"}
+	for _, expr := range exprs {
+		if _, err := Compile(expr); err == nil {
+			t.Errorf("Compile(%q) should fail", expr)
+		}
+	}
+}
+
+func TestPath_IsDefinite(t *testing.T) {
+	var tests = []struct {
+		expr string
+		want bool
+	}{
+		{"$", true},
+		{"$.a.b[0]['c']", true},
+		{"$.a.length()", true},
+		{"$.a[*]", false},
+		{"$..a", false},
+		{"$.a[0,1]", false},
+		{"$.a[1:]", false},
+		{"$.a[?(@.b)]", false},
+		{"$['a','b']", true},
+		{"$['a','b'].c", false},
+	}
+	for _, test := range tests {
+		if got := MustCompile(test.expr).IsDefinite(); got != test.want {
+			t.Errorf("IsDefinite(%q) returned %v, want %v", test.expr, got, test.want)
+		}
+	}
+}
+
+func TestEval(t *testing.T) {
+	var doc interface{}
+	if err := json.Unmarshal([]byte(`{"b":{"y":2,"x":1},"a":[1,2,3]}`), &doc); err != nil {
+		t.Fatal(err)
+	}
+
+	got, err := Eval(doc, "$.*")
+	if err != nil {
+		t.Fatal(err)
+	}
+	// The members of a map are iterated in the order of the keys.
+	want := []interface{}{[]interface{}{1.0, 2.0, 3.0}, map[string]interface{}{"x": 1.0, "y": 2.0}}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Eval returned %+v, want %+v", got, want)
+	}
+
+	got, err = Eval(doc, "$.b['x','z']")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(got, map[string]interface{}{"x": 1.0}) {
+		t.Errorf("Eval returned %+v, want %+v", got, map[string]interface{}{"x": 1.0})
+	}
+}
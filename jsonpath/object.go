@@ -0,0 +1,141 @@
+// Copyright 2026 LINE Corporation
+//
+// LINE Corporation licenses this file to you under the Apache License,
+// version 2.0 (the "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at:
+//
+//   https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+// WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+// License for the specific language governing permissions and limitations
+// under the License.
+
+package jsonpath
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+)
+
+// object is a JSON object which keeps the order of its members so that wildcards and deep scans
+// return the values in the same order as the server does.
+type object struct {
+	keys   []string
+	values map[string]interface{}
+}
+
+func newObject() *object {
+	return &object{values: make(map[string]interface{})}
+}
+
+func (o *object) set(key string, value interface{}) {
+	if _, ok := o.values[key]; !ok {
+		o.keys = append(o.keys, key)
+	}
+	o.values[key] = value
+}
+
+func (o *object) MarshalJSON() ([]byte, error) {
+	var buf bytes.Buffer
+	buf.WriteByte('{')
+	for i, key := range o.keys {
+		if i > 0 {
+			buf.WriteByte(',')
+		}
+		k, err := json.Marshal(key)
+		if err != nil {
+			return nil, err
+		}
+		buf.Write(k)
+		buf.WriteByte(':')
+		v, err := json.Marshal(o.values[key])
+		if err != nil {
+			return nil, err
+		}
+		buf.Write(v)
+	}
+	buf.WriteByte('}')
+	return buf.Bytes(), nil
+}
+
+// members returns the keys and values of a JSON object in order. A map is iterated in the order
+// of its sorted keys.
+func members(v interface{}) (keys []string, values map[string]interface{}, ok bool) {
+	switch o := v.(type) {
+	case *object:
+		return o.keys, o.values, true
+	case map[string]interface{}:
+		keys = make([]string, 0, len(o))
+		for k := range o {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+		return keys, o, true
+	}
+	return nil, nil, false
+}
+
+// decode decodes the JSON document keeping the order of the object members and the representation
+// of the numbers.
+func decode(data []byte) (interface{}, error) {
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.UseNumber()
+	v, err := decodeValue(dec)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := dec.Token(); err != io.EOF {
+		return nil, fmt.Errorf("jsonpath: invalid JSON document: unexpected data after the top-level value")
+	}
+	return v, nil
+}
+
+func decodeValue(dec *json.Decoder) (interface{}, error) {
+	tok, err := dec.Token()
+	if err != nil {
+		return nil, fmt.Errorf("jsonpath: invalid JSON document: %v", err)
+	}
+	switch t := tok.(type) {
+	case json.Delim:
+		switch t {
+		case '{':
+			o := newObject()
+			for dec.More() {
+				keyTok, err := dec.Token()
+				if err != nil {
+					return nil, fmt.Errorf("jsonpath: invalid JSON document: %v", err)
+				}
+				value, err := decodeValue(dec)
+				if err != nil {
+					return nil, err
+				}
+				o.set(keyTok.(string), value)
+			}
+			if _, err := dec.Token(); err != nil { // '}'
+				return nil, fmt.Errorf("jsonpath: invalid JSON document: %v", err)
+			}
+			return o, nil
+		case '[':
+			a := []interface{}{}
+			for dec.More() {
+				value, err := decodeValue(dec)
+				if err != nil {
+					return nil, err
+				}
+				a = append(a, value)
+			}
+			if _, err := dec.Token(); err != nil { // ']'
+				return nil, fmt.Errorf("jsonpath: invalid JSON document: %v", err)
+			}
+			return a, nil
+		}
+		return nil, fmt.Errorf("jsonpath: invalid JSON document: unexpected %v", t)
+	default:
+		return t, nil
+	}
+}
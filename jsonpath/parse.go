@@ -0,0 +1,490 @@
+// Copyright 2026 LINE Corporation
+//
+// LINE Corporation licenses this file to you under the Apache License,
+// version 2.0 (the "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at:
+//
+//   https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+// WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+// License for the specific language governing permissions and limitations
+// under the License.
+
+package jsonpath
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+type parser struct {
+	expr string
+	pos  int
+}
+
+func (p *parser) errorf(format string, args ...interface{}) error {
+	return fmt.Errorf("jsonpath: invalid path %q: %s at position %d", p.expr, fmt.Sprintf(format, args...), p.pos)
+}
+
+func (p *parser) eof() bool {
+	return p.pos >= len(p.expr)
+}
+
+func (p *parser) peek() byte {
+	if p.eof() {
+		return 0
+	}
+	return p.expr[p.pos]
+}
+
+func (p *parser) hasPrefix(prefix string) bool {
+	return strings.HasPrefix(p.expr[p.pos:], prefix)
+}
+
+func (p *parser) consume(prefix string) bool {
+	if p.hasPrefix(prefix) {
+		p.pos += len(prefix)
+		return true
+	}
+	return false
+}
+
+func (p *parser) skipSpaces() {
+	for !p.eof() && isSpace(p.expr[p.pos]) {
+		p.pos++
+	}
+}
+
+func isSpace(c byte) bool {
+	return c == ' ' || c == '\t' || c == '\n' || c == '\r'
+}
+
+// parsePath parses a path which starts with '$' or, in a filter, '@'. A path in a filter ends at
+// the first character which cannot continue it, such as a space or an operator.
+func (p *parser) parsePath(inFilter bool) (*Path, error) {
+	path := &Path{}
+	if !p.consume("$") && !(inFilter && p.consume("@")) {
+		return nil, p.errorf("a path should start with '$'")
+	}
+
+	for !p.eof() {
+		switch {
+		case p.consume(".."):
+			seg, err := p.parseDeepScanTarget(inFilter)
+			if err != nil {
+				return nil, err
+			}
+			path.segments = append(path.segments, &deepScanSegment{target: seg})
+		case p.consume("."):
+			if p.consume("*") {
+				path.segments = append(path.segments, &wildcardSegment{})
+				continue
+			}
+			name := p.readName(inFilter)
+			if len(name) == 0 {
+				return nil, p.errorf("a member name is expected")
+			}
+			if p.consume("(") {
+				if !p.consume(")") {
+					return nil, p.errorf("functions with arguments are not supported")
+				}
+				path.function = name
+				if !p.eof() && (p.peek() == '.' || p.peek() == '[') {
+					return nil, p.errorf("a function should be at the end of a path")
+				}
+				return path, nil
+			}
+			path.segments = append(path.segments, &childSegment{names: []string{name}})
+		case p.peek() == '[':
+			seg, err := p.parseBracket()
+			if err != nil {
+				return nil, err
+			}
+			path.segments = append(path.segments, seg)
+		default:
+			if inFilter {
+				return path, nil
+			}
+			return nil, p.errorf("unexpected character %q", p.peek())
+		}
+	}
+	return path, nil
+}
+
+func (p *parser) parseDeepScanTarget(inFilter bool) (segment, error) {
+	if p.consume("*") {
+		return &wildcardSegment{}, nil
+	}
+	if p.peek() == '[' {
+		return p.parseBracket()
+	}
+	name := p.readName(inFilter)
+	if len(name) == 0 {
+		return nil, p.errorf("a member name is expected after '..'")
+	}
+	return &childSegment{names: []string{name}}, nil
+}
+
+func (p *parser) readName(inFilter bool) string {
+	start := p.pos
+	for !p.eof() {
+		c := p.expr[p.pos]
+		if c == '.' || c == '[' || c == '(' {
+			break
+		}
+		if inFilter && (isSpace(c) || strings.IndexByte(")=!<>&|,~", c) >= 0) {
+			break
+		}
+		p.pos++
+	}
+	return p.expr[start:p.pos]
+}
+
+func (p *parser) parseBracket() (segment, error) {
+	p.consume("[")
+	p.skipSpaces()
+
+	var seg segment
+	switch c := p.peek(); {
+	case c == '*':
+		p.pos++
+		seg = &wildcardSegment{}
+	case c == '\'' || c == '"':
+		var names []string
+		for {
+			name, err := p.readQuoted()
+			if err != nil {
+				return nil, err
+			}
+			names = append(names, name)
+			p.skipSpaces()
+			if !p.consume(",") {
+				break
+			}
+			p.skipSpaces()
+		}
+		seg = &childSegment{names: names}
+	case c == '?':
+		p.pos++
+		p.skipSpaces()
+		if !p.consume("(") {
+			return nil, p.errorf("'(' is expected after '?'")
+		}
+		filter, err := p.parseOr()
+		if err != nil {
+			return nil, err
+		}
+		p.skipSpaces()
+		if !p.consume(")") {
+			return nil, p.errorf("')' is expected at the end of a filter")
+		}
+		seg = &filterSegment{filter: filter}
+	default:
+		end := strings.IndexByte(p.expr[p.pos:], ']')
+		if end < 0 {
+			return nil, p.errorf("']' is expected")
+		}
+		content := strings.TrimSpace(p.expr[p.pos : p.pos+end])
+		var err error
+		if strings.Contains(content, ":") {
+			seg, err = parseSlice(content)
+		} else {
+			seg, err = parseIndexes(content)
+		}
+		if err != nil {
+			return nil, p.errorf("%v", err)
+		}
+		p.pos += end
+	}
+
+	p.skipSpaces()
+	if !p.consume("]") {
+		return nil, p.errorf("']' is expected")
+	}
+	return seg, nil
+}
+
+func parseSlice(content string) (segment, error) {
+	parts := strings.Split(content, ":")
+	if len(parts) != 2 {
+		return nil, fmt.Errorf("invalid array slice: %s", content)
+	}
+	seg := &sliceSegment{}
+	if s := strings.TrimSpace(parts[0]); len(s) != 0 {
+		start, err := strconv.Atoi(s)
+		if err != nil {
+			return nil, fmt.Errorf("invalid array slice: %s", content)
+		}
+		seg.start, seg.hasStart = start, true
+	}
+	if s := strings.TrimSpace(parts[1]); len(s) != 0 {
+		end, err := strconv.Atoi(s)
+		if err != nil {
+			return nil, fmt.Errorf("invalid array slice: %s", content)
+		}
+		seg.end, seg.hasEnd = end, true
+	}
+	return seg, nil
+}
+
+func parseIndexes(content string) (segment, error) {
+	var indexes []int
+	for _, s := range strings.Split(content, ",") {
+		index, err := strconv.Atoi(strings.TrimSpace(s))
+		if err != nil {
+			return nil, fmt.Errorf("invalid array index: %s", content)
+		}
+		indexes = append(indexes, index)
+	}
+	return &indexSegment{indexes: indexes}, nil
+}
+
+// readQuoted reads a string enclosed in single or double quotes.
+func (p *parser) readQuoted() (string, error) {
+	quote := p.peek()
+	p.pos++
+	var sb strings.Builder
+	for !p.eof() {
+		c := p.expr[p.pos]
+		p.pos++
+		switch c {
+		case quote:
+			return sb.String(), nil
+		case '\\':
+			if p.eof() {
+				break
+			}
+			escaped := p.expr[p.pos]
+			p.pos++
+			switch escaped {
+			case 'n':
+				sb.WriteByte('\n')
+			case 't':
+				sb.WriteByte('\t')
+			case 'r':
+				sb.WriteByte('\r')
+			default:
+				sb.WriteByte(escaped)
+			}
+		default:
+			sb.WriteByte(c)
+		}
+	}
+	return "", p.errorf("unterminated string")
+}
+
+// parseOr parses the expression of a filter:
+//
+//	or      := and ('||' and)*
+//	and     := unary ('&&' unary)*
+//	unary   := '!' unary | '(' or ')' | operand (operator operand)?
+func (p *parser) parseOr() (filterExpr, error) {
+	left, err := p.parseAnd()
+	if err != nil {
+		return nil, err
+	}
+	for {
+		p.skipSpaces()
+		if !p.consume("||") {
+			return left, nil
+		}
+		right, err := p.parseAnd()
+		if err != nil {
+			return nil, err
+		}
+		left = &orExpr{left: left, right: right}
+	}
+}
+
+func (p *parser) parseAnd() (filterExpr, error) {
+	left, err := p.parseUnary()
+	if err != nil {
+		return nil, err
+	}
+	for {
+		p.skipSpaces()
+		if !p.consume("&&") {
+			return left, nil
+		}
+		right, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		left = &andExpr{left: left, right: right}
+	}
+}
+
+func (p *parser) parseUnary() (filterExpr, error) {
+	p.skipSpaces()
+	if p.peek() == '!' && !p.hasPrefix("!=") {
+		p.pos++
+		expr, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		return &notExpr{expr: expr}, nil
+	}
+	if p.consume("(") {
+		expr, err := p.parseOr()
+		if err != nil {
+			return nil, err
+		}
+		p.skipSpaces()
+		if !p.consume(")") {
+			return nil, p.errorf("')' is expected")
+		}
+		return expr, nil
+	}
+
+	left, err := p.parseOperand()
+	if err != nil {
+		return nil, err
+	}
+	p.skipSpaces()
+	op := p.readOperator()
+	if len(op) == 0 {
+		if _, ok := left.(*pathOperand); !ok {
+			return nil, p.errorf("an operator is expected")
+		}
+		return &existsExpr{operand: left}, nil
+	}
+	p.skipSpaces()
+	right, err := p.parseOperand()
+	if err != nil {
+		return nil, err
+	}
+	return &compareExpr{op: op, left: left, right: right}, nil
+}
+
+var operators = []string{"==", "!=", "<=", ">=", "=~", "<", ">", "nin", "in"}
+
+func (p *parser) readOperator() string {
+	for _, op := range operators {
+		if !p.hasPrefix(op) {
+			continue
+		}
+		if op == "in" || op == "nin" {
+			// a keyword operator should be followed by a space or an array
+			next := p.pos + len(op)
+			if next < len(p.expr) && !isSpace(p.expr[next]) && p.expr[next] != '[' {
+				continue
+			}
+		}
+		p.pos += len(op)
+		return op
+	}
+	return ""
+}
+
+func (p *parser) parseOperand() (operand, error) {
+	p.skipSpaces()
+	switch c := p.peek(); {
+	case c == '@' || c == '$':
+		path, err := p.parsePath(true)
+		if err != nil {
+			return nil, err
+		}
+		return &pathOperand{path: path, relative: c == '@'}, nil
+	case c == '\'' || c == '"':
+		s, err := p.readQuoted()
+		if err != nil {
+			return nil, err
+		}
+		return &valueOperand{value: s}, nil
+	case c == '/':
+		return p.parseRegex()
+	case c == '[':
+		return p.parseArray()
+	case c == '-' || (c >= '0' && c <= '9'):
+		start := p.pos
+		p.pos++
+		for !p.eof() && strings.IndexByte("0123456789.eE+-", p.peek()) >= 0 {
+			p.pos++
+		}
+		n, err := strconv.ParseFloat(p.expr[start:p.pos], 64)
+		if err != nil {
+			return nil, p.errorf("invalid number: %s", p.expr[start:p.pos])
+		}
+		return &valueOperand{value: n}, nil
+	case p.consume("true"):
+		return &valueOperand{value: true}, nil
+	case p.consume("false"):
+		return &valueOperand{value: false}, nil
+	case p.consume("null"):
+		return &valueOperand{value: nil}, nil
+	}
+	return nil, p.errorf("an operand is expected")
+}
+
+func (p *parser) parseRegex() (operand, error) {
+	p.pos++ // '/'
+	var sb strings.Builder
+	for {
+		if p.eof() {
+			return nil, p.errorf("unterminated regular expression")
+		}
+		c := p.expr[p.pos]
+		p.pos++
+		if c == '/' {
+			break
+		}
+		if c == '\\' && p.peek() == '/' {
+			c = '/'
+			p.pos++
+		} else if c == '\\' && !p.eof() {
+			sb.WriteByte(c)
+			c = p.expr[p.pos]
+			p.pos++
+		}
+		sb.WriteByte(c)
+	}
+
+	flags := ""
+	for !p.eof() && strings.IndexByte("imsx", p.peek()) >= 0 {
+		if p.peek() != 'x' {
+			flags += string(p.peek())
+		}
+		p.pos++
+	}
+	pattern := sb.String()
+	if len(flags) != 0 {
+		pattern = "(?" + flags + ")" + pattern
+	}
+	// Jayway JsonPath requires the whole string to match.
+	re, err := regexp.Compile("^(?:" + pattern + ")$")
+	if err != nil {
+		return nil, p.errorf("invalid regular expression: %v", err)
+	}
+	return &regexOperand{re: re}, nil
+}
+
+func (p *parser) parseArray() (operand, error) {
+	p.pos++ // '['
+	values := []interface{}{}
+	p.skipSpaces()
+	if p.consume("]") {
+		return &valueOperand{value: values}, nil
+	}
+	for {
+		elem, err := p.parseOperand()
+		if err != nil {
+			return nil, err
+		}
+		v, ok := elem.(*valueOperand)
+		if !ok {
+			return nil, p.errorf("an array should consist of literals")
+		}
+		values = append(values, v.value)
+		p.skipSpaces()
+		if p.consume("]") {
+			return &valueOperand{value: values}, nil
+		}
+		if !p.consume(",") {
+			return nil, p.errorf("',' or ']' is expected")
+		}
+	}
+}
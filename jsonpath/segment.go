@@ -0,0 +1,230 @@
+// Copyright 2026 LINE Corporation
+//
+// LINE Corporation licenses this file to you under the Apache License,
+// version 2.0 (the "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at:
+//
+//   https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+// WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+// License for the specific language governing permissions and limitations
+// under the License.
+
+package jsonpath
+
+// segment is a step of a Path which selects values from a node.
+type segment interface {
+	// apply appends the values selected from the node to the result.
+	apply(root, node interface{}, result []interface{}) ([]interface{}, error)
+	definite() bool
+}
+
+// childSegment selects the members of an object by their names.
+type childSegment struct {
+	names []string
+	// merge is true if the segment selects several members at the end of a path. The members are
+	// merged into an object rather than listed.
+	merge bool
+}
+
+func (s *childSegment) apply(root, node interface{}, result []interface{}) ([]interface{}, error) {
+	_, values, ok := members(node)
+	if !ok {
+		return result, nil
+	}
+	if s.merge {
+		var merged interface{}
+		if _, isObject := node.(*object); isObject {
+			o := newObject()
+			for _, name := range s.names {
+				if v, ok := values[name]; ok {
+					o.set(name, v)
+				}
+			}
+			merged = o
+		} else {
+			m := make(map[string]interface{})
+			for _, name := range s.names {
+				if v, ok := values[name]; ok {
+					m[name] = v
+				}
+			}
+			merged = m
+		}
+		return append(result, merged), nil
+	}
+	for _, name := range s.names {
+		if v, ok := values[name]; ok {
+			result = append(result, v)
+		}
+	}
+	return result, nil
+}
+
+func (s *childSegment) definite() bool {
+	return len(s.names) == 1 || s.merge
+}
+
+// indexSegment selects the elements of an array by their indexes.
+type indexSegment struct {
+	indexes []int
+}
+
+func (s *indexSegment) apply(root, node interface{}, result []interface{}) ([]interface{}, error) {
+	elements, ok := node.([]interface{})
+	if !ok {
+		return result, nil
+	}
+	for _, index := range s.indexes {
+		if index < 0 {
+			index += len(elements)
+		}
+		if index >= 0 && index < len(elements) {
+			result = append(result, elements[index])
+		}
+	}
+	return result, nil
+}
+
+func (s *indexSegment) definite() bool {
+	return len(s.indexes) == 1
+}
+
+// sliceSegment selects the elements of an array in the range of [start, end).
+type sliceSegment struct {
+	start, end       int
+	hasStart, hasEnd bool
+}
+
+func (s *sliceSegment) apply(root, node interface{}, result []interface{}) ([]interface{}, error) {
+	elements, ok := node.([]interface{})
+	if !ok {
+		return result, nil
+	}
+	start, end := 0, len(elements)
+	if s.hasStart {
+		start = clamp(s.start, len(elements))
+	}
+	if s.hasEnd {
+		end = clamp(s.end, len(elements))
+	}
+	for i := start; i < end; i++ {
+		result = append(result, elements[i])
+	}
+	return result, nil
+}
+
+func (s *sliceSegment) definite() bool {
+	return false
+}
+
+func clamp(index, length int) int {
+	if index < 0 {
+		index += length
+		if index < 0 {
+			return 0
+		}
+	}
+	if index > length {
+		return length
+	}
+	return index
+}
+
+// wildcardSegment selects all the members of an object or all the elements of an array.
+type wildcardSegment struct{}
+
+func (s *wildcardSegment) apply(root, node interface{}, result []interface{}) ([]interface{}, error) {
+	if elements, ok := node.([]interface{}); ok {
+		return append(result, elements...), nil
+	}
+	if keys, values, ok := members(node); ok {
+		for _, k := range keys {
+			result = append(result, values[k])
+		}
+	}
+	return result, nil
+}
+
+func (s *wildcardSegment) definite() bool {
+	return false
+}
+
+// filterSegment selects the elements of an array which match the filter. An object is selected
+// if the object itself matches the filter.
+type filterSegment struct {
+	filter filterExpr
+}
+
+func (s *filterSegment) apply(root, node interface{}, result []interface{}) ([]interface{}, error) {
+	if elements, ok := node.([]interface{}); ok {
+		for _, e := range elements {
+			matched, err := s.filter.match(root, e)
+			if err != nil {
+				return nil, err
+			}
+			if matched {
+				result = append(result, e)
+			}
+		}
+		return result, nil
+	}
+	if _, _, ok := members(node); ok {
+		matched, err := s.filter.match(root, node)
+		if err != nil {
+			return nil, err
+		}
+		if matched {
+			result = append(result, node)
+		}
+	}
+	return result, nil
+}
+
+func (s *filterSegment) definite() bool {
+	return false
+}
+
+// deepScanSegment applies the target segment to the node and all of its descendants in document order.
+type deepScanSegment struct {
+	target segment
+}
+
+func (s *deepScanSegment) apply(root, node interface{}, result []interface{}) ([]interface{}, error) {
+	var err error
+	if filter, ok := s.target.(*filterSegment); ok {
+		// A filter in a deep scan selects the objects which match the filter.
+		if _, _, isObject := members(node); isObject {
+			matched, err := filter.filter.match(root, node)
+			if err != nil {
+				return nil, err
+			}
+			if matched {
+				result = append(result, node)
+			}
+		}
+	} else if result, err = s.target.apply(root, node, result); err != nil {
+		return nil, err
+	}
+
+	if elements, ok := node.([]interface{}); ok {
+		for _, e := range elements {
+			if result, err = s.apply(root, e, result); err != nil {
+				return nil, err
+			}
+		}
+	} else if keys, values, ok := members(node); ok {
+		for _, k := range keys {
+			if result, err = s.apply(root, values[k], result); err != nil {
+				return nil, err
+			}
+		}
+	}
+	return result, nil
+}
+
+func (s *deepScanSegment) definite() bool {
+	return false
+}
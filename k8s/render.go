@@ -0,0 +1,226 @@
+// Copyright 2026 LINE Corporation
+//
+// LINE Corporation licenses this file to you under the Apache License,
+// version 2.0 (the "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at:
+//
+//   https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+// WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+// License for the specific language governing permissions and limitations
+// under the License.
+
+// Package k8s renders the files of a repository as a Kubernetes ConfigMap or Secret manifest, which can be
+// applied without a connection to Central Dogma from the cluster. For example:
+//
+//	resource, _, err := k8s.Build(ctx, client, "foo", "bar", "-1", "/conf/**", &k8s.Options{Namespace: "app"})
+//	if err != nil {
+//	    panic(err)
+//	}
+//	resource.WriteYAML(os.Stdout)
+//
+// prints a ConfigMap named "foo-bar-conf" whose keys are the base names of the files, with the annotations
+// which record the project, the repository, the path pattern and the revision of the files.
+package k8s
+
+import (
+	"context"
+	"fmt"
+	"path"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+
+	"go.linecorp.com/centraldogma"
+)
+
+// The kinds of the resources.
+const (
+	KindConfigMap = "ConfigMap"
+	KindSecret    = "Secret"
+)
+
+// The annotations which record where the files of a resource come from.
+const (
+	AnnotationProject     = "centraldogma.linecorp.com/project"
+	AnnotationRepository  = "centraldogma.linecorp.com/repository"
+	AnnotationPathPattern = "centraldogma.linecorp.com/path-pattern"
+	AnnotationRevision    = "centraldogma.linecorp.com/revision"
+)
+
+// maxNameLength is the maximum length of the name of a resource, which is a DNS subdomain.
+const maxNameLength = 253
+
+// KeyRule specifies how the paths of the files are mapped to the keys of a resource. The characters which
+// cannot be used in a key are replaced with "_".
+type KeyRule int
+
+const (
+	// KeyBaseName uses the base names of the files, e.g. "a.json" for /conf/a.json.
+	KeyBaseName KeyRule = iota
+	// KeyPath uses the paths of the files without the leading "/", and with the other "/"s replaced with
+	// "__", e.g. "conf__a.json" for /conf/a.json.
+	KeyPath
+)
+
+// ParseKeyRule parses the key rule, i.e. "basename" or "path".
+func ParseKeyRule(rule string) (KeyRule, error) {
+	switch strings.ToLower(rule) {
+	case "basename":
+		return KeyBaseName, nil
+	case "path":
+		return KeyPath, nil
+	default:
+		return 0, fmt.Errorf("unknown key rule: %q (expected basename or path)", rule)
+	}
+}
+
+// Options specifies how a resource is built.
+type Options struct {
+	// Kind is KindConfigMap or KindSecret. KindConfigMap is used if empty.
+	Kind string
+	// Name is the name of the resource. If empty, the project, the repository and the directories of the path
+	// pattern are joined with "-", e.g. "foo-bar-conf" for /conf/** of the repository bar in the project foo.
+	Name      string
+	Namespace string
+	Labels    map[string]string
+	KeyRule   KeyRule
+}
+
+// Resource is a ConfigMap or a Secret.
+type Resource struct {
+	Kind        string
+	Name        string
+	Namespace   string
+	Labels      map[string]string
+	Annotations map[string]string
+	// Data is the contents of the files keyed by the keys mapped from their paths.
+	Data map[string][]byte
+}
+
+// Build fetches the files which match the path pattern at the revision, and returns the resource which
+// contains them. The revision is normalized first, so that the absolute revision is recorded.
+func Build(ctx context.Context, reader centraldogma.ContentReader, projectName, repoName, revision,
+	pathPattern string, opts *Options) (resource *Resource, httpStatusCode int, err error) {
+	if opts == nil {
+		opts = &Options{}
+	}
+	if len(revision) == 0 {
+		revision = "-1"
+	}
+	if len(pathPattern) == 0 {
+		pathPattern = "/**"
+	}
+	kind := opts.Kind
+	if len(kind) == 0 {
+		kind = KindConfigMap
+	}
+	if kind != KindConfigMap && kind != KindSecret {
+		return nil, centraldogma.UnknownHttpStatusCode,
+			fmt.Errorf("unknown kind: %q (expected %s or %s)", kind, KindConfigMap, KindSecret)
+	}
+
+	rev, httpStatusCode, err := reader.NormalizeRevision(ctx, projectName, repoName, revision)
+	if err != nil {
+		return nil, httpStatusCode, err
+	}
+	entries, httpStatusCode, err := reader.GetFiles(ctx, projectName, repoName, strconv.Itoa(rev), pathPattern)
+	if err != nil {
+		return nil, httpStatusCode, err
+	}
+
+	name := opts.Name
+	if len(name) == 0 {
+		name = defaultName(projectName, repoName, pathPattern)
+	}
+	resource = &Resource{
+		Kind:      kind,
+		Name:      name,
+		Namespace: opts.Namespace,
+		Labels:    opts.Labels,
+		Annotations: map[string]string{
+			AnnotationProject:     projectName,
+			AnnotationRepository:  repoName,
+			AnnotationPathPattern: pathPattern,
+			AnnotationRevision:    strconv.Itoa(rev),
+		},
+		Data: make(map[string][]byte),
+	}
+	paths := make(map[string]string)
+	for _, entry := range entries {
+		if entry.Type == centraldogma.Directory {
+			continue
+		}
+		key := mapKey(entry.Path, opts.KeyRule)
+		if p, ok := paths[key]; ok {
+			return nil, centraldogma.UnknownHttpStatusCode,
+				fmt.Errorf("%s and %s are mapped to the same key %q", p, entry.Path, key)
+		}
+		paths[key] = entry.Path
+		resource.Data[key] = entry.Content
+	}
+	return resource, httpStatusCode, nil
+}
+
+func mapKey(p string, rule KeyRule) string {
+	key := path.Base(p)
+	if rule == KeyPath {
+		key = strings.ReplaceAll(strings.TrimPrefix(p, "/"), "/", "__")
+	}
+	return strings.Map(func(r rune) rune {
+		if r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z' || r >= '0' && r <= '9' || r == '-' || r == '_' || r == '.' {
+			return r
+		}
+		return '_'
+	}, key)
+}
+
+// defaultName joins the project, the repository and the directories of the path pattern before the first
+// glob, and converts them into a DNS subdomain.
+func defaultName(projectName, repoName, pathPattern string) string {
+	parts := []string{projectName, repoName}
+	pattern := strings.Split(pathPattern, ",")[0]
+	for _, s := range strings.Split(strings.Trim(pattern, "/"), "/") {
+		if len(s) == 0 || strings.ContainsAny(s, "*?[{") {
+			break
+		}
+		parts = append(parts, s)
+	}
+	return sanitizeName(strings.Join(parts, "-"))
+}
+
+// sanitizeName converts the name into a DNS subdomain, i.e. lower case alphanumeric characters, "-" and ".",
+// which starts and ends with an alphanumeric character.
+func sanitizeName(name string) string {
+	var b strings.Builder
+	for _, r := range strings.ToLower(name) {
+		if r >= 'a' && r <= 'z' || r >= '0' && r <= '9' || r == '.' {
+			b.WriteRune(r)
+		} else if !strings.HasSuffix(b.String(), "-") {
+			b.WriteRune('-')
+		}
+	}
+	s := b.String()
+	if len(s) > maxNameLength {
+		s = s[:maxNameLength]
+	}
+	return strings.Trim(s, "-.")
+}
+
+// sortedKeys returns the keys of the map in order.
+func sortedKeys(m map[string]string) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// isText returns whether the content can be written in the data of a ConfigMap rather than its binaryData.
+func isText(content []byte) bool {
+	return utf8.Valid(content)
+}
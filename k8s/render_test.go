@@ -0,0 +1,131 @@
+// Copyright 2026 LINE Corporation
+//
+// LINE Corporation licenses this file to you under the Apache License,
+// version 2.0 (the "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at:
+//
+//   https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+// WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+// License for the specific language governing permissions and limitations
+// under the License.
+
+package k8s_test
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	"go.linecorp.com/centraldogma"
+	"go.linecorp.com/centraldogma/dogmamock"
+	"go.linecorp.com/centraldogma/k8s"
+)
+
+func newClient() *dogmamock.Client {
+	client := dogmamock.New()
+	client.Return("NormalizeRevision", 3)
+	client.Return("GetFiles", []*centraldogma.Entry{
+		{Path: "/conf", Type: centraldogma.Directory},
+		{Path: "/conf/a.json", Type: centraldogma.JSON, Content: centraldogma.EntryContent(`{"a":1}`)},
+		{Path: "/conf/sub/b.txt", Type: centraldogma.Text, Content: centraldogma.EntryContent("line 1\nline 2\n")},
+		{Path: "/conf/c.bin", Type: centraldogma.Text, Content: centraldogma.EntryContent("\xff\xfe")},
+	})
+	return client
+}
+
+func TestConfigMap(t *testing.T) {
+	client := newClient()
+	resource, _, err := k8s.Build(context.Background(), client, "Foo", "bar", "-1", "/conf/**",
+		&k8s.Options{Namespace: "app", Labels: map[string]string{"team": "infra", "enabled": "true"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var buf bytes.Buffer
+	if err := resource.WriteYAML(&buf); err != nil {
+		t.Fatal(err)
+	}
+	want := `apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: foo-bar-conf
+  namespace: app
+  labels:
+    enabled: "true"
+    team: infra
+  annotations:
+    centraldogma.linecorp.com/path-pattern: "/conf/**"
+    centraldogma.linecorp.com/project: Foo
+    centraldogma.linecorp.com/repository: bar
+    centraldogma.linecorp.com/revision: "3"
+data:
+  a.json: "{\"a\":1}"
+  b.txt: |
+    line 1
+    line 2
+binaryData:
+  c.bin: "//4="
+`
+	if buf.String() != want {
+		t.Errorf("WriteYAML() =\n%s\nwant\n%s", buf.String(), want)
+	}
+
+	calls := client.Calls("GetFiles")
+	if len(calls) != 1 || calls[0].Args[2] != "3" {
+		t.Errorf("GetFiles() calls = %+v, want one call at the revision 3", calls)
+	}
+}
+
+func TestSecret(t *testing.T) {
+	resource, _, err := k8s.Build(context.Background(), newClient(), "foo", "bar", "", "/conf/**",
+		&k8s.Options{Kind: k8s.KindSecret, Name: "my-secret", KeyRule: k8s.KeyPath})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var buf bytes.Buffer
+	if err := resource.WriteYAML(&buf); err != nil {
+		t.Fatal(err)
+	}
+	want := `apiVersion: v1
+kind: Secret
+metadata:
+  name: my-secret
+  annotations:
+    centraldogma.linecorp.com/path-pattern: "/conf/**"
+    centraldogma.linecorp.com/project: foo
+    centraldogma.linecorp.com/repository: bar
+    centraldogma.linecorp.com/revision: "3"
+type: Opaque
+data:
+  conf__a.json: "eyJhIjoxfQ=="
+  conf__c.bin: "//4="
+  conf__sub__b.txt: "bGluZSAxCmxpbmUgMgo="
+`
+	if buf.String() != want {
+		t.Errorf("WriteYAML() =\n%s\nwant\n%s", buf.String(), want)
+	}
+}
+
+func TestDuplicateKeys(t *testing.T) {
+	client := dogmamock.New()
+	client.Return("NormalizeRevision", 3)
+	client.Return("GetFiles", []*centraldogma.Entry{
+		{Path: "/a/x.txt", Type: centraldogma.Text, Content: centraldogma.EntryContent("a")},
+		{Path: "/b/x.txt", Type: centraldogma.Text, Content: centraldogma.EntryContent("b")},
+	})
+	if _, _, err := k8s.Build(context.Background(), client, "foo", "bar", "-1", "/**", nil); err == nil {
+		t.Error("Build() with the same base names should fail")
+	}
+	if _, _, err := k8s.Build(context.Background(), client, "foo", "bar", "-1", "/**",
+		&k8s.Options{KeyRule: k8s.KeyPath}); err != nil {
+		t.Errorf("Build() with KeyPath failed: %v", err)
+	}
+	if _, _, err := k8s.Build(context.Background(), client, "foo", "bar", "-1", "/**",
+		&k8s.Options{Kind: "Deployment"}); err == nil {
+		t.Error("Build() with an unknown kind should fail")
+	}
+}
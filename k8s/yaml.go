@@ -0,0 +1,128 @@
+// Copyright 2026 LINE Corporation
+//
+// LINE Corporation licenses this file to you under the Apache License,
+// version 2.0 (the "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at:
+//
+//   https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+// WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+// License for the specific language governing permissions and limitations
+// under the License.
+
+package k8s
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"io"
+	"regexp"
+	"strings"
+)
+
+// WriteYAML writes the resource as a YAML manifest. The keys are written in order, so the same resource is
+// always written the same way. The text contents of a ConfigMap are written in its data, and the others are
+// encoded with base64 in its binaryData. All contents of a Secret are encoded with base64 in its data.
+func (r *Resource) WriteYAML(w io.Writer) error {
+	bw := bufio.NewWriter(w)
+	bw.WriteString("apiVersion: v1\n")
+	bw.WriteString("kind: " + r.Kind + "\n")
+	bw.WriteString("metadata:\n")
+	bw.WriteString("  name: " + yamlString(r.Name) + "\n")
+	if len(r.Namespace) != 0 {
+		bw.WriteString("  namespace: " + yamlString(r.Namespace) + "\n")
+	}
+	writeMap(bw, "  ", "labels", r.Labels)
+	writeMap(bw, "  ", "annotations", r.Annotations)
+
+	data := make(map[string]string, len(r.Data))
+	binaryData := make(map[string]string)
+	for key, content := range r.Data {
+		if r.Kind == KindConfigMap && isText(content) {
+			data[key] = string(content)
+		} else if r.Kind == KindConfigMap {
+			binaryData[key] = base64.StdEncoding.EncodeToString(content)
+		} else {
+			data[key] = base64.StdEncoding.EncodeToString(content)
+		}
+	}
+	if r.Kind == KindSecret {
+		bw.WriteString("type: Opaque\n")
+	}
+	writeMap(bw, "", "data", data)
+	writeMap(bw, "", "binaryData", binaryData)
+	return bw.Flush()
+}
+
+func writeMap(w *bufio.Writer, indent, name string, m map[string]string) {
+	if len(m) == 0 {
+		return
+	}
+	w.WriteString(indent + name + ":\n")
+	for _, key := range sortedKeys(m) {
+		w.WriteString(indent + "  " + yamlString(key) + ":")
+		writeValue(w, indent+"    ", m[key])
+	}
+}
+
+// writeValue writes the multi-line value as a literal block scalar, and the others as a scalar on the line.
+func writeValue(w *bufio.Writer, indent, value string) {
+	if !canBeLiteral(value) {
+		w.WriteString(" " + yamlString(value) + "\n")
+		return
+	}
+
+	// The chomping indicator keeps the trailing newlines as they are.
+	trimmed := strings.TrimRight(value, "\n")
+	switch len(value) - len(trimmed) {
+	case 0:
+		w.WriteString(" |-\n")
+	case 1:
+		w.WriteString(" |\n")
+	default:
+		w.WriteString(" |+\n")
+	}
+	for _, line := range strings.Split(strings.TrimSuffix(value, "\n"), "\n") {
+		if len(line) != 0 {
+			w.WriteString(indent + line)
+		}
+		w.WriteString("\n")
+	}
+}
+
+// canBeLiteral returns whether the value can be written as a literal block scalar, i.e. it has multiple lines
+// with no control characters except tabs, and its first line does not start with a space.
+func canBeLiteral(value string) bool {
+	if !strings.Contains(value, "\n") || strings.HasPrefix(value, " ") || strings.HasPrefix(value, "\n") {
+		return false
+	}
+	for _, r := range value {
+		if r < 0x20 && r != '\n' && r != '\t' || r == 0x7f || r == '\ufeff' {
+			return false
+		}
+	}
+	return true
+}
+
+var plainPattern = regexp.MustCompile(`^[A-Za-z_][-A-Za-z0-9_./]*$`)
+
+// yamlString returns the string as a plain scalar if it cannot be read as another type, and as a double-quoted
+// scalar otherwise. A JSON string is a valid double-quoted scalar.
+func yamlString(s string) string {
+	if plainPattern.MatchString(s) {
+		switch strings.ToLower(s) {
+		case "true", "false", "yes", "no", "on", "off", "y", "n", "null":
+		default:
+			return s
+		}
+	}
+	var buf bytes.Buffer
+	enc := json.NewEncoder(&buf)
+	enc.SetEscapeHTML(false)
+	_ = enc.Encode(s)
+	return strings.TrimSuffix(buf.String(), "\n")
+}
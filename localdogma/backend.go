@@ -0,0 +1,284 @@
+// Copyright 2026 LINE Corporation
+//
+// LINE Corporation licenses this file to you under the Apache License,
+// version 2.0 (the "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at:
+//
+//   https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+// WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+// License for the specific language governing permissions and limitations
+// under the License.
+
+// Package localdogma serves the files in a local directory through the interfaces of the centraldogma
+// package, so that the code which reads its configuration from Central Dogma can run without a server
+// during local development. The directory is laid out as <project>/<repo>/<path>, e.g. the file
+// /config/a.json of the repository bar in the project foo is <root>/foo/bar/config/a.json.
+//
+// The revisions are emulated: the files of a repository are polled, and its revision is incremented
+// whenever a file is added, modified or removed. Like a repository of a server, a repository starts at
+// the revision 1 with no files, and the files found by the first scan are committed at the revision 2.
+// Only the latest revision is served. For example:
+//
+//	backend, err := localdogma.New("./dogma")
+//	if err != nil {
+//	    panic(err)
+//	}
+//	defer backend.Close()
+//
+//	var reader centraldogma.ContentReader = backend // or a *centraldogma.Client
+//	entry, _, err := reader.GetFile(ctx, "foo", "bar", "-1", &centraldogma.Query{Path: "/a.json"})
+package localdogma
+
+import (
+	"crypto/sha256"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/sirupsen/logrus"
+
+	"go.linecorp.com/centraldogma"
+)
+
+var log = logrus.New()
+
+var (
+	_ centraldogma.ContentReader = (*Backend)(nil)
+	_ centraldogma.Watching      = (*Backend)(nil)
+)
+
+// ErrNotSupported is returned by the methods which need the history of a repository, which a Backend does
+// not keep.
+var ErrNotSupported = errors.New("localdogma: not supported by the local backend")
+
+// DefaultPollInterval is the default interval of polling the files.
+const DefaultPollInterval = time.Second
+
+// modTimeGranularity is the coarsest granularity of the modification times of the files among the common
+// file systems, i.e. FAT.
+const modTimeGranularity = 2 * time.Second
+
+// Backend serves the files in a local directory. It is safe for concurrent use.
+type Backend struct {
+	root string
+
+	mu           sync.Mutex
+	pollInterval time.Duration
+	repos        map[string]*repository // keyed by "<project>/<repo>"
+	// updated is closed and replaced whenever the revision of a repository is incremented, which wakes up
+	// the watchers.
+	updated chan struct{}
+
+	done      chan struct{}
+	closeOnce sync.Once
+}
+
+type repository struct {
+	dir      string
+	revision int
+	files    map[string]*file
+	// changed[i] is the paths which were changed at the revision i+2.
+	changed [][]string
+}
+
+type file struct {
+	content []byte
+	hash    [sha256.Size]byte
+	modTime time.Time
+	size    int64
+	// readAt is the time when the content was read.
+	readAt time.Time
+}
+
+// unchanged returns whether the file is known to be unchanged since it was read without reading it again.
+// A file modified within the granularity of the modification time after it was read can have the same
+// modification time and size, so the file read too soon after its modification time has to be read again.
+func (f *file) unchanged(info os.FileInfo) bool {
+	return f.modTime.Equal(info.ModTime()) && f.size == info.Size() && f.readAt.Sub(f.modTime) >= modTimeGranularity
+}
+
+// New returns a new Backend which serves the files in the root directory, and starts polling them
+// at DefaultPollInterval. The caller should call Close when finished, to stop polling.
+func New(root string) (*Backend, error) {
+	info, err := os.Stat(root)
+	if err != nil {
+		return nil, err
+	}
+	if !info.IsDir() {
+		return nil, fmt.Errorf("localdogma: %s is not a directory", root)
+	}
+
+	b := &Backend{
+		root:         root,
+		pollInterval: DefaultPollInterval,
+		repos:        make(map[string]*repository),
+		updated:      make(chan struct{}),
+		done:         make(chan struct{}),
+	}
+	go b.pollLoop()
+	return b, nil
+}
+
+// SetPollInterval sets the interval of polling the files.
+func (b *Backend) SetPollInterval(interval time.Duration) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.pollInterval = interval
+}
+
+// Close stops polling the files.
+func (b *Backend) Close() {
+	b.closeOnce.Do(func() {
+		close(b.done)
+	})
+}
+
+// Poll scans the files of the repositories which have been accessed, and increments their revisions if
+// the files were changed. It is called periodically, but can be called to detect the changes immediately.
+func (b *Backend) Poll() error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	var errs []string
+	for key, repo := range b.repos {
+		if err := b.scan(repo); err != nil {
+			errs = append(errs, fmt.Sprintf("%s: %v", key, err))
+		}
+	}
+	if len(errs) != 0 {
+		sort.Strings(errs)
+		return fmt.Errorf("localdogma: failed to poll: %s", strings.Join(errs, ", "))
+	}
+	return nil
+}
+
+func (b *Backend) pollLoop() {
+	for {
+		b.mu.Lock()
+		interval := b.pollInterval
+		b.mu.Unlock()
+
+		select {
+		case <-b.done:
+			return
+		case <-time.After(interval):
+		}
+		if err := b.Poll(); err != nil {
+			log.Debug(err)
+		}
+	}
+}
+
+// repository returns the repository, scanning its files when it is accessed for the first time.
+// b.mu must be held.
+func (b *Backend) repository(projectName, repoName string) (*repository, int, error) {
+	key := projectName + "/" + repoName
+	if repo, ok := b.repos[key]; ok {
+		return repo, http.StatusOK, nil
+	}
+	if !validName(projectName) || !validName(repoName) {
+		return nil, http.StatusBadRequest, fmt.Errorf("localdogma: invalid repository %s", key)
+	}
+
+	dir := filepath.Join(b.root, projectName, repoName)
+	if info, err := os.Stat(dir); err != nil || !info.IsDir() {
+		return nil, http.StatusNotFound, fmt.Errorf("localdogma: repository %s does not exist in %s", key, b.root)
+	}
+	repo := &repository{dir: dir, revision: 1, files: make(map[string]*file)}
+	if err := b.scan(repo); err != nil {
+		return nil, centraldogma.UnknownHttpStatusCode, err
+	}
+	b.repos[key] = repo
+	return repo, http.StatusOK, nil
+}
+
+func validName(name string) bool {
+	return len(name) != 0 && name != "." && name != ".." && !strings.ContainsAny(name, `/\`)
+}
+
+// scan reads the files of the repository and increments its revision if they were changed. The files whose
+// names start with "." are ignored. b.mu must be held.
+func (b *Backend) scan(repo *repository) error {
+	files := make(map[string]*file, len(repo.files))
+	err := filepath.Walk(repo.dir, func(p string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if strings.HasPrefix(info.Name(), ".") && p != repo.dir {
+			if info.IsDir() {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if !info.Mode().IsRegular() {
+			return nil
+		}
+
+		rel, err := filepath.Rel(repo.dir, p)
+		if err != nil {
+			return err
+		}
+		path := "/" + filepath.ToSlash(rel)
+		if old, ok := repo.files[path]; ok && old.unchanged(info) {
+			files[path] = old
+			return nil
+		}
+		readAt := time.Now()
+		content, err := ioutil.ReadFile(p)
+		if err != nil {
+			return err
+		}
+		files[path] = &file{
+			content: content, hash: sha256.Sum256(content), modTime: info.ModTime(), size: info.Size(),
+			readAt: readAt,
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	var changed []string
+	for path, f := range files {
+		if old, ok := repo.files[path]; !ok || old.hash != f.hash {
+			changed = append(changed, path)
+		}
+	}
+	for path := range repo.files {
+		if _, ok := files[path]; !ok {
+			changed = append(changed, path)
+		}
+	}
+	repo.files = files
+	if len(changed) == 0 {
+		return nil
+	}
+
+	sort.Strings(changed)
+	repo.revision++
+	repo.changed = append(repo.changed, changed)
+	close(b.updated)
+	b.updated = make(chan struct{})
+	return nil
+}
+
+// changedSince returns the paths which were changed after the revision.
+func (r *repository) changedSince(revision int) []string {
+	if revision < 1 {
+		revision = 1
+	}
+	var paths []string
+	for i := revision - 1; i < len(r.changed); i++ {
+		paths = append(paths, r.changed[i]...)
+	}
+	return paths
+}
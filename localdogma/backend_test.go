@@ -0,0 +1,238 @@
+// Copyright 2026 LINE Corporation
+//
+// LINE Corporation licenses this file to you under the Apache License,
+// version 2.0 (the "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at:
+//
+//   https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+// WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+// License for the specific language governing permissions and limitations
+// under the License.
+
+package localdogma_test
+
+import (
+	"context"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+
+	"go.linecorp.com/centraldogma"
+	"go.linecorp.com/centraldogma/localdogma"
+)
+
+func writeFile(t *testing.T, root, path, content string) {
+	t.Helper()
+	name := filepath.Join(root, filepath.FromSlash(path))
+	if err := os.MkdirAll(filepath.Dir(name), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := ioutil.WriteFile(name, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestGetFile(t *testing.T) {
+	root := t.TempDir()
+	writeFile(t, root, "foo/bar/a.json", `{"a":{"b":1}}`)
+	writeFile(t, root, "foo/bar/dir/b.txt", "hello\n")
+	writeFile(t, root, "foo/bar/.git/config", "ignored")
+
+	backend, err := localdogma.New(root)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer backend.Close()
+	ctx := context.Background()
+
+	entry, _, err := backend.GetFile(ctx, "foo", "bar", "-1",
+		&centraldogma.Query{Path: "/a.json", Type: centraldogma.Identity})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if entry.Type != centraldogma.JSON || string(entry.Content) != `{"a":{"b":1}}` || entry.Revision != 2 {
+		t.Errorf("GetFile() = %+v, want the JSON file at the revision 2", entry)
+	}
+
+	entry, _, err = backend.GetFile(ctx, "foo", "bar", "2",
+		&centraldogma.Query{Path: "/a.json", Type: centraldogma.JSONPath, Expressions: []string{"$.a"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(entry.Content) != `{"b":1}` {
+		t.Errorf("GetFile() with JSON path = %s, want {\"b\":1}", entry.Content)
+	}
+
+	if _, httpStatusCode, err := backend.GetFile(ctx, "foo", "bar", "-1",
+		&centraldogma.Query{Path: "/c.json", Type: centraldogma.Identity}); err == nil ||
+		httpStatusCode != http.StatusNotFound {
+		t.Errorf("GetFile() = %v, %v, want 404", httpStatusCode, err)
+	}
+	if _, httpStatusCode, err := backend.GetFile(ctx, "foo", "baz", "-1",
+		&centraldogma.Query{Path: "/a.json", Type: centraldogma.Identity}); err == nil ||
+		httpStatusCode != http.StatusNotFound {
+		t.Errorf("GetFile() of a missing repository = %v, %v, want 404", httpStatusCode, err)
+	}
+	if _, _, err := backend.GetFile(ctx, "foo", "bar", "1",
+		&centraldogma.Query{Path: "/a.json", Type: centraldogma.Identity}); err == nil {
+		t.Error("GetFile() at an old revision should fail")
+	}
+
+	entries, _, err := backend.ListFiles(ctx, "foo", "bar", "-1", "/**")
+	if err != nil {
+		t.Fatal(err)
+	}
+	var paths []string
+	for _, entry := range entries {
+		paths = append(paths, entry.Path)
+	}
+	if want := []string{"/a.json", "/dir", "/dir/b.txt"}; !reflect.DeepEqual(paths, want) {
+		t.Errorf("ListFiles() = %v, want %v", paths, want)
+	}
+
+	entries, _, err = backend.GetFiles(ctx, "foo", "bar", "-1", "*.txt")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(entries) != 1 || entries[0].Type != centraldogma.Text || string(entries[0].Content) != "hello\n" {
+		t.Errorf("GetFiles() = %+v, want /dir/b.txt", entries)
+	}
+}
+
+func TestWatch(t *testing.T) {
+	root := t.TempDir()
+	writeFile(t, root, "foo/bar/a.json", `{"a":1,"b":1}`)
+
+	backend, err := localdogma.New(root)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer backend.Close()
+
+	watcher, err := backend.FileWatcher("foo", "bar",
+		&centraldogma.Query{Path: "/a.json", Type: centraldogma.JSONPath, Expressions: []string{"$.a"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer watcher.Close()
+	if initial := watcher.AwaitInitialValueWith(time.Second); initial.Err != nil ||
+		initial.Revision != 2 || string(initial.Entry.Content) != "1" {
+		t.Fatalf("AwaitInitialValue() = %+v, want 1 at the revision 2", initial)
+	}
+
+	changes, closer, err := backend.WatchRepository(context.Background(), "foo", "bar", "*.json", 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer closer()
+	if result := <-changes; result.Revision != 2 {
+		t.Fatalf("WatchRepository() = %+v, want the revision 2", result)
+	}
+
+	ch := make(chan centraldogma.WatchResult, 2)
+	if err := watcher.Watch(func(result centraldogma.WatchResult) {
+		if result.Revision > 2 {
+			ch <- result
+		}
+	}); err != nil {
+		t.Fatal(err)
+	}
+
+	// The change of "b" is not notified to the file watcher because the result of the query is not changed.
+	writeFile(t, root, "foo/bar/a.json", `{"a":1,"b":2}`)
+	if err := backend.Poll(); err != nil {
+		t.Fatal(err)
+	}
+	writeFile(t, root, "foo/bar/a.json", `{"a":2,"b":2}`)
+	if err := backend.Poll(); err != nil {
+		t.Fatal(err)
+	}
+
+	select {
+	case result := <-ch:
+		if result.Revision != 4 || string(result.Entry.Content) != "2" {
+			t.Errorf("watch result = %+v, want 2 at the revision 4", result)
+		}
+	case <-time.After(3 * time.Second):
+		t.Fatal("timed out waiting for the change")
+	}
+	select {
+	case result := <-changes:
+		if result.Revision < 3 {
+			t.Errorf("WatchRepository() = %+v, want the revision 3 or later", result)
+		}
+	case <-time.After(3 * time.Second):
+		t.Fatal("timed out waiting for the change of the repository")
+	}
+}
+
+func TestPoll_SameModTimeAndSize(t *testing.T) {
+	root := t.TempDir()
+	writeFile(t, root, "foo/bar/a.txt", "1")
+	name := filepath.Join(root, "foo", "bar", "a.txt")
+	info, err := os.Stat(name)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	backend, err := localdogma.New(root)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer backend.Close()
+	ctx := context.Background()
+	query := &centraldogma.Query{Path: "/a.txt", Type: centraldogma.Identity}
+	if _, _, err := backend.GetFile(ctx, "foo", "bar", "-1", query); err != nil {
+		t.Fatal(err)
+	}
+
+	// The edit which keeps the modification time and the size, as on a file system with a coarse granularity
+	// of the modification time, is noticed as well.
+	writeFile(t, root, "foo/bar/a.txt", "2")
+	if err := os.Chtimes(name, info.ModTime(), info.ModTime()); err != nil {
+		t.Fatal(err)
+	}
+	if err := backend.Poll(); err != nil {
+		t.Fatal(err)
+	}
+	entry, _, err := backend.GetFile(ctx, "foo", "bar", "-1", query)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(entry.Content) != "2" || entry.Revision != 3 {
+		t.Errorf("GetFile() = %+v, want 2 at the revision 3", entry)
+	}
+}
+
+func TestWatch_Close(t *testing.T) {
+	root := t.TempDir()
+	writeFile(t, root, "foo/bar/a.json", `{"a":1}`)
+
+	backend, err := localdogma.New(root)
+	if err != nil {
+		t.Fatal(err)
+	}
+	watcher, err := backend.RepoWatcher("foo", "bar", "/**")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer watcher.Close()
+	watcher.AwaitInitialValueWith(time.Second)
+
+	// The watcher is closed with the backend instead of retrying.
+	backend.Close()
+	deadline := time.Now().Add(3 * time.Second)
+	for watcher.Watch(func(centraldogma.WatchResult) {}) != centraldogma.ErrWatcherClosed {
+		if time.Now().After(deadline) {
+			t.Fatal("timed out waiting for the watcher to be closed")
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+}
@@ -0,0 +1,272 @@
+// Copyright 2026 LINE Corporation
+//
+// LINE Corporation licenses this file to you under the Apache License,
+// version 2.0 (the "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at:
+//
+//   https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+// WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+// License for the specific language governing permissions and limitations
+// under the License.
+
+package localdogma
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+
+	"go.linecorp.com/centraldogma"
+	"go.linecorp.com/centraldogma/internal/pathpattern"
+	"go.linecorp.com/centraldogma/jsonpath"
+)
+
+// NormalizeRevision converts the relative revision number to the absolute revision number. Only the latest
+// revision can be specified.
+func (b *Backend) NormalizeRevision(ctx context.Context,
+	projectName, repoName, revision string) (normalizedRev int, httpStatusCode int, err error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	repo, httpStatusCode, err := b.repository(projectName, repoName)
+	if err != nil {
+		return 0, httpStatusCode, err
+	}
+	if err := checkRevision(repo, revision); err != nil {
+		return 0, http.StatusNotFound, err
+	}
+	return repo.revision, http.StatusOK, nil
+}
+
+// Normalize converts the relative Revision to the absolute Revision. Only the latest revision can be specified.
+func (b *Backend) Normalize(ctx context.Context, projectName, repoName string,
+	revision centraldogma.Revision) (normalizedRev centraldogma.Revision, httpStatusCode int, err error) {
+	rev, httpStatusCode, err := b.NormalizeRevision(ctx, projectName, repoName, revisionString(revision))
+	return centraldogma.Revision(rev), httpStatusCode, err
+}
+
+// ListFiles returns the files and the directories which match the path pattern at the latest revision.
+func (b *Backend) ListFiles(ctx context.Context,
+	projectName, repoName, revision, pathPattern string) (entries []*centraldogma.Entry, httpStatusCode int,
+	err error) {
+	return b.entries(projectName, repoName, revision, pathPattern, false)
+}
+
+// ListFilesAt returns the files and the directories which match the path pattern at the latest revision.
+func (b *Backend) ListFilesAt(ctx context.Context, projectName, repoName string, revision centraldogma.Revision,
+	pathPattern string) (entries []*centraldogma.Entry, httpStatusCode int, err error) {
+	return b.entries(projectName, repoName, revisionString(revision), pathPattern, false)
+}
+
+// GetFile returns the file at the latest revision with the specified Query.
+func (b *Backend) GetFile(ctx context.Context, projectName, repoName, revision string,
+	query *centraldogma.Query) (entry *centraldogma.Entry, httpStatusCode int, err error) {
+	if query == nil {
+		return nil, centraldogma.UnknownHttpStatusCode, centraldogma.ErrQueryMustBeSet
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	repo, httpStatusCode, err := b.repository(projectName, repoName)
+	if err != nil {
+		return nil, httpStatusCode, err
+	}
+	if err := checkRevision(repo, revision); err != nil {
+		return nil, http.StatusNotFound, err
+	}
+	return getEntry(repo, query)
+}
+
+// GetFileAt returns the file at the latest revision with the specified Query.
+func (b *Backend) GetFileAt(ctx context.Context, projectName, repoName string, revision centraldogma.Revision,
+	query *centraldogma.Query) (entry *centraldogma.Entry, httpStatusCode int, err error) {
+	return b.GetFile(ctx, projectName, repoName, revisionString(revision), query)
+}
+
+// GetFiles returns the files which match the path pattern at the latest revision.
+func (b *Backend) GetFiles(ctx context.Context,
+	projectName, repoName, revision, pathPattern string) (entries []*centraldogma.Entry, httpStatusCode int,
+	err error) {
+	return b.entries(projectName, repoName, revision, pathPattern, true)
+}
+
+// GetFilesAt returns the files which match the path pattern at the latest revision.
+func (b *Backend) GetFilesAt(ctx context.Context, projectName, repoName string, revision centraldogma.Revision,
+	pathPattern string) (entries []*centraldogma.Entry, httpStatusCode int, err error) {
+	return b.entries(projectName, repoName, revisionString(revision), pathPattern, true)
+}
+
+// GetHistory returns ErrNotSupported because a Backend does not keep the history.
+func (b *Backend) GetHistory(ctx context.Context, projectName, repoName, from, to, pathPattern string,
+	maxCommits int) (commits []*centraldogma.Commit, httpStatusCode int, err error) {
+	return nil, centraldogma.UnknownHttpStatusCode, ErrNotSupported
+}
+
+// GetHistoryBetween returns ErrNotSupported because a Backend does not keep the history.
+func (b *Backend) GetHistoryBetween(ctx context.Context, projectName, repoName string, from, to centraldogma.Revision,
+	pathPattern string, maxCommits int) (commits []*centraldogma.Commit, httpStatusCode int, err error) {
+	return nil, centraldogma.UnknownHttpStatusCode, ErrNotSupported
+}
+
+// IterateHistory returns the HistoryIterator which fails with ErrNotSupported because a Backend does not keep
+// the history.
+func (b *Backend) IterateHistory(ctx context.Context, projectName, repoName string,
+	opts *centraldogma.HistoryOptions) *centraldogma.HistoryIterator {
+	return centraldogma.NewHistoryIterator(ctx, opts, func(ctx context.Context, from, to centraldogma.Revision,
+		pathPattern string, maxCommits int) ([]*centraldogma.Commit, error) {
+		return nil, ErrNotSupported
+	})
+}
+
+// GetCommit returns ErrNotSupported because a Backend does not keep the history.
+func (b *Backend) GetCommit(ctx context.Context, projectName, repoName string,
+	revision centraldogma.Revision) (detail *centraldogma.CommitDetail, httpStatusCode int, err error) {
+	return nil, centraldogma.UnknownHttpStatusCode, ErrNotSupported
+}
+
+// GetDiff returns ErrNotSupported because a Backend does not keep the history.
+func (b *Backend) GetDiff(ctx context.Context, projectName, repoName, from, to string,
+	query *centraldogma.Query) (change *centraldogma.Change, httpStatusCode int, err error) {
+	return nil, centraldogma.UnknownHttpStatusCode, ErrNotSupported
+}
+
+// GetDiffBetween returns ErrNotSupported because a Backend does not keep the history.
+func (b *Backend) GetDiffBetween(ctx context.Context, projectName, repoName string, from, to centraldogma.Revision,
+	query *centraldogma.Query) (change *centraldogma.Change, httpStatusCode int, err error) {
+	return nil, centraldogma.UnknownHttpStatusCode, ErrNotSupported
+}
+
+// GetDiffs returns ErrNotSupported because a Backend does not keep the history.
+func (b *Backend) GetDiffs(ctx context.Context, projectName, repoName, from, to,
+	pathPattern string) (changes []*centraldogma.Change, httpStatusCode int, err error) {
+	return nil, centraldogma.UnknownHttpStatusCode, ErrNotSupported
+}
+
+// GetDiffsBetween returns ErrNotSupported because a Backend does not keep the history.
+func (b *Backend) GetDiffsBetween(ctx context.Context, projectName, repoName string, from, to centraldogma.Revision,
+	pathPattern string) (changes []*centraldogma.Change, httpStatusCode int, err error) {
+	return nil, centraldogma.UnknownHttpStatusCode, ErrNotSupported
+}
+
+// Blame returns ErrNotSupported because a Backend does not keep the history.
+func (b *Backend) Blame(ctx context.Context, projectName, repoName, path string,
+	revision centraldogma.Revision) (blame *centraldogma.Blame, httpStatusCode int, err error) {
+	return nil, centraldogma.UnknownHttpStatusCode, ErrNotSupported
+}
+
+// MergeFiles returns ErrNotSupported.
+func (b *Backend) MergeFiles(ctx context.Context, projectName, repoName string, revision centraldogma.Revision,
+	mergeQuery *centraldogma.MergeQuery) (mergedEntry *centraldogma.MergedEntry, httpStatusCode int, err error) {
+	return nil, centraldogma.UnknownHttpStatusCode, ErrNotSupported
+}
+
+func (b *Backend) entries(projectName, repoName, revision, pathPattern string,
+	withContent bool) ([]*centraldogma.Entry, int, error) {
+	pattern, err := pathpattern.Compile(pathPattern)
+	if err != nil {
+		return nil, http.StatusBadRequest, err
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	repo, httpStatusCode, err := b.repository(projectName, repoName)
+	if err != nil {
+		return nil, httpStatusCode, err
+	}
+	if err := checkRevision(repo, revision); err != nil {
+		return nil, http.StatusNotFound, err
+	}
+
+	entries := make([]*centraldogma.Entry, 0)
+	if !withContent {
+		for dir := range directories(repo.files) {
+			if pattern.Match(dir) {
+				entries = append(entries, &centraldogma.Entry{
+					Path: dir, Type: centraldogma.Directory, Revision: repo.revision,
+				})
+			}
+		}
+	}
+	for path, f := range repo.files {
+		if pattern.Match(path) {
+			entry := newEntry(repo, path, f)
+			if !withContent {
+				entry.Content = nil
+			}
+			entries = append(entries, entry)
+		}
+	}
+	sort.Slice(entries, func(i, j int) bool { return entries[i].Path < entries[j].Path })
+	return entries, http.StatusOK, nil
+}
+
+// checkRevision returns an error unless the revision is the latest revision of the repository.
+func checkRevision(repo *repository, revision string) error {
+	if len(revision) == 0 || revision == "-1" || revision == strconv.Itoa(repo.revision) {
+		return nil
+	}
+	return fmt.Errorf("localdogma: revision %s is not available; only the latest revision %d is served",
+		revision, repo.revision)
+}
+
+// revisionString returns the revision string which checkRevision accepts for the Revision. 0 means the latest.
+func revisionString(revision centraldogma.Revision) string {
+	if revision == 0 {
+		return ""
+	}
+	return revision.String()
+}
+
+// getEntry returns the entry of the file at the latest revision, whose content is the result of the query.
+func getEntry(repo *repository, query *centraldogma.Query) (*centraldogma.Entry, int, error) {
+	f, ok := repo.files[query.Path]
+	if !ok {
+		return nil, http.StatusNotFound, fmt.Errorf("localdogma: %s does not exist at the revision %d",
+			query.Path, repo.revision)
+	}
+	entry := newEntry(repo, query.Path, f)
+	if query.Type != centraldogma.JSONPath {
+		return entry, http.StatusOK, nil
+	}
+
+	if entry.Type != centraldogma.JSON {
+		return nil, http.StatusBadRequest, fmt.Errorf("localdogma: %s is not a JSON file", query.Path)
+	}
+	content, err := jsonpath.EvalJSON(entry.Content, query.Expressions...)
+	if err != nil {
+		return nil, http.StatusBadRequest, err
+	}
+	entry.Content = content
+	return entry, http.StatusOK, nil
+}
+
+func newEntry(repo *repository, path string, f *file) *centraldogma.Entry {
+	entry := &centraldogma.Entry{
+		Path:       path,
+		Type:       centraldogma.Text,
+		Content:    centraldogma.EntryContent(f.content),
+		Revision:   repo.revision,
+		ModifiedAt: f.modTime.UTC().Format("2006-01-02T15:04:05.000Z"),
+	}
+	if strings.HasSuffix(strings.ToLower(path), ".json") && json.Valid(f.content) {
+		entry.Type = centraldogma.JSON
+	}
+	return entry
+}
+
+// directories returns the directories of the files.
+func directories(files map[string]*file) map[string]bool {
+	dirs := make(map[string]bool)
+	for path := range files {
+		for i := strings.LastIndex(path, "/"); i > 0; i = strings.LastIndex(path[:i], "/") {
+			dirs[path[:i]] = true
+		}
+	}
+	return dirs
+}
@@ -0,0 +1,168 @@
+// Copyright 2026 LINE Corporation
+//
+// LINE Corporation licenses this file to you under the Apache License,
+// version 2.0 (the "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at:
+//
+//   https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+// WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+// License for the specific language governing permissions and limitations
+// under the License.
+
+package localdogma
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"net/http"
+	"time"
+
+	"go.linecorp.com/centraldogma"
+	"go.linecorp.com/centraldogma/internal/pathpattern"
+)
+
+// WatchFile watches on the changes of the file. The timeout is ignored because the changes are detected
+// by polling the files rather than long polling. See centraldogma.Client.WatchFile for the usage.
+func (b *Backend) WatchFile(ctx context.Context, projectName, repoName string, query *centraldogma.Query,
+	timeout time.Duration) (result <-chan centraldogma.WatchResult, closer func(), err error) {
+	if query == nil {
+		return nil, nil, centraldogma.ErrQueryMustBeSet
+	}
+	result, closer = centraldogma.WatchChannel(centraldogma.NewWatcher(ctx, projectName, repoName, query.Path,
+		b.fileWatchFunc(projectName, repoName, query)))
+	return result, closer, nil
+}
+
+// WatchRepository watches on the changes of the files which match the path pattern. The timeout is ignored
+// because the changes are detected by polling the files rather than long polling.
+// See centraldogma.Client.WatchRepository for the usage.
+func (b *Backend) WatchRepository(ctx context.Context, projectName, repoName, pathPattern string,
+	timeout time.Duration) (result <-chan centraldogma.WatchResult, closer func(), err error) {
+	pattern, err := pathpattern.Compile(pathPattern)
+	if err != nil {
+		return nil, nil, err
+	}
+	result, closer = centraldogma.WatchChannel(centraldogma.NewWatcher(ctx, projectName, repoName, pathPattern,
+		b.repoWatchFunc(projectName, repoName, pattern)))
+	return result, closer, nil
+}
+
+// FileWatcher returns a Watcher which notifies its listeners when the result of the given Query becomes
+// available or changes.
+func (b *Backend) FileWatcher(projectName, repoName string,
+	query *centraldogma.Query) (*centraldogma.Watcher, error) {
+	if query == nil {
+		return nil, centraldogma.ErrQueryMustBeSet
+	}
+	return centraldogma.NewWatcher(context.Background(), projectName, repoName, query.Path,
+		b.fileWatchFunc(projectName, repoName, query)), nil
+}
+
+// RepoWatcher returns a Watcher which notifies its listeners when the files which match the path pattern
+// become available or change.
+func (b *Backend) RepoWatcher(projectName, repoName, pathPattern string) (*centraldogma.Watcher, error) {
+	pattern, err := pathpattern.Compile(pathPattern)
+	if err != nil {
+		return nil, err
+	}
+	return centraldogma.NewWatcher(context.Background(), projectName, repoName, pathPattern,
+		b.repoWatchFunc(projectName, repoName, pattern)), nil
+}
+
+// MergeWatcher returns ErrNotSupported.
+func (b *Backend) MergeWatcher(projectName, repoName string,
+	mergeQuery *centraldogma.MergeQuery) (*centraldogma.Watcher, error) {
+	return nil, ErrNotSupported
+}
+
+// errClosed closes the watchers when the backend is closed.
+var errClosed = fmt.Errorf("localdogma: the backend is closed: %w", centraldogma.ErrWatcherClosed)
+
+// checkFunc returns the result of a watch if the repository was changed after the last known revision,
+// or nil otherwise. b.mu is held while it is called.
+type checkFunc func(repo *repository, lastKnownRevision int) *centraldogma.WatchResult
+
+func (b *Backend) fileWatchFunc(projectName, repoName string,
+	query *centraldogma.Query) centraldogma.WatchFunc {
+	// lastContent is the content of the last result, which skips the changes that do not change the result
+	// of the query, e.g. the changes of the other values than the ones selected by the JSON path expressions.
+	var lastContent []byte
+	return b.watchFunc(projectName, repoName, func(repo *repository,
+		lastKnownRevision int) *centraldogma.WatchResult {
+		if !contains(repo.changedSince(lastKnownRevision), query.Path) {
+			return nil
+		}
+		if _, ok := repo.files[query.Path]; !ok {
+			return nil
+		}
+		entry, httpStatusCode, err := getEntry(repo, query)
+		if err != nil {
+			return &centraldogma.WatchResult{HttpStatusCode: httpStatusCode, Err: err}
+		}
+		if lastContent != nil && bytes.Equal(lastContent, entry.Content) {
+			return nil
+		}
+		lastContent = entry.Content
+		return &centraldogma.WatchResult{
+			Revision: repo.revision, Entry: *entry, HttpStatusCode: http.StatusOK,
+		}
+	})
+}
+
+func (b *Backend) repoWatchFunc(projectName, repoName string,
+	pattern *pathpattern.Pattern) centraldogma.WatchFunc {
+	return b.watchFunc(projectName, repoName, func(repo *repository,
+		lastKnownRevision int) *centraldogma.WatchResult {
+		for _, path := range repo.changedSince(lastKnownRevision) {
+			if pattern.Match(path) {
+				return &centraldogma.WatchResult{Revision: repo.revision, HttpStatusCode: http.StatusOK}
+			}
+		}
+		return nil
+	})
+}
+
+// watchFunc returns a centraldogma.WatchFunc which waits until the check returns a result.
+func (b *Backend) watchFunc(projectName, repoName string, check checkFunc) centraldogma.WatchFunc {
+	return func(ctx context.Context, lastKnownRevision int) *centraldogma.WatchResult {
+		for {
+			b.mu.Lock()
+			updated := b.updated
+			repo, httpStatusCode, err := b.repository(projectName, repoName)
+			var result *centraldogma.WatchResult
+			if err == nil {
+				result = check(repo, lastKnownRevision)
+				// The changes which are skipped by the check are not checked again.
+				lastKnownRevision = repo.revision
+			}
+			b.mu.Unlock()
+			if err != nil {
+				return &centraldogma.WatchResult{HttpStatusCode: httpStatusCode, Err: err}
+			}
+			if result != nil {
+				return result
+			}
+
+			select {
+			case <-updated:
+			case <-b.done:
+				return &centraldogma.WatchResult{Err: errClosed}
+			case <-ctx.Done():
+				return &centraldogma.WatchResult{Err: ctx.Err()}
+			}
+		}
+	}
+}
+
+func contains(paths []string, path string) bool {
+	for _, p := range paths {
+		if p == path {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,169 @@
+// Copyright 2026 LINE Corporation
+//
+// LINE Corporation licenses this file to you under the Apache License,
+// version 2.0 (the "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at:
+//
+//   https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+// WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+// License for the specific language governing permissions and limitations
+// under the License.
+
+package centraldogma
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"path"
+	"sort"
+	"time"
+)
+
+type metadataService service
+
+// ProjectMetadata represents the metadata of a project, which specifies who can access the project.
+type ProjectMetadata struct {
+	Name     string                         `json:"name"`
+	Repos    map[string]*RepositoryMetadata `json:"repos,omitempty"`
+	Members  map[string]*Member             `json:"members,omitempty"`
+	Tokens   map[string]*TokenRegistration  `json:"tokens,omitempty"`
+	Creation *UserAndTimestamp              `json:"creation,omitempty"`
+	Removal  *UserAndTimestamp              `json:"removal,omitempty"`
+}
+
+// RepositoryMetadata represents the metadata of a repository.
+type RepositoryMetadata struct {
+	Name string `json:"name"`
+	RepositoryPermissions
+	Creation *UserAndTimestamp `json:"creation,omitempty"`
+	Removal  *UserAndTimestamp `json:"removal,omitempty"`
+}
+
+// Member represents a member of a project.
+type Member struct {
+	Login    string            `json:"login"`
+	Role     ProjectRole       `json:"role"`
+	Creation *UserAndTimestamp `json:"creation,omitempty"`
+}
+
+// UserAndTimestamp represents who did an action and when.
+type UserAndTimestamp struct {
+	User      string `json:"user"`
+	Timestamp string `json:"timestamp"`
+}
+
+// Time returns the time when the action was done. It returns the zero time if Timestamp is empty.
+func (u *UserAndTimestamp) Time() (time.Time, error) {
+	return parseOptionalTimestamp(u.Timestamp)
+}
+
+func validateProjectRole(role ProjectRole) error {
+	if _, ok := projectRoleMap[role.String()]; !ok {
+		return fmt.Errorf("invalid project role: %d (expected OWNER, MEMBER or GUEST)", role)
+	}
+	return nil
+}
+
+func (m *metadataService) getProjectMetadata(ctx context.Context, projectName string) (*ProjectMetadata, int, error) {
+	u, err := url.Parse(path.Join(
+		defaultPathPrefix,
+		projects, projectName,
+	))
+	if err != nil {
+		return nil, UnknownHttpStatusCode, err
+	}
+
+	req, err := m.client.newRequest(http.MethodGet, u, nil)
+	if err != nil {
+		return nil, UnknownHttpStatusCode, err
+	}
+
+	projectMetadata := new(ProjectMetadata)
+	httpStatusCode, err := m.client.do(ctx, req, projectMetadata, false)
+	if err != nil {
+		return nil, httpStatusCode, err
+	}
+	return projectMetadata, httpStatusCode, nil
+}
+
+func (m *metadataService) listMembers(ctx context.Context, projectName string) ([]*Member, int, error) {
+	projectMetadata, httpStatusCode, err := m.getProjectMetadata(ctx, projectName)
+	if err != nil {
+		return nil, httpStatusCode, err
+	}
+
+	members := make([]*Member, 0, len(projectMetadata.Members))
+	for _, member := range projectMetadata.Members {
+		members = append(members, member)
+	}
+	sort.Slice(members, func(i, j int) bool { return members[i].Login < members[j].Login })
+	return members, httpStatusCode, nil
+}
+
+func (m *metadataService) addMember(ctx context.Context,
+	projectName, login string, role ProjectRole) (int, error) {
+	if err := validateProjectRole(role); err != nil {
+		return UnknownHttpStatusCode, err
+	}
+
+	u, err := url.Parse(path.Join(
+		defaultPathPrefix,
+		metadata, projectName,
+		members,
+	))
+	if err != nil {
+		return UnknownHttpStatusCode, err
+	}
+
+	body := map[string]interface{}{"id": login, "role": role}
+	req, err := m.client.newRequest(http.MethodPost, u, body)
+	if err != nil {
+		return UnknownHttpStatusCode, err
+	}
+	return m.client.do(ctx, req, nil, false)
+}
+
+func (m *metadataService) updateMemberRole(ctx context.Context,
+	projectName, login string, role ProjectRole) (int, error) {
+	if err := validateProjectRole(role); err != nil {
+		return UnknownHttpStatusCode, err
+	}
+
+	u, err := url.Parse(path.Join(
+		defaultPathPrefix,
+		metadata, projectName,
+		members, login,
+	))
+	if err != nil {
+		return UnknownHttpStatusCode, err
+	}
+
+	body := []map[string]interface{}{{"op": "replace", "path": "/role", "value": role}}
+	req, err := m.client.newRequest(http.MethodPatch, u, body)
+	if err != nil {
+		return UnknownHttpStatusCode, err
+	}
+	return m.client.do(ctx, req, nil, false)
+}
+
+func (m *metadataService) removeMember(ctx context.Context, projectName, login string) (int, error) {
+	u, err := url.Parse(path.Join(
+		defaultPathPrefix,
+		metadata, projectName,
+		members, login,
+	))
+	if err != nil {
+		return UnknownHttpStatusCode, err
+	}
+
+	req, err := m.client.newRequest(http.MethodDelete, u, nil)
+	if err != nil {
+		return UnknownHttpStatusCode, err
+	}
+	return m.client.do(ctx, req, nil, false)
+}
@@ -0,0 +1,145 @@
+// Copyright 2026 LINE Corporation
+//
+// LINE Corporation licenses this file to you under the Apache License,
+// version 2.0 (the "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at:
+//
+//   https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+// WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+// License for the specific language governing permissions and limitations
+// under the License.
+
+package centraldogma
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"reflect"
+	"testing"
+)
+
+const testProjectMetadata = `{"name":"foo",
+"repos":{"bar":{"name":"bar", "creation":{"user":"minux@m.x", "timestamp":"2026-01-02T03:04:05Z"}}},
+"members":{"minux@m.x":{"login":"minux@m.x", "role":"OWNER",
+"creation":{"user":"minux@m.x", "timestamp":"2026-01-02T03:04:05Z"}},
+"alice@m.x":{"login":"alice@m.x", "role":"GUEST",
+"creation":{"user":"minux@m.x", "timestamp":"2026-01-02T03:04:06Z"}}},
+"creation":{"user":"minux@m.x", "timestamp":"2026-01-02T03:04:05Z"}}`
+
+func TestGetProjectMetadata(t *testing.T) {
+	c, mux, teardown := setup()
+	defer teardown()
+
+	mux.HandleFunc("/api/v1/projects/foo", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, http.MethodGet)
+		fmt.Fprint(w, testProjectMetadata)
+	})
+
+	projectMetadata, httpStatusCode, err := c.GetProjectMetadata(context.Background(), "foo")
+	if err != nil {
+		t.Fatal(err)
+	}
+	testStatusCode(t, httpStatusCode, 200)
+
+	creation := &UserAndTimestamp{User: "minux@m.x", Timestamp: "2026-01-02T03:04:05Z"}
+	want := &ProjectMetadata{
+		Name:  "foo",
+		Repos: map[string]*RepositoryMetadata{"bar": {Name: "bar", Creation: creation}},
+		Members: map[string]*Member{
+			"minux@m.x": {Login: "minux@m.x", Role: RoleOwner, Creation: creation},
+			"alice@m.x": {Login: "alice@m.x", Role: RoleGuest,
+				Creation: &UserAndTimestamp{User: "minux@m.x", Timestamp: "2026-01-02T03:04:06Z"}},
+		},
+		Creation: creation,
+	}
+	if !reflect.DeepEqual(projectMetadata, want) {
+		t.Errorf("GetProjectMetadata returned %+v, want %+v", projectMetadata, want)
+	}
+}
+
+func TestListMembers(t *testing.T) {
+	c, mux, teardown := setup()
+	defer teardown()
+
+	mux.HandleFunc("/api/v1/projects/foo", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, http.MethodGet)
+		fmt.Fprint(w, testProjectMetadata)
+	})
+
+	members, _, err := c.ListMembers(context.Background(), "foo")
+	if err != nil {
+		t.Fatal(err)
+	}
+	var got []string
+	for _, member := range members {
+		got = append(got, member.Login+":"+member.Role.String())
+	}
+	want := []string{"alice@m.x:GUEST", "minux@m.x:OWNER"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("ListMembers returned %v, want %v", got, want)
+	}
+}
+
+func TestAddMember(t *testing.T) {
+	c, mux, teardown := setup()
+	defer teardown()
+
+	mux.HandleFunc("/api/v1/metadata/foo/members", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, http.MethodPost)
+		testHeader(t, r, "Content-Type", "application/json")
+		testBody(t, r, `{"id":"alice@m.x","role":"MEMBER"}`+"\n")
+		fmt.Fprint(w, `{"major":2, "minor":0}`)
+	})
+
+	httpStatusCode, err := c.AddMember(context.Background(), "foo", "alice@m.x", RoleMember)
+	if err != nil {
+		t.Fatal(err)
+	}
+	testStatusCode(t, httpStatusCode, 200)
+
+	if _, err := c.AddMember(context.Background(), "foo", "alice@m.x", ProjectRole(0)); err == nil {
+		t.Errorf("AddMember succeeded with an invalid role")
+	}
+}
+
+func TestUpdateMemberRole(t *testing.T) {
+	c, mux, teardown := setup()
+	defer teardown()
+
+	mux.HandleFunc("/api/v1/metadata/foo/members/alice@m.x", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, http.MethodPatch)
+		testHeader(t, r, "Content-Type", "application/json-patch+json")
+		testBody(t, r, `[{"op":"replace","path":"/role","value":"OWNER"}]`+"\n")
+		fmt.Fprint(w, `{"major":3, "minor":0}`)
+	})
+
+	httpStatusCode, err := c.UpdateMemberRole(context.Background(), "foo", "alice@m.x", RoleOwner)
+	if err != nil {
+		t.Fatal(err)
+	}
+	testStatusCode(t, httpStatusCode, 200)
+
+	if _, err := c.UpdateMemberRole(context.Background(), "foo", "alice@m.x", ProjectRole(4)); err == nil {
+		t.Errorf("UpdateMemberRole succeeded with an invalid role")
+	}
+}
+
+func TestRemoveMember(t *testing.T) {
+	c, mux, teardown := setup()
+	defer teardown()
+
+	mux.HandleFunc("/api/v1/metadata/foo/members/alice@m.x", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, http.MethodDelete)
+		w.WriteHeader(http.StatusNoContent)
+	})
+
+	httpStatusCode, err := c.RemoveMember(context.Background(), "foo", "alice@m.x")
+	if err != nil {
+		t.Fatal(err)
+	}
+	testStatusCode(t, httpStatusCode, 204)
+}
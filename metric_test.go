@@ -86,10 +86,19 @@ func TestMetricCollector(t *testing.T) {
 
 	sink := globalPrometheusSink.(*promMetrics.PrometheusSink)
 
-	ch := make(chan prometheus.Metric, 100)
-	sink.Collect(ch)
-
-	if metric, ok := <-ch; !ok || metric == nil {
+	// The global sink keeps a metric for each request path of every test in this package which used setup(),
+	// which is more than a buffered channel of a fixed size can hold. Collect blocks until all of them are
+	// received, so they are drained while being collected.
+	ch := make(chan prometheus.Metric)
+	go func() {
+		sink.Collect(ch)
+		close(ch)
+	}()
+
+	metric, ok := <-ch
+	for range ch {
+	}
+	if !ok || metric == nil {
 		t.Fatal()
 	}
 }
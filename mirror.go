@@ -0,0 +1,430 @@
+// Copyright 2026 LINE Corporation
+//
+// LINE Corporation licenses this file to you under the Apache License,
+// version 2.0 (the "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at:
+//
+//   https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+// WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+// License for the specific language governing permissions and limitations
+// under the License.
+
+package centraldogma
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+const (
+	metaRepo        = "meta"
+	mirrorsPath     = "/mirrors.json"
+	credentialsPath = "/credentials.json"
+
+	defaultMirrorType = "single"
+)
+
+var (
+	configIDPattern = regexp.MustCompile(`^[a-zA-Z0-9][a-zA-Z0-9_.\-]*$`)
+
+	mirrorSchemes = map[string]bool{"git": true, "git+http": true, "git+https": true, "git+ssh": true}
+)
+
+type mirrorService service
+
+// Mirror represents a mirror which copies a Git repository into a Central Dogma repository, or vice versa.
+// The mirrors of a project are stored in the /mirrors.json of its meta repository.
+type Mirror struct {
+	ID   string `json:"id"`
+	Type string `json:"type,omitempty"` // "single" by default
+	// Enabled specifies whether the mirror is run. The mirror is run if it is nil.
+	Enabled *bool `json:"enabled,omitempty"`
+	// Schedule is the Quartz cron expression which specifies when the mirror is run, e.g. "0 * * * * ?".
+	Schedule  string          `json:"schedule"`
+	Direction MirrorDirection `json:"direction"`
+	// LocalRepo and LocalPath are the repository and the directory in the project which are mirrored.
+	LocalRepo string `json:"localRepo"`
+	LocalPath string `json:"localPath,omitempty"`
+	// RemoteURI is the URI of the Git repository, the path in it and the branch,
+	// e.g. "git+ssh://github.com/foo/bar.git/settings#main".
+	RemoteURI string `json:"remoteUri"`
+	// CredentialID is the ID of the Credential which is used to access the Git repository.
+	CredentialID string `json:"credentialId,omitempty"`
+}
+
+// Validate returns an error if the mirror is not valid.
+func (m *Mirror) Validate() error {
+	if !configIDPattern.MatchString(m.ID) {
+		return fmt.Errorf("invalid mirror ID: %q", m.ID)
+	}
+	if len(m.Type) != 0 && m.Type != defaultMirrorType {
+		return fmt.Errorf("invalid type of the mirror %s: %q", m.ID, m.Type)
+	}
+	// A Quartz cron expression has 6 or 7 fields.
+	if fields := strings.Fields(m.Schedule); len(fields) != 6 && len(fields) != 7 {
+		return fmt.Errorf("invalid schedule of the mirror %s: %q", m.ID, m.Schedule)
+	}
+	if m.Direction != RemoteToLocal && m.Direction != LocalToRemote {
+		return fmt.Errorf("invalid direction of the mirror %s: %v", m.ID, m.Direction)
+	}
+	if len(m.LocalRepo) == 0 || m.LocalRepo == metaRepo {
+		return fmt.Errorf("invalid local repository of the mirror %s: %q", m.ID, m.LocalRepo)
+	}
+	if len(m.LocalPath) != 0 && !strings.HasPrefix(m.LocalPath, "/") {
+		return fmt.Errorf("local path of the mirror %s must be absolute: %q", m.ID, m.LocalPath)
+	}
+	u, err := url.Parse(m.RemoteURI)
+	if err != nil || !mirrorSchemes[u.Scheme] || len(u.Host) == 0 || !strings.Contains(u.Path, ".git") {
+		return fmt.Errorf("invalid remote URI of the mirror %s: %q", m.ID, m.RemoteURI)
+	}
+	if len(m.CredentialID) != 0 && !configIDPattern.MatchString(m.CredentialID) {
+		return fmt.Errorf("invalid credential ID of the mirror %s: %q", m.ID, m.CredentialID)
+	}
+	return nil
+}
+
+// withDefaults returns a copy of the mirror whose unspecified Type is filled.
+func (m *Mirror) withDefaults() *Mirror {
+	copied := *m
+	if len(copied.Type) == 0 {
+		copied.Type = defaultMirrorType
+	}
+	return &copied
+}
+
+// Credential represents a credential which is used to access a remote Git repository.
+// The credentials of a project are stored in the /credentials.json of its meta repository.
+type Credential struct {
+	ID   string         `json:"id"`
+	Type CredentialType `json:"type"`
+	// HostnamePatterns are the regular expressions of the hosts which the credential is used for.
+	HostnamePatterns []string `json:"hostnamePatterns,omitempty"`
+	Username         string   `json:"username,omitempty"`
+	Password         string   `json:"password,omitempty"`
+	PublicKey        string   `json:"publicKey,omitempty"`
+	PrivateKey       string   `json:"privateKey,omitempty"`
+	Passphrase       string   `json:"passphrase,omitempty"`
+	AccessToken      string   `json:"accessToken,omitempty"`
+}
+
+// Validate returns an error if the credential is not valid.
+func (c *Credential) Validate() error {
+	if !configIDPattern.MatchString(c.ID) {
+		return fmt.Errorf("invalid credential ID: %q", c.ID)
+	}
+	for _, pattern := range c.HostnamePatterns {
+		if _, err := regexp.Compile(pattern); err != nil {
+			return fmt.Errorf("invalid hostname pattern of the credential %s: %v", c.ID, err)
+		}
+	}
+
+	var missing string
+	switch c.Type {
+	case CredentialNone:
+	case CredentialPassword:
+		if len(c.Username) == 0 {
+			missing = "username"
+		} else if len(c.Password) == 0 {
+			missing = "password"
+		}
+	case CredentialPublicKey:
+		if len(c.Username) == 0 {
+			missing = "username"
+		} else if len(c.PublicKey) == 0 {
+			missing = "publicKey"
+		} else if len(c.PrivateKey) == 0 {
+			missing = "privateKey"
+		}
+	case CredentialAccessToken:
+		if len(c.AccessToken) == 0 {
+			missing = "accessToken"
+		}
+	default:
+		return fmt.Errorf("invalid type of the credential %s: %v", c.ID, c.Type)
+	}
+	if len(missing) != 0 {
+		return fmt.Errorf("%s of the %v credential %s must not be empty", missing, c.Type, c.ID)
+	}
+	return nil
+}
+
+func (m *mirrorService) listMirrors(ctx context.Context, projectName string) ([]*Mirror, int, error) {
+	revision, httpStatusCode, err := m.headRevision(ctx, projectName)
+	if err != nil {
+		return nil, httpStatusCode, err
+	}
+	var mirrors []*Mirror
+	if httpStatusCode, err = m.readMetaFile(ctx, projectName, revision, mirrorsPath, &mirrors); err != nil {
+		return nil, httpStatusCode, err
+	}
+	return mirrors, httpStatusCode, nil
+}
+
+func (m *mirrorService) createMirror(ctx context.Context,
+	projectName string, mirror *Mirror) (*PushResult, int, error) {
+	mirror = mirror.withDefaults()
+	if err := mirror.Validate(); err != nil {
+		return nil, UnknownHttpStatusCode, err
+	}
+	return m.updateMirrors(ctx, projectName, mirror, func(entries configEntries) (configEntries, error) {
+		i, err := entries.indexOf(mirror.ID)
+		if err != nil {
+			return nil, err
+		}
+		if i >= 0 {
+			return nil, fmt.Errorf("mirror %s already exists in the project %s", mirror.ID, projectName)
+		}
+		return entries.append(mirror)
+	}, "Add the mirror "+mirror.ID)
+}
+
+func (m *mirrorService) updateMirror(ctx context.Context,
+	projectName string, mirror *Mirror) (*PushResult, int, error) {
+	mirror = mirror.withDefaults()
+	if err := mirror.Validate(); err != nil {
+		return nil, UnknownHttpStatusCode, err
+	}
+	return m.updateMirrors(ctx, projectName, mirror, func(entries configEntries) (configEntries, error) {
+		i, err := entries.indexOf(mirror.ID)
+		if err != nil {
+			return nil, err
+		}
+		if i < 0 {
+			return nil, fmt.Errorf("mirror %s does not exist in the project %s", mirror.ID, projectName)
+		}
+		return entries.replace(i, mirror)
+	}, "Update the mirror "+mirror.ID)
+}
+
+func (m *mirrorService) deleteMirror(ctx context.Context, projectName, id string) (*PushResult, int, error) {
+	return m.updateMirrors(ctx, projectName, nil, func(entries configEntries) (configEntries, error) {
+		i, err := entries.indexOf(id)
+		if err != nil {
+			return nil, err
+		}
+		if i < 0 {
+			return nil, fmt.Errorf("mirror %s does not exist in the project %s", id, projectName)
+		}
+		return entries.remove(i), nil
+	}, "Delete the mirror "+id)
+}
+
+// updateMirrors pushes the mirrors modified by the function. If the mirror, which is nil when a mirror is
+// deleted, has a credential, the credential must exist at the same revision as the mirrors.
+func (m *mirrorService) updateMirrors(ctx context.Context, projectName string, mirror *Mirror,
+	modify func(configEntries) (configEntries, error), summary string) (*PushResult, int, error) {
+	revision, httpStatusCode, err := m.headRevision(ctx, projectName)
+	if err != nil {
+		return nil, httpStatusCode, err
+	}
+	if mirror != nil && len(mirror.CredentialID) != 0 {
+		var credentials []*Credential
+		httpStatusCode, err := m.readMetaFile(ctx, projectName, revision, credentialsPath, &credentials)
+		if err != nil {
+			return nil, httpStatusCode, err
+		}
+		if indexOfCredential(credentials, mirror.CredentialID) < 0 {
+			return nil, UnknownHttpStatusCode, fmt.Errorf("credential %s of the mirror %s does not exist",
+				mirror.CredentialID, mirror.ID)
+		}
+	}
+	return m.updateMetaFile(ctx, projectName, revision, mirrorsPath, modify, summary)
+}
+
+func (m *mirrorService) listCredentials(ctx context.Context, projectName string) ([]*Credential, int, error) {
+	revision, httpStatusCode, err := m.headRevision(ctx, projectName)
+	if err != nil {
+		return nil, httpStatusCode, err
+	}
+	var credentials []*Credential
+	if httpStatusCode, err = m.readMetaFile(ctx, projectName, revision, credentialsPath, &credentials); err != nil {
+		return nil, httpStatusCode, err
+	}
+	return credentials, httpStatusCode, nil
+}
+
+func (m *mirrorService) createCredential(ctx context.Context,
+	projectName string, credential *Credential) (*PushResult, int, error) {
+	if err := credential.Validate(); err != nil {
+		return nil, UnknownHttpStatusCode, err
+	}
+	return m.updateCredentials(ctx, projectName, func(entries configEntries) (configEntries, error) {
+		i, err := entries.indexOf(credential.ID)
+		if err != nil {
+			return nil, err
+		}
+		if i >= 0 {
+			return nil, fmt.Errorf("credential %s already exists in the project %s", credential.ID, projectName)
+		}
+		return entries.append(credential)
+	}, "Add the credential "+credential.ID)
+}
+
+func (m *mirrorService) updateCredential(ctx context.Context,
+	projectName string, credential *Credential) (*PushResult, int, error) {
+	if err := credential.Validate(); err != nil {
+		return nil, UnknownHttpStatusCode, err
+	}
+	return m.updateCredentials(ctx, projectName, func(entries configEntries) (configEntries, error) {
+		i, err := entries.indexOf(credential.ID)
+		if err != nil {
+			return nil, err
+		}
+		if i < 0 {
+			return nil, fmt.Errorf("credential %s does not exist in the project %s", credential.ID, projectName)
+		}
+		return entries.replace(i, credential)
+	}, "Update the credential "+credential.ID)
+}
+
+func (m *mirrorService) deleteCredential(ctx context.Context, projectName, id string) (*PushResult, int, error) {
+	// The mirrors and the credentials are read at the same revision, which the deletion is pushed on top of,
+	// so that the push fails if a mirror starts to use the credential in the meantime.
+	revision, httpStatusCode, err := m.headRevision(ctx, projectName)
+	if err != nil {
+		return nil, httpStatusCode, err
+	}
+	var mirrors []*Mirror
+	if httpStatusCode, err = m.readMetaFile(ctx, projectName, revision, mirrorsPath, &mirrors); err != nil {
+		return nil, httpStatusCode, err
+	}
+	for _, mirror := range mirrors {
+		if mirror.CredentialID == id {
+			return nil, UnknownHttpStatusCode, fmt.Errorf("credential %s is used by the mirror %s", id, mirror.ID)
+		}
+	}
+
+	return m.updateMetaFile(ctx, projectName, revision, credentialsPath,
+		func(entries configEntries) (configEntries, error) {
+			i, err := entries.indexOf(id)
+			if err != nil {
+				return nil, err
+			}
+			if i < 0 {
+				return nil, fmt.Errorf("credential %s does not exist in the project %s", id, projectName)
+			}
+			return entries.remove(i), nil
+		}, "Delete the credential "+id)
+}
+
+// updateCredentials pushes the credentials modified by the function.
+func (m *mirrorService) updateCredentials(ctx context.Context, projectName string,
+	modify func(configEntries) (configEntries, error), summary string) (*PushResult, int, error) {
+	revision, httpStatusCode, err := m.headRevision(ctx, projectName)
+	if err != nil {
+		return nil, httpStatusCode, err
+	}
+	return m.updateMetaFile(ctx, projectName, revision, credentialsPath, modify, summary)
+}
+
+// headRevision returns the latest revision of the meta repository of the project.
+func (m *mirrorService) headRevision(ctx context.Context, projectName string) (int, int, error) {
+	return m.client.repository.normalizeRevision(ctx, projectName, metaRepo, "-1")
+}
+
+// readMetaFile reads the JSON file in the meta repository of the project at the revision into v.
+// v is not modified if the file does not exist.
+func (m *mirrorService) readMetaFile(ctx context.Context,
+	projectName string, revision int, filePath string, v interface{}) (int, error) {
+	entry, httpStatusCode, err := m.client.content.getFile(ctx, projectName, metaRepo, strconv.Itoa(revision),
+		&Query{Path: filePath, Type: Identity})
+	if err != nil {
+		if httpStatusCode == http.StatusNotFound {
+			return httpStatusCode, nil
+		}
+		return httpStatusCode, err
+	}
+	if err := json.Unmarshal(entry.Content, v); err != nil {
+		return httpStatusCode, fmt.Errorf("failed to parse /%s/%s%s: %v", projectName, metaRepo, filePath, err)
+	}
+	return httpStatusCode, nil
+}
+
+// updateMetaFile reads the JSON array file in the meta repository of the project at the revision, and pushes
+// the entries modified by the function. The push fails if the file was modified after the revision.
+func (m *mirrorService) updateMetaFile(ctx context.Context, projectName string, revision int, filePath string,
+	modify func(configEntries) (configEntries, error), summary string) (*PushResult, int, error) {
+	var entries configEntries
+	if httpStatusCode, err := m.readMetaFile(ctx, projectName, revision, filePath, &entries); err != nil {
+		return nil, httpStatusCode, err
+	}
+	entries, err := modify(entries)
+	if err != nil {
+		return nil, UnknownHttpStatusCode, err
+	}
+	if entries == nil {
+		entries = configEntries{}
+	}
+	change := &Change{Path: filePath, Type: UpsertJSON, Content: entries}
+	return m.client.content.push(ctx, projectName, metaRepo, strconv.Itoa(revision),
+		&CommitMessage{Summary: summary}, []*Change{change})
+}
+
+// configEntries are the entries of a JSON array file in the meta repository. The entries are kept as they
+// are, so that the entries which are not modified are pushed back with the fields which Mirror and Credential
+// do not have, and without the fields which are absent.
+type configEntries []json.RawMessage
+
+// indexOf returns the index of the entry which has the ID, or -1 if there is no such entry.
+func (entries configEntries) indexOf(id string) (int, error) {
+	for i, entry := range entries {
+		var e struct {
+			ID string `json:"id"`
+		}
+		if err := json.Unmarshal(entry, &e); err != nil {
+			return -1, fmt.Errorf("failed to parse the entry %d: %v", i, err)
+		}
+		if e.ID == id {
+			return i, nil
+		}
+	}
+	return -1, nil
+}
+
+func (entries configEntries) append(v interface{}) (configEntries, error) {
+	entry, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+	return append(entries, entry), nil
+}
+
+func (entries configEntries) replace(i int, v interface{}) (configEntries, error) {
+	entry, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+	entries[i] = entry
+	return entries, nil
+}
+
+func (entries configEntries) remove(i int) configEntries {
+	return append(entries[:i], entries[i+1:]...)
+}
+
+func indexOfMirror(mirrors []*Mirror, id string) int {
+	for i, mirror := range mirrors {
+		if mirror.ID == id {
+			return i
+		}
+	}
+	return -1
+}
+
+func indexOfCredential(credentials []*Credential, id string) int {
+	for i, credential := range credentials {
+		if credential.ID == id {
+			return i
+		}
+	}
+	return -1
+}
@@ -0,0 +1,248 @@
+// Copyright 2026 LINE Corporation
+//
+// LINE Corporation licenses this file to you under the Apache License,
+// version 2.0 (the "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at:
+//
+//   https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+// WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+// License for the specific language governing permissions and limitations
+// under the License.
+
+package centraldogma
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"reflect"
+	"testing"
+)
+
+const (
+	testMirrors = `[{"id":"settings", "type":"single", "enabled":true, "schedule":"0 * * * * ?",
+"direction":"REMOTE_TO_LOCAL", "localRepo":"bar", "localPath":"/", "remoteUri":"git+ssh://github.com/foo/bar.git#main",
+"credentialId":"github"}]`
+	testCredentials = `[{"id":"github", "type":"access_token", "accessToken":"secret"}]`
+)
+
+// handleMetaRepository serves the meta repository of the project foo, whose latest revision is 3.
+// The files which are not in the contents do not exist. It returns the changes pushed to it.
+func handleMetaRepository(t *testing.T, mux *http.ServeMux, contents map[string]string) *[]*Change {
+	var pushed []*Change
+	mux.HandleFunc("/api/v1/projects/foo/repos/meta/revision/-1", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"revision":3}`)
+	})
+	mux.HandleFunc("/api/v1/projects/foo/repos/meta/contents/", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, http.MethodGet)
+		testURLQuery(t, r, "revision", "3")
+		filePath := r.URL.Path[len("/api/v1/projects/foo/repos/meta/contents"):]
+		content, ok := contents[filePath]
+		if !ok {
+			w.WriteHeader(http.StatusNotFound)
+			fmt.Fprint(w, `{"exception":"EntryNotFoundException", "message":"not found"}`)
+			return
+		}
+		fmt.Fprintf(w, `{"path":%q, "type":"JSON", "revision":3, "content":%s}`, filePath, content)
+	})
+	mux.HandleFunc("/api/v1/projects/foo/repos/meta/contents", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, http.MethodPost)
+		testURLQuery(t, r, "revision", "3")
+		var reqBody push
+		_ = json.NewDecoder(r.Body).Decode(&reqBody)
+		pushed = append(pushed, reqBody.Changes...)
+		fmt.Fprint(w, `{"revision":4, "pushedAt":"2026-01-02T03:04:05Z"}`)
+	})
+	return &pushed
+}
+
+func TestListMirrors(t *testing.T) {
+	c, mux, teardown := setup()
+	defer teardown()
+	handleMetaRepository(t, mux, map[string]string{"/mirrors.json": testMirrors})
+
+	mirrors, _, err := c.ListMirrors(context.Background(), "foo")
+	if err != nil {
+		t.Fatal(err)
+	}
+	enabled := true
+	want := []*Mirror{{ID: "settings", Type: "single", Enabled: &enabled, Schedule: "0 * * * * ?",
+		Direction: RemoteToLocal, LocalRepo: "bar", LocalPath: "/",
+		RemoteURI: "git+ssh://github.com/foo/bar.git#main", CredentialID: "github"}}
+	if !reflect.DeepEqual(mirrors, want) {
+		t.Errorf("ListMirrors returned %+v, want %+v", mirrors, want)
+	}
+
+	// The project has no credentials.
+	credentials, _, err := c.ListCredentials(context.Background(), "foo")
+	if err != nil || len(credentials) != 0 {
+		t.Errorf("ListCredentials returned %+v, %v, want no credentials", credentials, err)
+	}
+}
+
+func TestCreateMirror(t *testing.T) {
+	c, mux, teardown := setup()
+	defer teardown()
+	pushed := handleMetaRepository(t, mux, map[string]string{"/credentials.json": testCredentials})
+
+	enabled := true
+	mirror := &Mirror{ID: "settings", Enabled: &enabled, Schedule: "0 * * * * ?", Direction: RemoteToLocal,
+		LocalRepo: "bar", RemoteURI: "git+https://github.com/foo/bar.git#main", CredentialID: "github"}
+	result, _, err := c.CreateMirror(context.Background(), "foo", mirror)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if result.Revision != 4 {
+		t.Errorf("CreateMirror returned %+v, want the revision 4", result)
+	}
+
+	want := []*Change{{Path: "/mirrors.json", Type: UpsertJSON, Content: []interface{}{map[string]interface{}{
+		"id": "settings", "type": "single", "enabled": true, "schedule": "0 * * * * ?",
+		"direction": "REMOTE_TO_LOCAL", "localRepo": "bar", "remoteUri": "git+https://github.com/foo/bar.git#main",
+		"credentialId": "github",
+	}}}}
+	if !reflect.DeepEqual(*pushed, want) {
+		t.Errorf("CreateMirror pushed %+v, want %+v", *pushed, want)
+	}
+
+	mirror.CredentialID = "gitlab"
+	if _, _, err := c.CreateMirror(context.Background(), "foo", mirror); err == nil {
+		t.Errorf("CreateMirror succeeded with a missing credential")
+	}
+}
+
+func TestUpdateAndDeleteMirror(t *testing.T) {
+	c, mux, teardown := setup()
+	defer teardown()
+	pushed := handleMetaRepository(t, mux, map[string]string{"/mirrors.json": testMirrors})
+
+	mirror := &Mirror{ID: "settings", Schedule: "0 0 * * * ?", Direction: LocalToRemote,
+		LocalRepo: "bar", RemoteURI: "git://github.com/foo/bar.git"}
+	if _, _, err := c.UpdateMirror(context.Background(), "foo", mirror); err != nil {
+		t.Fatal(err)
+	}
+	if _, _, err := c.DeleteMirror(context.Background(), "foo", "settings"); err != nil {
+		t.Fatal(err)
+	}
+	if len(*pushed) != 2 {
+		t.Fatalf("pushed %+v, want 2 changes", *pushed)
+	}
+	if updated := (*pushed)[0].Content.([]interface{})[0].(map[string]interface{}); updated["enabled"] != nil ||
+		updated["direction"] != "LOCAL_TO_REMOTE" {
+		t.Errorf("UpdateMirror pushed %+v", updated)
+	}
+	if deleted := (*pushed)[1].Content; !reflect.DeepEqual(deleted, []interface{}{}) {
+		t.Errorf("DeleteMirror pushed %+v, want an empty array", deleted)
+	}
+
+	if _, _, err := c.DeleteMirror(context.Background(), "foo", "missing"); err == nil {
+		t.Errorf("DeleteMirror succeeded with a missing mirror")
+	}
+}
+
+func TestUpdateMirror_KeepOtherEntries(t *testing.T) {
+	c, mux, teardown := setup()
+	defer teardown()
+
+	other := `{"id":"other","schedule":"0 0 * * * ?","direction":"LOCAL_TO_REMOTE","localRepo":"baz",` +
+		`"remoteUri":"git://github.com/foo/baz.git","gitignore":["/target"],"zone":"us"}`
+	mux.HandleFunc("/api/v1/projects/foo/repos/meta/revision/-1", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"revision":3}`)
+	})
+	mux.HandleFunc("/api/v1/projects/foo/repos/meta/contents/mirrors.json", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprintf(w, `{"path":"/mirrors.json", "type":"JSON", "revision":3, "content":[%s, %s]}`,
+			other, `{"id":"settings","schedule":"0 * * * * ?"}`)
+	})
+	var pushed []json.RawMessage
+	mux.HandleFunc("/api/v1/projects/foo/repos/meta/contents", func(w http.ResponseWriter, r *http.Request) {
+		var reqBody struct {
+			Changes []struct {
+				Content []json.RawMessage `json:"content"`
+			} `json:"changes"`
+		}
+		_ = json.NewDecoder(r.Body).Decode(&reqBody)
+		pushed = reqBody.Changes[0].Content
+		fmt.Fprint(w, `{"revision":4, "pushedAt":"2026-01-02T03:04:05Z"}`)
+	})
+
+	mirror := &Mirror{ID: "settings", Schedule: "0 0 * * * ?", Direction: RemoteToLocal,
+		LocalRepo: "bar", RemoteURI: "git://github.com/foo/bar.git"}
+	if _, _, err := c.UpdateMirror(context.Background(), "foo", mirror); err != nil {
+		t.Fatal(err)
+	}
+	if len(pushed) != 2 || string(pushed[0]) != other {
+		t.Errorf("UpdateMirror pushed %s, want the other mirror as it is: %s", pushed, other)
+	}
+	if want := `{"id":"settings","type":"single","schedule":"0 0 * * * ?","direction":"REMOTE_TO_LOCAL",` +
+		`"localRepo":"bar","remoteUri":"git://github.com/foo/bar.git"}`; len(pushed) == 2 && string(pushed[1]) != want {
+		t.Errorf("UpdateMirror pushed %s, want %s", pushed[1], want)
+	}
+}
+
+func TestCredentials(t *testing.T) {
+	c, mux, teardown := setup()
+	defer teardown()
+	pushed := handleMetaRepository(t, mux, map[string]string{
+		"/mirrors.json": testMirrors, "/credentials.json": testCredentials})
+
+	credential := &Credential{ID: "gitlab", Type: CredentialPassword, Username: "minux", Password: "secret"}
+	if _, _, err := c.CreateCredential(context.Background(), "foo", credential); err != nil {
+		t.Fatal(err)
+	}
+	if got := len((*pushed)[0].Content.([]interface{})); got != 2 {
+		t.Errorf("CreateCredential pushed %v credentials, want 2", got)
+	}
+	duplicate := &Credential{ID: "github", Type: CredentialNone}
+	if _, _, err := c.CreateCredential(context.Background(), "foo", duplicate); err == nil {
+		t.Errorf("CreateCredential succeeded with a duplicate ID")
+	}
+	// The credential is used by the mirror.
+	if _, _, err := c.DeleteCredential(context.Background(), "foo", "github"); err == nil {
+		t.Errorf("DeleteCredential succeeded with a credential in use")
+	}
+}
+
+func TestValidateMirrorAndCredential(t *testing.T) {
+	valid := Mirror{ID: "settings", Schedule: "0 * * * * ?", Direction: RemoteToLocal, LocalRepo: "bar",
+		RemoteURI: "git+ssh://github.com/foo/bar.git"}
+	if err := valid.Validate(); err != nil {
+		t.Errorf("Validate returned %v for %+v", err, valid)
+	}
+	invalid := []func(m *Mirror){
+		func(m *Mirror) { m.ID = "" },
+		func(m *Mirror) { m.Schedule = "* * *" },
+		func(m *Mirror) { m.Direction = 0 },
+		func(m *Mirror) { m.LocalRepo = "meta" },
+		func(m *Mirror) { m.LocalPath = "settings" },
+		func(m *Mirror) { m.RemoteURI = "https://github.com/foo/bar.git" },
+		func(m *Mirror) { m.RemoteURI = "git+ssh://github.com/foo/bar" },
+	}
+	for i, modify := range invalid {
+		m := valid
+		modify(&m)
+		if err := m.Validate(); err == nil {
+			t.Errorf("%d: Validate succeeded for %+v", i, m)
+		}
+	}
+
+	var tests = []struct {
+		credential Credential
+		valid      bool
+	}{
+		{Credential{ID: "a", Type: CredentialNone}, true},
+		{Credential{ID: "a", Type: CredentialPassword, Username: "u"}, false},
+		{Credential{ID: "a", Type: CredentialPublicKey, Username: "u", PublicKey: "p", PrivateKey: "p"}, true},
+		{Credential{ID: "a", Type: CredentialAccessToken}, false},
+		{Credential{ID: "a", Type: CredentialNone, HostnamePatterns: []string{"("}}, false},
+		{Credential{ID: "a"}, false},
+	}
+	for _, test := range tests {
+		if err := test.credential.Validate(); (err == nil) != test.valid {
+			t.Errorf("Validate returned %v for %+v", err, test.credential)
+		}
+	}
+}
@@ -16,9 +16,11 @@ package centraldogma
 
 import (
 	"context"
+	"fmt"
 	"net/http"
 	"net/url"
 	"path"
+	"strconv"
 )
 
 type repositoryService service
@@ -208,6 +210,120 @@ func (r *repositoryService) normalizeRevision(
 	return rev.Rev, httpStatusCode, nil
 }
 
+// absoluteRevision is like normalizeRevision but does not send a request if the revision is already absolute.
+func (r *repositoryService) absoluteRevision(
+	ctx context.Context, projectName, repoName, revision string) (int, int, error) {
+	if rev, err := strconv.Atoi(revision); err == nil && rev > 0 {
+		return rev, UnknownHttpStatusCode, nil
+	}
+	return r.normalizeRevision(ctx, projectName, repoName, revision)
+}
+
 type rev struct {
 	Rev int `json:"revision"`
 }
+
+// RepositoryPermissions represents who can read or write a repository.
+type RepositoryPermissions struct {
+	PerRolePermissions PerRolePermissions `json:"perRolePermissions"`
+	// PerUserPermissions is the permissions of the users, keyed by their login IDs.
+	PerUserPermissions map[string][]Permission `json:"perUserPermissions,omitempty"`
+	// PerTokenPermissions is the permissions of the application tokens, keyed by their application IDs.
+	PerTokenPermissions map[string][]Permission `json:"perTokenPermissions,omitempty"`
+}
+
+// PerRolePermissions represents the permissions of the project roles on a repository.
+type PerRolePermissions struct {
+	Owner  []Permission `json:"owner"`
+	Member []Permission `json:"member"`
+	Guest  []Permission `json:"guest"`
+}
+
+func (r *repositoryService) getPermissions(ctx context.Context,
+	projectName, repoName string) (*RepositoryPermissions, int, error) {
+	projectMetadata, httpStatusCode, err := r.client.metadata.getProjectMetadata(ctx, projectName)
+	if err != nil {
+		return nil, httpStatusCode, err
+	}
+	repoMetadata, ok := projectMetadata.Repos[repoName]
+	if !ok {
+		return nil, httpStatusCode, fmt.Errorf("no metadata of the repository /%s/%s", projectName, repoName)
+	}
+	return &repoMetadata.RepositoryPermissions, httpStatusCode, nil
+}
+
+func (r *repositoryService) setRolePermissions(ctx context.Context,
+	projectName, repoName string, permissions PerRolePermissions) (int, error) {
+	u, err := url.Parse(path.Join(
+		defaultPathPrefix,
+		metadata, projectName,
+		repos, repoName,
+		actionPermission, "role",
+	))
+	if err != nil {
+		return UnknownHttpStatusCode, err
+	}
+
+	body := PerRolePermissions{
+		Owner:  nonNilPermissions(permissions.Owner),
+		Member: nonNilPermissions(permissions.Member),
+		Guest:  nonNilPermissions(permissions.Guest),
+	}
+	req, err := r.client.newRequest(http.MethodPost, u, body)
+	if err != nil {
+		return UnknownHttpStatusCode, err
+	}
+	return r.client.do(ctx, req, nil, false)
+}
+
+// addPermission grants the permissions to a user or an application token. The kind is
+// either users or tokens.
+func (r *repositoryService) addPermission(ctx context.Context,
+	projectName, repoName, kind, id string, permissions []Permission) (int, error) {
+	u, err := url.Parse(path.Join(
+		defaultPathPrefix,
+		metadata, projectName,
+		repos, repoName,
+		actionPermission, kind,
+	))
+	if err != nil {
+		return UnknownHttpStatusCode, err
+	}
+
+	body := map[string]interface{}{"id": id, "permissions": nonNilPermissions(permissions)}
+	req, err := r.client.newRequest(http.MethodPost, u, body)
+	if err != nil {
+		return UnknownHttpStatusCode, err
+	}
+	return r.client.do(ctx, req, nil, false)
+}
+
+// removePermission revokes the permissions of a user or an application token. The kind is
+// either users or tokens.
+func (r *repositoryService) removePermission(ctx context.Context,
+	projectName, repoName, kind, id string) (int, error) {
+	u, err := url.Parse(path.Join(
+		defaultPathPrefix,
+		metadata, projectName,
+		repos, repoName,
+		actionPermission, kind, id,
+	))
+	if err != nil {
+		return UnknownHttpStatusCode, err
+	}
+
+	req, err := r.client.newRequest(http.MethodDelete, u, nil)
+	if err != nil {
+		return UnknownHttpStatusCode, err
+	}
+	return r.client.do(ctx, req, nil, false)
+}
+
+// nonNilPermissions returns an empty slice if the permissions are nil, so that they are encoded as
+// an empty JSON array rather than null.
+func nonNilPermissions(permissions []Permission) []Permission {
+	if permissions == nil {
+		return []Permission{}
+	}
+	return permissions
+}
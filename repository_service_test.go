@@ -163,3 +163,94 @@ func TestNormalizeRevision(t *testing.T) {
 		t.Errorf("NormalizeRevision returned %v, want %v", normalizedRevision, want)
 	}
 }
+
+func TestGetRepositoryPermissions(t *testing.T) {
+	c, mux, teardown := setup()
+	defer teardown()
+
+	mux.HandleFunc("/api/v1/projects/foo", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, http.MethodGet)
+		fmt.Fprint(w, `{"name":"foo", "repos":{"bar":{"name":"bar",
+"perRolePermissions":{"owner":["READ", "WRITE"], "member":["READ"], "guest":[]},
+"perUserPermissions":{"alice@m.x":["READ", "WRITE"]},
+"perTokenPermissions":{"ci":["READ"]}}}}`)
+	})
+
+	permissions, _, err := c.GetRepositoryPermissions(context.Background(), "foo", "bar")
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := &RepositoryPermissions{
+		PerRolePermissions: PerRolePermissions{
+			Owner:  []Permission{PermissionRead, PermissionWrite},
+			Member: []Permission{PermissionRead},
+			Guest:  []Permission{},
+		},
+		PerUserPermissions:  map[string][]Permission{"alice@m.x": {PermissionRead, PermissionWrite}},
+		PerTokenPermissions: map[string][]Permission{"ci": {PermissionRead}},
+	}
+	if !reflect.DeepEqual(permissions, want) {
+		t.Errorf("GetRepositoryPermissions returned %+v, want %+v", permissions, want)
+	}
+
+	if _, _, err := c.GetRepositoryPermissions(context.Background(), "foo", "baz"); err == nil {
+		t.Errorf("GetRepositoryPermissions succeeded for a missing repository")
+	}
+}
+
+func TestSetRolePermissions(t *testing.T) {
+	c, mux, teardown := setup()
+	defer teardown()
+
+	mux.HandleFunc("/api/v1/metadata/foo/repos/bar/perm/role", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, http.MethodPost)
+		testBody(t, r, `{"owner":["READ","WRITE"],"member":["READ"],"guest":[]}`+"\n")
+		fmt.Fprint(w, `{"major":2, "minor":0}`)
+	})
+
+	httpStatusCode, err := c.SetRolePermissions(context.Background(), "foo", "bar", PerRolePermissions{
+		Owner:  []Permission{PermissionRead, PermissionWrite},
+		Member: []Permission{PermissionRead},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	testStatusCode(t, httpStatusCode, 200)
+}
+
+func TestUserAndTokenPermission(t *testing.T) {
+	c, mux, teardown := setup()
+	defer teardown()
+
+	for _, kind := range []string{"users", "tokens"} {
+		mux.HandleFunc("/api/v1/metadata/foo/repos/bar/perm/"+kind, func(w http.ResponseWriter, r *http.Request) {
+			testMethod(t, r, http.MethodPost)
+			testBody(t, r, `{"id":"alice","permissions":["READ"]}`+"\n")
+			fmt.Fprint(w, `{"major":2, "minor":0}`)
+		})
+		mux.HandleFunc("/api/v1/metadata/foo/repos/bar/perm/"+kind+"/alice",
+			func(w http.ResponseWriter, r *http.Request) {
+				testMethod(t, r, http.MethodDelete)
+				w.WriteHeader(http.StatusNoContent)
+			})
+	}
+
+	ctx := context.Background()
+	read := []Permission{PermissionRead}
+	if _, err := c.AddUserPermission(ctx, "foo", "bar", "alice", read); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := c.GrantTokenRepositoryPermission(ctx, "foo", "bar", "alice", read); err != nil {
+		t.Fatal(err)
+	}
+	httpStatusCode, err := c.RemoveUserPermission(ctx, "foo", "bar", "alice")
+	if err != nil {
+		t.Fatal(err)
+	}
+	testStatusCode(t, httpStatusCode, 204)
+	httpStatusCode, err = c.RevokeTokenRepositoryPermission(ctx, "foo", "bar", "alice")
+	if err != nil {
+		t.Fatal(err)
+	}
+	testStatusCode(t, httpStatusCode, 204)
+}
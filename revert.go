@@ -0,0 +1,197 @@
+// Copyright 2026 LINE Corporation
+//
+// LINE Corporation licenses this file to you under the Apache License,
+// version 2.0 (the "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at:
+//
+//   https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+// WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+// License for the specific language governing permissions and limitations
+// under the License.
+
+package centraldogma
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+func (con *contentService) revert(ctx context.Context, projectName, repoName, revision string,
+	commitMessage *CommitMessage) (*PushResult, int, error) {
+	detail, httpStatusCode, err := con.getCommit(ctx, projectName, repoName, revision)
+	if err != nil {
+		return nil, httpStatusCode, err
+	}
+	rev := detail.Commit.Revision
+	if len(detail.Changes) == 0 {
+		return nil, UnknownHttpStatusCode, fmt.Errorf("the revision %v of /%s/%s has no changes to revert",
+			rev, projectName, repoName)
+	}
+
+	var paths []string
+	for _, change := range detail.Changes {
+		paths = append(paths, change.Path)
+		if change.Type == Rename {
+			paths = append(paths, fmt.Sprint(change.Content))
+		}
+	}
+	headRevision, httpStatusCode, err := con.checkUnchanged(ctx, projectName, repoName, rev, paths)
+	if err != nil {
+		return nil, httpStatusCode, err
+	}
+
+	var changes []*Change
+	for _, change := range detail.Changes {
+		if change.Type == Rename {
+			changes = append(changes, &Change{Path: fmt.Sprint(change.Content), Type: Rename, Content: change.Path})
+			continue
+		}
+		restored, httpStatusCode, err := con.restoreChange(ctx, projectName, repoName, rev-1, change.Path)
+		if err != nil {
+			return nil, httpStatusCode, err
+		}
+		changes = append(changes, restored)
+	}
+
+	if commitMessage == nil {
+		commitMessage = &CommitMessage{
+			Summary: fmt.Sprintf("Revert %q", detail.Commit.CommitMessage.Summary),
+			Detail:  fmt.Sprintf("This reverts the revision %v.", rev),
+		}
+	}
+	return con.push(ctx, projectName, repoName, strconv.Itoa(headRevision), commitMessage, changes)
+}
+
+func (con *contentService) rollback(ctx context.Context,
+	projectName, repoName, pathPattern, toRevision string) (*PushResult, int, error) {
+	to, httpStatusCode, err := con.client.repository.absoluteRevision(ctx, projectName, repoName, toRevision)
+	if err != nil {
+		return nil, httpStatusCode, err
+	}
+	headRevision, httpStatusCode, err := con.client.repository.normalizeRevision(ctx, projectName, repoName, "-1")
+	if err != nil {
+		return nil, httpStatusCode, err
+	}
+	if to >= headRevision {
+		return nil, UnknownHttpStatusCode, nil
+	}
+
+	diffs, httpStatusCode, err := con.getDiffs(ctx, projectName, repoName,
+		strconv.Itoa(to), strconv.Itoa(headRevision), pathPattern)
+	if err != nil {
+		return nil, httpStatusCode, err
+	}
+	if len(diffs) == 0 {
+		return nil, UnknownHttpStatusCode, nil
+	}
+	paths := make([]string, len(diffs))
+	for i, diff := range diffs {
+		paths[i] = diff.Path
+	}
+	changes, httpStatusCode, err := con.restoreChanges(ctx, projectName, repoName, to, paths)
+	if err != nil {
+		return nil, httpStatusCode, err
+	}
+
+	// The changes are pushed on top of the latest revision, unless a commit pushed while computing them
+	// changed any of the files.
+	baseRevision, httpStatusCode, err := con.checkUnchanged(ctx, projectName, repoName, headRevision, paths)
+	if err != nil {
+		return nil, httpStatusCode, err
+	}
+
+	if len(pathPattern) == 0 {
+		pathPattern = "/**"
+	}
+	commitMessage := &CommitMessage{Summary: fmt.Sprintf("Rollback %s to the revision %v", pathPattern, to)}
+	return con.push(ctx, projectName, repoName, strconv.Itoa(baseRevision), commitMessage, changes)
+}
+
+// checkUnchanged returns the latest revision after checking that no commit after the revision changed
+// any of the paths. ErrRevertConflict is returned with the changed paths otherwise.
+func (con *contentService) checkUnchanged(ctx context.Context,
+	projectName, repoName string, revision int, paths []string) (int, int, error) {
+	headRevision, httpStatusCode, err := con.client.repository.normalizeRevision(ctx, projectName, repoName, "-1")
+	if err != nil {
+		return 0, httpStatusCode, err
+	}
+	if headRevision <= revision {
+		return headRevision, httpStatusCode, nil
+	}
+
+	later, httpStatusCode, err := con.getDiffs(ctx, projectName, repoName,
+		strconv.Itoa(revision), strconv.Itoa(headRevision), strings.Join(paths, ","))
+	if err != nil {
+		return 0, httpStatusCode, err
+	}
+	if len(later) != 0 {
+		conflicts := make([]string, len(later))
+		for i, change := range later {
+			conflicts[i] = change.Path
+		}
+		return 0, UnknownHttpStatusCode, fmt.Errorf("%w: %s", ErrRevertConflict, strings.Join(conflicts, ", "))
+	}
+	return headRevision, httpStatusCode, nil
+}
+
+// restoreChanges returns the Changes which restore the files to their state at the revision. The files are
+// fetched with a single request.
+func (con *contentService) restoreChanges(ctx context.Context,
+	projectName, repoName string, revision int, paths []string) ([]*Change, int, error) {
+	if len(paths) == 1 {
+		// The server returns the entry rather than the list of the entries for the path of a single file.
+		change, httpStatusCode, err := con.restoreChange(ctx, projectName, repoName, revision, paths[0])
+		if err != nil {
+			return nil, httpStatusCode, err
+		}
+		return []*Change{change}, httpStatusCode, nil
+	}
+
+	entries, httpStatusCode, err := con.getFiles(ctx, projectName, repoName, strconv.Itoa(revision),
+		strings.Join(paths, ","))
+	if err != nil && !isException(err, "EntryNotFoundException") {
+		return nil, httpStatusCode, err
+	}
+	found := make(map[string]*Entry, len(entries))
+	for _, entry := range entries {
+		found[entry.Path] = entry
+	}
+	changes := make([]*Change, len(paths))
+	for i, path := range paths {
+		changes[i] = restoredChange(path, found[path])
+	}
+	return changes, httpStatusCode, nil
+}
+
+// restoreChange returns the Change which restores the file to its state at the revision, i.e. an upsert
+// of the file or a removal if the file did not exist.
+func (con *contentService) restoreChange(ctx context.Context,
+	projectName, repoName string, revision int, path string) (*Change, int, error) {
+	entry, httpStatusCode, err := con.getFile(
+		ctx, projectName, repoName, strconv.Itoa(revision), &Query{Path: path, Type: Identity})
+	if err != nil {
+		if !isException(err, "EntryNotFoundException") {
+			return nil, httpStatusCode, err
+		}
+		entry = nil
+	}
+	return restoredChange(path, entry), httpStatusCode, nil
+}
+
+// restoredChange returns the Change which upserts the entry, or removes the file if the entry is nil.
+func restoredChange(path string, entry *Entry) *Change {
+	switch {
+	case entry == nil:
+		return &Change{Path: path, Type: Remove}
+	case entry.Type == JSON:
+		return &Change{Path: path, Type: UpsertJSON, Content: json.RawMessage(entry.Content)}
+	default:
+		return &Change{Path: path, Type: UpsertText, Content: string(entry.Content)}
+	}
+}
@@ -0,0 +1,219 @@
+// Copyright 2026 LINE Corporation
+//
+// LINE Corporation licenses this file to you under the Apache License,
+// version 2.0 (the "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at:
+//
+//   https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+// WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+// License for the specific language governing permissions and limitations
+// under the License.
+
+package centraldogma
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"reflect"
+	"testing"
+)
+
+// handleRevertRepository serves a repository whose revision 3 modified /a.json, added /b.txt, removed /c.txt
+// and renamed /d.txt to /e.txt. The latest revision is 4, which changed the files returned by laterDiffs.
+func handleRevertRepository(t *testing.T, mux *http.ServeMux, laterDiffs string) {
+	mux.HandleFunc("/api/v1/projects/foo/repos/bar/revision/-1", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"revision":4}`)
+	})
+	mux.HandleFunc("/api/v1/projects/foo/repos/bar/commits/3", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `[{"revision":3, "author":{"name":"minux"}, "commitMessage":{"summary":"Bad change"},
+"pushedAt":"2026-01-02T03:04:05Z"}]`)
+	})
+	mux.HandleFunc("/api/v1/projects/foo/repos/bar/compare", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, http.MethodGet)
+		switch from := r.URL.Query().Get("from"); from {
+		case "2":
+			fmt.Fprint(w, `[{"path":"/a.json", "type":"APPLY_JSON_PATCH",
+"content":[{"op":"safeReplace", "path":"/a", "oldValue":1, "value":2}]},
+{"path":"/b.txt", "type":"UPSERT_TEXT", "content":"b"},
+{"path":"/c.txt", "type":"REMOVE"},
+{"path":"/d.txt", "type":"RENAME", "content":"/e.txt"}]`)
+		case "3":
+			testURLQuery(t, r, "to", "4")
+			testURLQuery(t, r, "pathPattern", "/a.json,/b.txt,/c.txt,/d.txt,/e.txt")
+			fmt.Fprint(w, laterDiffs)
+		default:
+			t.Errorf("unexpected from: %v", from)
+		}
+	})
+	mux.HandleFunc("/api/v1/projects/foo/repos/bar/contents/", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, http.MethodGet)
+		testURLQuery(t, r, "revision", "2")
+		switch r.URL.Path {
+		case "/api/v1/projects/foo/repos/bar/contents/a.json":
+			fmt.Fprint(w, `{"path":"/a.json", "type":"JSON", "content":{"a":1}}`)
+		case "/api/v1/projects/foo/repos/bar/contents/c.txt":
+			fmt.Fprint(w, `{"path":"/c.txt", "type":"TEXT", "content":"c"}`)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+			fmt.Fprint(w, `{"exception":"EntryNotFoundException", "message":"not found"}`)
+		}
+	})
+}
+
+func TestRevert(t *testing.T) {
+	c, mux, teardown := setup()
+	defer teardown()
+	handleRevertRepository(t, mux, `[]`)
+
+	mux.HandleFunc("/api/v1/projects/foo/repos/bar/contents", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, http.MethodPost)
+		testURLQuery(t, r, "revision", "4")
+
+		var reqBody push
+		_ = json.NewDecoder(r.Body).Decode(&reqBody)
+		want := push{
+			CommitMessage: &CommitMessage{Summary: `Revert "Bad change"`, Detail: "This reverts the revision 3."},
+			Changes: []*Change{
+				{Path: "/a.json", Type: UpsertJSON, Content: map[string]interface{}{"a": 1.0}},
+				{Path: "/b.txt", Type: Remove},
+				{Path: "/c.txt", Type: UpsertText, Content: "c"},
+				{Path: "/e.txt", Type: Rename, Content: "/d.txt"},
+			},
+		}
+		if !reflect.DeepEqual(reqBody, want) {
+			t.Errorf("Revert request body %+v, want %+v", reqBody, want)
+		}
+		fmt.Fprint(w, `{"revision":5, "pushedAt":"2026-01-02T03:04:05Z"}`)
+	})
+
+	result, _, err := c.Revert(context.Background(), "foo", "bar", 3, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := &PushResult{Revision: 5, PushedAt: "2026-01-02T03:04:05Z"}
+	if !reflect.DeepEqual(result, want) {
+		t.Errorf("Revert returned %+v, want %+v", result, want)
+	}
+}
+
+func TestRevert_Conflict(t *testing.T) {
+	c, mux, teardown := setup()
+	defer teardown()
+	handleRevertRepository(t, mux, `[{"path":"/e.txt", "type":"APPLY_TEXT_PATCH", "content":"..."}]`)
+
+	_, _, err := c.Revert(context.Background(), "foo", "bar", 3, nil)
+	if !errors.Is(err, ErrRevertConflict) {
+		t.Errorf("Revert returned %v, want %v", err, ErrRevertConflict)
+	}
+}
+
+func TestRollback(t *testing.T) {
+	c, mux, teardown := setup()
+	defer teardown()
+
+	mux.HandleFunc("/api/v1/projects/foo/repos/bar/revision/-1", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"revision":4}`)
+	})
+	mux.HandleFunc("/api/v1/projects/foo/repos/bar/compare", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, http.MethodGet)
+		testURLQuery(t, r, "from", "2")
+		testURLQuery(t, r, "to", "4")
+		testURLQuery(t, r, "pathPattern", "/config/**")
+		fmt.Fprint(w, `[{"path":"/config/a.json", "type":"APPLY_JSON_PATCH", "content":[]},
+{"path":"/config/b.txt", "type":"UPSERT_TEXT", "content":"b"}]`)
+	})
+	mux.HandleFunc("/api/v1/projects/foo/repos/bar/contents/", func(w http.ResponseWriter, r *http.Request) {
+		// All files are fetched with a single request.
+		testMethod(t, r, http.MethodGet)
+		testURLQuery(t, r, "revision", "2")
+		if r.URL.Path != "/api/v1/projects/foo/repos/bar/contents/config/a.json,/config/b.txt" {
+			t.Errorf("unexpected path: %v", r.URL.Path)
+		}
+		fmt.Fprint(w, `[{"path":"/config/a.json", "type":"JSON", "content":{"a":1}}]`)
+	})
+	mux.HandleFunc("/api/v1/projects/foo/repos/bar/contents", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, http.MethodPost)
+		testURLQuery(t, r, "revision", "4")
+
+		var reqBody push
+		_ = json.NewDecoder(r.Body).Decode(&reqBody)
+		want := push{
+			CommitMessage: &CommitMessage{Summary: "Rollback /config/** to the revision 2"},
+			Changes: []*Change{
+				{Path: "/config/a.json", Type: UpsertJSON, Content: map[string]interface{}{"a": 1.0}},
+				{Path: "/config/b.txt", Type: Remove},
+			},
+		}
+		if !reflect.DeepEqual(reqBody, want) {
+			t.Errorf("Rollback request body %+v, want %+v", reqBody, want)
+		}
+		fmt.Fprint(w, `{"revision":5, "pushedAt":"2026-01-02T03:04:05Z"}`)
+	})
+
+	result, _, err := c.Rollback(context.Background(), "foo", "bar", "/config/**", 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if result == nil || result.Revision != 5 {
+		t.Errorf("Rollback returned %+v, want the revision 5", result)
+	}
+
+	// Nothing to roll back to the latest revision.
+	result, _, err = c.Rollback(context.Background(), "foo", "bar", "/config/**", Head())
+	if err != nil || result != nil {
+		t.Errorf("Rollback returned %+v, %v, want nil", result, err)
+	}
+}
+
+func TestRollback_Conflict(t *testing.T) {
+	c, mux, teardown := setup()
+	defer teardown()
+
+	// The revision 5 is pushed while computing the changes, and changes the file returned by laterDiffs.
+	var laterDiffs string
+	numNormalizations := 0
+	mux.HandleFunc("/api/v1/projects/foo/repos/bar/revision/-1", func(w http.ResponseWriter, r *http.Request) {
+		numNormalizations++
+		fmt.Fprintf(w, `{"revision":%d}`, 3+numNormalizations)
+	})
+	mux.HandleFunc("/api/v1/projects/foo/repos/bar/compare", func(w http.ResponseWriter, r *http.Request) {
+		switch from := r.URL.Query().Get("from"); from {
+		case "2":
+			fmt.Fprint(w, `[{"path":"/config/a.json", "type":"APPLY_JSON_PATCH", "content":[]}]`)
+		case "4":
+			testURLQuery(t, r, "to", "5")
+			testURLQuery(t, r, "pathPattern", "/config/a.json")
+			fmt.Fprint(w, laterDiffs)
+		default:
+			t.Errorf("unexpected from: %v", from)
+		}
+	})
+	mux.HandleFunc("/api/v1/projects/foo/repos/bar/contents/config/a.json",
+		func(w http.ResponseWriter, r *http.Request) {
+			fmt.Fprint(w, `{"path":"/config/a.json", "type":"JSON", "content":{"a":1}}`)
+		})
+	mux.HandleFunc("/api/v1/projects/foo/repos/bar/contents", func(w http.ResponseWriter, r *http.Request) {
+		// The commit which did not change the file does not prevent the rollback.
+		testURLQuery(t, r, "revision", "5")
+		fmt.Fprint(w, `{"revision":6, "pushedAt":"2026-01-02T03:04:05Z"}`)
+	})
+
+	laterDiffs = `[{"path":"/config/a.json", "type":"APPLY_JSON_PATCH", "content":[]}]`
+	_, _, err := c.Rollback(context.Background(), "foo", "bar", "/config/**", 2)
+	if !errors.Is(err, ErrRevertConflict) {
+		t.Errorf("Rollback returned %v, want %v", err, ErrRevertConflict)
+	}
+
+	laterDiffs = `[]`
+	numNormalizations = 0
+	result, _, err := c.Rollback(context.Background(), "foo", "bar", "/config/**", 2)
+	if err != nil || result == nil || result.Revision != 6 {
+		t.Errorf("Rollback returned %+v, %v, want the revision 6", result, err)
+	}
+}
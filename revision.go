@@ -0,0 +1,134 @@
+// Copyright 2026 LINE Corporation
+//
+// LINE Corporation licenses this file to you under the Apache License,
+// version 2.0 (the "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at:
+//
+//   https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+// WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+// License for the specific language governing permissions and limitations
+// under the License.
+
+package centraldogma
+
+import (
+	"fmt"
+	"strconv"
+	"time"
+)
+
+// Revision represents a revision of a repository. A positive revision is absolute, e.g. 1 is the initial
+// revision. A negative revision is relative to the latest revision, e.g. -1 is the latest revision and -2 is
+// the revision before it. The zero value is not a valid revision.
+//
+// The methods of Client which take a Revision, e.g. GetFileAt, have the counterparts which take the revision
+// as a string such as "-1" for backward compatibility, e.g. GetFile. For example:
+//
+//	entry, _, err := client.GetFileAt(ctx, "foo", "bar", centraldogma.Relative(-2), query)
+type Revision int
+
+const (
+	// HeadRevision is the latest revision.
+	HeadRevision Revision = -1
+	// InitRevision is the initial revision of a repository.
+	InitRevision Revision = 1
+)
+
+// Head returns the latest revision.
+func Head() Revision {
+	return HeadRevision
+}
+
+// Init returns the initial revision.
+func Init() Revision {
+	return InitRevision
+}
+
+// Relative returns the revision relative to the latest revision, e.g. Relative(-1) is the latest revision
+// and Relative(-2) is the revision before it. n is clamped to -1, i.e. the latest revision, if it is not
+// negative.
+func Relative(n int) Revision {
+	if n >= 0 {
+		return HeadRevision
+	}
+	return Revision(n)
+}
+
+// Absolute returns the absolute revision. n is clamped to 1, i.e. the initial revision, if it is not positive.
+func Absolute(n int) Revision {
+	if n <= 0 {
+		return InitRevision
+	}
+	return Revision(n)
+}
+
+// ParseRevision parses the revision string such as "-1" or "3".
+func ParseRevision(revision string) (Revision, error) {
+	rev, err := strconv.Atoi(revision)
+	if err != nil || rev == 0 {
+		return 0, fmt.Errorf("invalid revision: %q", revision)
+	}
+	return Revision(rev), nil
+}
+
+// revisionArg returns the revision string sent to the server. The zero Revision is sent as the empty string,
+// which means the default revision of the method.
+func revisionArg(revision Revision) string {
+	if revision == 0 {
+		return ""
+	}
+	return revision.String()
+}
+
+// IsAbsolute returns whether the revision is absolute.
+func (r Revision) IsAbsolute() bool {
+	return r > 0
+}
+
+// IsRelative returns whether the revision is relative to the latest revision.
+func (r Revision) IsRelative() bool {
+	return r < 0
+}
+
+// String returns the revision in the form accepted by the methods of Client, e.g. "-1".
+func (r Revision) String() string {
+	return strconv.Itoa(int(r))
+}
+
+// CreatedTime returns the time when the project was created. It returns the zero time if
+// CreatedAt is empty.
+func (p *Project) CreatedTime() (time.Time, error) {
+	return parseOptionalTimestamp(p.CreatedAt)
+}
+
+// CreatedTime returns the time when the repository was created. It returns the zero time if
+// CreatedAt is empty.
+func (r *Repository) CreatedTime() (time.Time, error) {
+	return parseOptionalTimestamp(r.CreatedAt)
+}
+
+// PushedTime returns the time when the commit was pushed. It returns the zero time if PushedAt is empty.
+func (c *Commit) PushedTime() (time.Time, error) {
+	return parseOptionalTimestamp(c.PushedAt)
+}
+
+// PushedTime returns the time when the changes were pushed. It returns the zero time if PushedAt is empty.
+func (p *PushResult) PushedTime() (time.Time, error) {
+	return parseOptionalTimestamp(p.PushedAt)
+}
+
+// ModifiedTime returns the time when the entry was last modified. It returns the zero time if
+// ModifiedAt is empty.
+func (e *Entry) ModifiedTime() (time.Time, error) {
+	return parseOptionalTimestamp(e.ModifiedAt)
+}
+
+func parseOptionalTimestamp(timestamp string) (time.Time, error) {
+	if len(timestamp) == 0 {
+		return time.Time{}, nil
+	}
+	return parseTimestamp(timestamp)
+}
@@ -0,0 +1,115 @@
+// Copyright 2026 LINE Corporation
+//
+// LINE Corporation licenses this file to you under the Apache License,
+// version 2.0 (the "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at:
+//
+//   https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+// WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+// License for the specific language governing permissions and limitations
+// under the License.
+
+package centraldogma
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+)
+
+func TestRevision(t *testing.T) {
+	if got := Head(); !got.IsRelative() || got.IsAbsolute() || got.String() != "-1" {
+		t.Errorf("Head() = %v, want a relative revision -1", got)
+	}
+	if got := Relative(-2); !got.IsRelative() || got.String() != "-2" {
+		t.Errorf("Relative(-2) = %v, want a relative revision -2", got)
+	}
+	if got := Absolute(3); !got.IsAbsolute() || got.String() != "3" {
+		t.Errorf("Absolute(3) = %v, want an absolute revision 3", got)
+	}
+	if got := Init(); got != InitRevision || got.String() != "1" {
+		t.Errorf("Init() = %v, want 1", got)
+	}
+	if got := Relative(1); got != HeadRevision {
+		t.Errorf("Relative(1) = %v, want the clamped revision -1", got)
+	}
+	if got := Absolute(0); got != InitRevision {
+		t.Errorf("Absolute(0) = %v, want the clamped revision 1", got)
+	}
+
+	var tests = []struct {
+		revision string
+		want     Revision
+		wantErr  bool
+	}{
+		{"-1", HeadRevision, false},
+		{"5", Revision(5), false},
+		{"0", 0, true},
+		{"head", 0, true},
+		{"", 0, true},
+	}
+	for _, test := range tests {
+		got, err := ParseRevision(test.revision)
+		if got != test.want || (err != nil) != test.wantErr {
+			t.Errorf("ParseRevision(%q) = %v, %v, want %v", test.revision, got, err, test.want)
+		}
+	}
+}
+
+func TestRevisionArguments(t *testing.T) {
+	c, mux, teardown := setup()
+	defer teardown()
+
+	mux.HandleFunc("/api/v1/projects/foo/repos/bar/contents/a.txt", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, http.MethodGet)
+		fmt.Fprintf(w, `{"path":"/a.txt","type":"TEXT","content":"%s"}`, r.URL.Query().Get("revision"))
+	})
+	mux.HandleFunc("/api/v1/projects/foo/repos/bar/revision/-2", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"revision":4}`)
+	})
+
+	query := &Query{Path: "/a.txt", Type: Identity}
+	rev := Relative(-2)
+	entry, _, err := c.GetFileAt(context.Background(), "foo", "bar", rev, query)
+	if err != nil || string(entry.Content) != "-2" {
+		t.Errorf("GetFileAt(-2) sent the revision %q, %v, want -2", entry.Content, err)
+	}
+	if entry, _, err = c.GetFileAt(context.Background(), "foo", "bar", 0, query); err != nil || len(entry.Content) != 0 {
+		t.Errorf("GetFileAt(0) sent the revision %q, %v, want none", entry.Content, err)
+	}
+	if entry, _, err = c.GetFile(context.Background(), "foo", "bar", "3", query); err != nil ||
+		string(entry.Content) != "3" {
+		t.Errorf("GetFile(3) sent the revision %q, %v, want 3", entry.Content, err)
+	}
+	if normalized, _, err := c.Normalize(context.Background(), "foo", "bar", rev); err != nil || normalized != 4 {
+		t.Errorf("Normalize(-2) = %v, %v, want 4", normalized, err)
+	}
+
+	// The revision strings are sent as they are, so the ones only the server understands keep working.
+	if entry, _, err = c.GetFile(context.Background(), "foo", "bar", "head", query); err != nil ||
+		string(entry.Content) != "head" {
+		t.Errorf("GetFile(head) sent the revision %q, %v, want head", entry.Content, err)
+	}
+}
+
+func TestTimestamps(t *testing.T) {
+	want := time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)
+
+	commit := &Commit{PushedAt: "2026-01-02T03:04:05Z"}
+	if got, err := commit.PushedTime(); err != nil || !got.Equal(want) {
+		t.Errorf("PushedTime() = %v, %v, want %v", got, err, want)
+	}
+	project := &Project{}
+	if got, err := project.CreatedTime(); err != nil || !got.IsZero() {
+		t.Errorf("CreatedTime() = %v, %v, want the zero time", got, err)
+	}
+	entry := &Entry{ModifiedAt: "yesterday"}
+	if _, err := entry.ModifiedTime(); err == nil {
+		t.Errorf("ModifiedTime() succeeded, want an error")
+	}
+}
@@ -0,0 +1,306 @@
+// Copyright 2026 LINE Corporation
+//
+// LINE Corporation licenses this file to you under the Apache License,
+// version 2.0 (the "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at:
+//
+//   https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+// WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+// License for the specific language governing permissions and limitations
+// under the License.
+
+// Package syncagent mirrors the files of a repository to a local directory, for the applications which can
+// only read their configuration from local files. An Agent watches the files which match a path pattern
+// with a RepoWatcher, and whenever they change, writes them to the directory atomically and removes
+// the ones which were deleted. For example:
+//
+//	agent, err := syncagent.New(client, syncagent.Config{
+//	    Project:     "foo",
+//	    Repository:  "bar",
+//	    PathPattern: "/conf/**",
+//	    Dir:         "/etc/myapp",
+//	    OnSync:      func(status syncagent.Status) error { return reloadMyApp() },
+//	})
+//	if err != nil {
+//	    panic(err)
+//	}
+//	if err := agent.Start(); err != nil {
+//	    panic(err)
+//	}
+//	defer agent.Close()
+//	http.Handle("/health", agent)
+//
+// The file /conf/a.json is written to /etc/myapp/conf/a.json. The agent keeps the list of the files it wrote
+// in a centraldogma.ExportManifest named centraldogma.ExportManifestName in the directory, so that it only
+// removes its own files, even after it is restarted. A manifest written for another repository or path
+// pattern, e.g. by an export, is ignored. The sync fails if a file named centraldogma.ExportManifestName at
+// the root of the repository matches the path pattern.
+package syncagent
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/sirupsen/logrus"
+
+	"go.linecorp.com/centraldogma"
+)
+
+var log = logrus.New()
+
+// DefaultRetryInterval is the default interval of retrying a failed sync.
+const DefaultRetryInterval = 10 * time.Second
+
+// Client is the client which an Agent reads the files with, e.g. a *centraldogma.Client or
+// a *localdogma.Backend.
+type Client interface {
+	centraldogma.ContentReader
+	centraldogma.Watching
+}
+
+// Config is the configuration of an Agent.
+type Config struct {
+	Project    string
+	Repository string
+	// PathPattern is the path pattern of the files to mirror. "/**" is used if empty.
+	PathPattern string
+	// Dir is the local directory which the files are written to.
+	Dir string
+	// OnSync is called after a sync changed the files in the directory, e.g. to reload the application.
+	// The error it returns is reported by the Status until the next sync.
+	OnSync func(status Status) error
+	// RetryInterval is the interval of retrying a failed sync. DefaultRetryInterval is used if zero.
+	RetryInterval time.Duration
+}
+
+// Status is the status of an Agent.
+type Status struct {
+	Project     string `json:"project"`
+	Repository  string `json:"repository"`
+	PathPattern string `json:"pathPattern"`
+	Dir         string `json:"dir"`
+	// Revision is the revision which the files were synced at last, or 0 if they have never been synced.
+	Revision int    `json:"revision"`
+	SyncedAt string `json:"syncedAt,omitempty"`
+	Files    int    `json:"files"`
+	// LastError is the error of the last sync, or the one returned by Config.OnSync.
+	LastError string `json:"lastError,omitempty"`
+}
+
+// Healthy returns whether the files have been synced and the last sync succeeded.
+func (s Status) Healthy() bool {
+	return s.Revision > 0 && len(s.LastError) == 0
+}
+
+// Agent mirrors the files of a repository to a local directory. It is safe for concurrent use.
+type Agent struct {
+	client Client
+	config Config
+
+	syncMu   sync.Mutex // serializes the syncs
+	manifest *centraldogma.ExportManifest
+
+	mu      sync.Mutex
+	status  Status
+	pending int // the latest revision notified by the watcher
+	watcher *centraldogma.Watcher
+
+	notify    chan struct{}
+	done      chan struct{}
+	closeOnce sync.Once
+	wg        sync.WaitGroup
+}
+
+// New returns a new Agent. The files in the directory are not changed until Start or Sync is called.
+func New(client Client, config Config) (*Agent, error) {
+	if client == nil {
+		return nil, errors.New("syncagent: client should not be nil")
+	}
+	if len(config.Project) == 0 || len(config.Repository) == 0 || len(config.Dir) == 0 {
+		return nil, errors.New("syncagent: project, repository and dir should not be empty")
+	}
+	if len(config.PathPattern) == 0 {
+		config.PathPattern = "/**"
+	}
+	if config.RetryInterval <= 0 {
+		config.RetryInterval = DefaultRetryInterval
+	}
+
+	manifest, err := readManifest(config.Dir)
+	if err != nil {
+		return nil, err
+	}
+	if manifest != nil && !manifestMatches(manifest, &config) {
+		// The files it lists are kept, and it is overwritten by the first sync.
+		log.Warnf("syncagent: ignoring the manifest in %s, which was written for /%s/%s%s", config.Dir,
+			manifest.Project, manifest.Repository, manifest.PathPattern)
+		manifest = nil
+	}
+	return &Agent{
+		client:   client,
+		config:   config,
+		manifest: manifest,
+		status: Status{
+			Project:     config.Project,
+			Repository:  config.Repository,
+			PathPattern: config.PathPattern,
+			Dir:         config.Dir,
+		},
+		notify: make(chan struct{}, 1),
+		done:   make(chan struct{}),
+	}, nil
+}
+
+// Start syncs the files at the latest revision, and then keeps them in sync until Close is called.
+// A failed sync is retried in the background, so Start returns an error only if it fails to watch.
+func (a *Agent) Start() error {
+	watcher, err := a.client.RepoWatcher(a.config.Project, a.config.Repository, a.config.PathPattern)
+	if err != nil {
+		return err
+	}
+	a.mu.Lock()
+	a.watcher = watcher
+	a.mu.Unlock()
+
+	a.wg.Add(1)
+	go a.loop()
+	a.trigger(0)
+	return watcher.Watch(func(result centraldogma.WatchResult) {
+		if result.Err == nil {
+			a.trigger(result.Revision)
+		}
+	})
+}
+
+// Close stops syncing the files.
+func (a *Agent) Close() {
+	a.closeOnce.Do(func() {
+		close(a.done)
+		a.mu.Lock()
+		watcher := a.watcher
+		a.mu.Unlock()
+		if watcher != nil {
+			watcher.Close()
+		}
+		a.wg.Wait()
+	})
+}
+
+// Status returns the current status.
+func (a *Agent) Status() Status {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	return a.status
+}
+
+// ServeHTTP writes the Status as JSON with its health. The status code is 200 if it is healthy, and 503
+// otherwise.
+func (a *Agent) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	status := a.Status()
+	b, err := json.Marshal(&struct {
+		Healthy bool `json:"healthy"`
+		Status
+	}{status.Healthy(), status})
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if !status.Healthy() {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}
+	_, _ = w.Write(b)
+}
+
+// Sync syncs the files at the latest revision once.
+func (a *Agent) Sync(ctx context.Context) error {
+	return a.syncAt(ctx, 0)
+}
+
+// trigger requests a sync at the revision, or at the latest revision if it is 0.
+func (a *Agent) trigger(revision int) {
+	a.mu.Lock()
+	if revision == 0 || revision > a.pending {
+		a.pending = revision
+	}
+	a.mu.Unlock()
+	select {
+	case a.notify <- struct{}{}:
+	default:
+	}
+}
+
+func (a *Agent) loop() {
+	defer a.wg.Done()
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go func() {
+		<-a.done
+		cancel()
+	}()
+
+	var retry <-chan time.Time
+	for {
+		retrying := false
+		select {
+		case <-a.done:
+			return
+		case <-a.notify:
+		case <-retry:
+			retrying = true
+		}
+
+		a.mu.Lock()
+		revision := a.pending
+		a.mu.Unlock()
+		if retrying {
+			// The revision which failed to sync may not be available anymore, e.g. for a localdogma.Backend.
+			revision = 0
+		}
+		if err := a.syncAt(ctx, revision); err != nil {
+			log.Warnf("syncagent: failed to sync /%s/%s%s at the revision %d: %v",
+				a.config.Project, a.config.Repository, a.config.PathPattern, revision, err)
+			retry = time.After(a.config.RetryInterval)
+		} else {
+			retry = nil
+		}
+	}
+}
+
+func (a *Agent) syncAt(ctx context.Context, revision int) error {
+	a.syncMu.Lock()
+	defer a.syncMu.Unlock()
+
+	changed, err := a.sync(ctx, revision)
+	if err != nil {
+		a.setError(err)
+		return err
+	}
+
+	status := a.Status()
+	status.LastError = ""
+	if changed && a.config.OnSync != nil {
+		if err := a.config.OnSync(status); err != nil {
+			status.LastError = fmt.Sprintf("failed to run OnSync: %v", err)
+		}
+	}
+	a.mu.Lock()
+	a.status = status
+	a.mu.Unlock()
+	return nil
+}
+
+func (a *Agent) setError(err error) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	a.status.LastError = err.Error()
+}
@@ -0,0 +1,268 @@
+// Copyright 2026 LINE Corporation
+//
+// LINE Corporation licenses this file to you under the Apache License,
+// version 2.0 (the "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at:
+//
+//   https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+// WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+// License for the specific language governing permissions and limitations
+// under the License.
+
+package syncagent_test
+
+import (
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"go.linecorp.com/centraldogma"
+	"go.linecorp.com/centraldogma/dogmatest"
+	"go.linecorp.com/centraldogma/localdogma"
+	"go.linecorp.com/centraldogma/syncagent"
+)
+
+func writeFile(t *testing.T, root, path, content string) {
+	t.Helper()
+	name := filepath.Join(root, filepath.FromSlash(path))
+	if err := os.MkdirAll(filepath.Dir(name), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := ioutil.WriteFile(name, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func readFile(t *testing.T, root, path string) string {
+	t.Helper()
+	b, err := ioutil.ReadFile(filepath.Join(root, filepath.FromSlash(path)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return string(b)
+}
+
+func TestSync(t *testing.T) {
+	root := t.TempDir()
+	writeFile(t, root, "foo/bar/conf/a.json", `{"a":1}`)
+	writeFile(t, root, "foo/bar/conf/sub/b.txt", "b")
+	writeFile(t, root, "foo/bar/other.txt", "other")
+	backend, err := localdogma.New(root)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer backend.Close()
+
+	dir := t.TempDir()
+	writeFile(t, dir, "unmanaged.txt", "keep")
+	synced := 0
+	agent, err := syncagent.New(backend, syncagent.Config{
+		Project: "foo", Repository: "bar", PathPattern: "/conf/**", Dir: dir,
+		OnSync: func(status syncagent.Status) error {
+			synced++
+			return nil
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := agent.Sync(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	if got := readFile(t, dir, "conf/a.json"); got != `{"a":1}` {
+		t.Errorf("conf/a.json = %q", got)
+	}
+	if got := readFile(t, dir, "conf/sub/b.txt"); got != "b" {
+		t.Errorf("conf/sub/b.txt = %q", got)
+	}
+	if _, err := os.Stat(filepath.Join(dir, "other.txt")); !os.IsNotExist(err) {
+		t.Errorf("other.txt should not be synced: %v", err)
+	}
+	if status := agent.Status(); !status.Healthy() || status.Revision != 2 || status.Files != 2 || synced != 1 {
+		t.Errorf("Status() = %+v, OnSync called %d times, want 2 files at the revision 2", status, synced)
+	}
+
+	// The deleted files are removed, but the others are kept.
+	if err := os.RemoveAll(filepath.Join(root, "foo", "bar", "conf", "sub")); err != nil {
+		t.Fatal(err)
+	}
+	if err := backend.Poll(); err != nil {
+		t.Fatal(err)
+	}
+	if err := agent.Sync(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := os.Stat(filepath.Join(dir, "conf", "sub")); !os.IsNotExist(err) {
+		t.Errorf("conf/sub should be removed: %v", err)
+	}
+	if got := readFile(t, dir, "unmanaged.txt"); got != "keep" {
+		t.Errorf("unmanaged.txt = %q, want keep", got)
+	}
+	if synced != 2 {
+		t.Errorf("OnSync called %d times, want 2", synced)
+	}
+
+	// A new agent removes the files which the previous one wrote, after it is restarted.
+	if err := os.Remove(filepath.Join(root, "foo", "bar", "conf", "a.json")); err != nil {
+		t.Fatal(err)
+	}
+	writeFile(t, root, "foo/bar/conf/c.txt", "c")
+	if err := backend.Poll(); err != nil {
+		t.Fatal(err)
+	}
+	agent, err = syncagent.New(backend, syncagent.Config{
+		Project: "foo", Repository: "bar", PathPattern: "/conf/**", Dir: dir,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := agent.Sync(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := os.Stat(filepath.Join(dir, "conf", "a.json")); !os.IsNotExist(err) {
+		t.Errorf("conf/a.json should be removed: %v", err)
+	}
+	if got := readFile(t, dir, "conf/c.txt"); got != "c" {
+		t.Errorf("conf/c.txt = %q", got)
+	}
+}
+
+func TestStartAndHealth(t *testing.T) {
+	root := t.TempDir()
+	writeFile(t, root, "foo/bar/a.txt", "1")
+	backend, err := localdogma.New(root)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer backend.Close()
+
+	dir := t.TempDir()
+	synced := make(chan syncagent.Status, 10)
+	agent, err := syncagent.New(backend, syncagent.Config{
+		Project: "foo", Repository: "bar", Dir: dir, RetryInterval: 10 * time.Millisecond,
+		OnSync: func(status syncagent.Status) error {
+			synced <- status
+			return nil
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	server := httptest.NewServer(agent)
+	defer server.Close()
+	if res, err := http.Get(server.URL); err != nil || res.StatusCode != http.StatusServiceUnavailable {
+		t.Errorf("health before syncing = %v, %v, want 503", res, err)
+	}
+
+	if err := agent.Start(); err != nil {
+		t.Fatal(err)
+	}
+	defer agent.Close()
+	waitForSync := func(revision int) {
+		t.Helper()
+		for {
+			select {
+			case status := <-synced:
+				if status.Revision >= revision {
+					return
+				}
+			case <-time.After(5 * time.Second):
+				t.Fatalf("timed out waiting for the revision %d", revision)
+			}
+		}
+	}
+	waitForSync(2)
+
+	writeFile(t, root, "foo/bar/a.txt", "2")
+	if err := backend.Poll(); err != nil {
+		t.Fatal(err)
+	}
+	waitForSync(3)
+	if got := readFile(t, dir, "a.txt"); got != "2" {
+		t.Errorf("a.txt = %q, want 2", got)
+	}
+
+	res, err := http.Get(server.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer res.Body.Close()
+	var health struct {
+		Healthy  bool `json:"healthy"`
+		Revision int  `json:"revision"`
+	}
+	if err := json.NewDecoder(res.Body).Decode(&health); err != nil {
+		t.Fatal(err)
+	}
+	if res.StatusCode != http.StatusOK || !health.Healthy || health.Revision != 3 {
+		t.Errorf("health = %d %+v, want healthy at the revision 3", res.StatusCode, health)
+	}
+}
+
+func TestSync_ForeignManifest(t *testing.T) {
+	root := t.TempDir()
+	writeFile(t, root, "foo/bar/conf/a.json", `{"a":1}`)
+	backend, err := localdogma.New(root)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer backend.Close()
+
+	// The manifest written by an export of another repository does not make the agent remove its files.
+	dir := t.TempDir()
+	writeFile(t, dir, "other.txt", "keep")
+	manifest, err := json.Marshal(&centraldogma.ExportManifest{
+		Project: "foo", Repository: "baz", Revision: 2, PathPattern: "/conf/**",
+		Files: []*centraldogma.ExportedFile{{Path: "/other.txt"}},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	writeFile(t, dir, centraldogma.ExportManifestName, string(manifest))
+
+	agent, err := syncagent.New(backend, syncagent.Config{
+		Project: "foo", Repository: "bar", PathPattern: "/conf/**", Dir: dir,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := agent.Sync(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	if got := readFile(t, dir, "other.txt"); got != "keep" {
+		t.Errorf("other.txt = %q, want keep", got)
+	}
+	if got := readFile(t, dir, "conf/a.json"); got != `{"a":1}` {
+		t.Errorf("conf/a.json = %q", got)
+	}
+}
+
+func TestSync_ManifestConflict(t *testing.T) {
+	server := dogmatest.NewServer()
+	defer server.Close()
+	if _, err := server.UpsertFile("foo", "bar", "/"+centraldogma.ExportManifestName, `{}`); err != nil {
+		t.Fatal(err)
+	}
+
+	dir := t.TempDir()
+	agent, err := syncagent.New(server.Client(), syncagent.Config{Project: "foo", Repository: "bar", Dir: dir})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := agent.Sync(context.Background()); err == nil {
+		t.Error("Sync() of the file named as the manifest should fail")
+	}
+	if _, err := os.Stat(filepath.Join(dir, centraldogma.ExportManifestName)); !os.IsNotExist(err) {
+		t.Errorf("the file named as the manifest should not be written: %v", err)
+	}
+}
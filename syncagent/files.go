@@ -0,0 +1,206 @@
+// Copyright 2026 LINE Corporation
+//
+// LINE Corporation licenses this file to you under the Apache License,
+// version 2.0 (the "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at:
+//
+//   https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+// WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+// License for the specific language governing permissions and limitations
+// under the License.
+
+package syncagent
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"go.linecorp.com/centraldogma"
+)
+
+// sync writes the files at the revision, or at the latest revision if it is 0, and returns whether
+// the files in the directory were changed. a.syncMu must be held.
+func (a *Agent) sync(ctx context.Context, revision int) (bool, error) {
+	config := a.config
+	if revision == 0 {
+		rev, _, err := a.client.NormalizeRevision(ctx, config.Project, config.Repository, "-1")
+		if err != nil {
+			return false, err
+		}
+		revision = rev
+	}
+	if status := a.Status(); revision <= status.Revision && len(status.LastError) == 0 {
+		return false, nil
+	}
+
+	entries, httpStatusCode, err := a.client.GetFiles(ctx, config.Project, config.Repository,
+		strconv.Itoa(revision), config.PathPattern)
+	if err != nil {
+		// The revision exists, so 404 means that no files match the path pattern.
+		if httpStatusCode != http.StatusNotFound {
+			return false, err
+		}
+		entries = nil
+	}
+	files := make([]*centraldogma.Entry, 0, len(entries))
+	for _, entry := range entries {
+		if entry.Type == centraldogma.Directory {
+			continue
+		}
+		if path.Clean("/"+entry.Path) != entry.Path {
+			return false, fmt.Errorf("invalid path of the entry: %q", entry.Path)
+		}
+		if entry.Path == "/"+centraldogma.ExportManifestName {
+			return false, fmt.Errorf("the entry %q conflicts with the manifest", entry.Path)
+		}
+		files = append(files, entry)
+	}
+	sort.Slice(files, func(i, j int) bool { return files[i].Path < files[j].Path })
+
+	changed, err := a.writeFiles(files)
+	if err != nil {
+		return false, err
+	}
+	manifest := centraldogma.NewExportManifest(config.Project, config.Repository, revision, config.PathPattern,
+		files)
+	if err := writeManifest(config.Dir, manifest); err != nil {
+		return false, err
+	}
+	a.manifest = manifest
+
+	a.mu.Lock()
+	a.status.Revision = revision
+	a.status.SyncedAt = time.Now().UTC().Format(time.RFC3339)
+	a.status.Files = len(files)
+	a.mu.Unlock()
+	return changed, nil
+}
+
+// writeFiles writes the files whose contents differ from the local ones, and removes the files which were
+// written by the last sync but are not in the files anymore.
+func (a *Agent) writeFiles(files []*centraldogma.Entry) (bool, error) {
+	changed := false
+	written := make(map[string]bool, len(files))
+	for _, entry := range files {
+		written[entry.Path] = true
+		name := a.localPath(entry.Path)
+		if current, err := ioutil.ReadFile(name); err == nil && bytes.Equal(current, entry.Content) {
+			continue
+		}
+		if err := writeFileAtomically(name, entry.Content); err != nil {
+			return changed, err
+		}
+		changed = true
+	}
+
+	if a.manifest == nil {
+		return changed, nil
+	}
+	for _, f := range a.manifest.Files {
+		if written[f.Path] {
+			continue
+		}
+		name := a.localPath(f.Path)
+		if err := os.Remove(name); err != nil && !os.IsNotExist(err) {
+			return changed, err
+		}
+		changed = true
+		removeEmptyDirs(a.config.Dir, filepath.Dir(name))
+	}
+	return changed, nil
+}
+
+func (a *Agent) localPath(p string) string {
+	return filepath.Join(a.config.Dir, filepath.FromSlash(strings.TrimPrefix(p, "/")))
+}
+
+// writeFileAtomically writes the content to a temporary file in the same directory and renames it, so that
+// the readers never see a partially written file. The name of the temporary file starts with ".".
+func writeFileAtomically(name string, content []byte) error {
+	dir := filepath.Dir(name)
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return err
+	}
+	f, err := ioutil.TempFile(dir, ".dogma-agent-")
+	if err != nil {
+		return err
+	}
+	tempName := f.Name()
+	_, err = f.Write(content)
+	if err == nil {
+		err = f.Chmod(0644)
+	}
+	if closeErr := f.Close(); err == nil {
+		err = closeErr
+	}
+	if err == nil {
+		err = os.Rename(tempName, name)
+	}
+	if err != nil {
+		_ = os.Remove(tempName)
+	}
+	return err
+}
+
+// removeEmptyDirs removes the dir and its parents while they are empty, up to the root which is not removed.
+func removeEmptyDirs(root, dir string) {
+	root = filepath.Clean(root)
+	for dir = filepath.Clean(dir); dir != root && strings.HasPrefix(dir, root); dir = filepath.Dir(dir) {
+		if os.Remove(dir) != nil {
+			return
+		}
+	}
+}
+
+func readManifest(dir string) (*centraldogma.ExportManifest, error) {
+	b, err := ioutil.ReadFile(filepath.Join(dir, centraldogma.ExportManifestName))
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	manifest := new(centraldogma.ExportManifest)
+	if err := json.Unmarshal(b, manifest); err != nil {
+		return nil, fmt.Errorf("syncagent: failed to read the manifest in %s: %v", dir, err)
+	}
+	return manifest, nil
+}
+
+// manifestMatches returns whether the manifest was written for the files of the config, so that the agent
+// owns the files it lists. A manifest written by an export of another repository or with another path
+// pattern lists the files which the agent must not remove.
+func manifestMatches(manifest *centraldogma.ExportManifest, config *Config) bool {
+	if manifest.Project != config.Project || manifest.Repository != config.Repository ||
+		manifest.PathPattern != config.PathPattern {
+		return false
+	}
+	for _, f := range manifest.Files {
+		if path.Clean("/"+f.Path) != f.Path || f.Path == "/"+centraldogma.ExportManifestName {
+			return false
+		}
+	}
+	return true
+}
+
+func writeManifest(dir string, manifest *centraldogma.ExportManifest) error {
+	b, err := json.MarshalIndent(manifest, "", "  ")
+	if err != nil {
+		return err
+	}
+	return writeFileAtomically(filepath.Join(dir, centraldogma.ExportManifestName), append(b, '\n'))
+}
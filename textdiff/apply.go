@@ -0,0 +1,175 @@
+// Copyright 2026 LINE Corporation
+//
+// LINE Corporation licenses this file to you under the Apache License,
+// version 2.0 (the "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at:
+//
+//   https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+// WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+// License for the specific language governing permissions and limitations
+// under the License.
+
+package textdiff
+
+import (
+	"fmt"
+	"strings"
+)
+
+// HunkResult reports how a hunk was applied.
+type HunkResult struct {
+	Hunk    *Hunk
+	Applied bool
+	// Offset is the number of lines the hunk was moved from the line in its header.
+	Offset int
+	// Fuzz is the number of context lines which were ignored at the beginning and the end of the hunk.
+	Fuzz int
+}
+
+// ConflictError is returned when some hunks of a patch cannot be applied.
+type ConflictError struct {
+	Hunks []*Hunk
+}
+
+func (e *ConflictError) Error() string {
+	headers := make([]string, len(e.Hunks))
+	for i, hunk := range e.Hunks {
+		headers[i] = fmt.Sprintf("@@ -%s +%s @@",
+			formatRange(hunk.OldStart, hunk.OldLines), formatRange(hunk.NewStart, hunk.NewLines))
+	}
+	return fmt.Sprintf("textdiff: failed to apply %d hunk(s): %s", len(e.Hunks), strings.Join(headers, ", "))
+}
+
+// Apply applies the unified diff to the text. A hunk is applied even if the lines have been moved
+// since the diff was generated, but its context lines must match exactly.
+func Apply(text, patch string) (string, error) {
+	result, _, err := ApplyFuzzy(text, patch, 0)
+	return result, err
+}
+
+// ApplyFuzzy applies the unified diff to the text, ignoring up to fuzz context lines at the beginning and
+// the end of a hunk when it does not match. If some hunks cannot be applied, the text with the other hunks
+// applied is returned along with a *ConflictError.
+func ApplyFuzzy(text, patch string, fuzz int) (string, []HunkResult, error) {
+	filePatch, err := Parse(patch)
+	if err != nil {
+		return "", nil, err
+	}
+	return filePatch.Apply(text, fuzz)
+}
+
+// Apply applies the FilePatch to the text. See ApplyFuzzy for details.
+func (p *FilePatch) Apply(text string, fuzz int) (string, []HunkResult, error) {
+	lines := splitLines(text)
+	var out []string
+	var results []HunkResult
+	var conflicts []*Hunk
+	pos, offset := 0, 0
+
+	for _, hunk := range p.Hunks {
+		result := HunkResult{Hunk: hunk}
+		before, after, leading, trailing := hunk.sides()
+
+		expected := hunk.OldStart - 1
+		if hunk.OldLines == 0 {
+			expected = hunk.OldStart
+		}
+
+		for f := 0; f <= fuzz && !result.Applied; f++ {
+			lead, trail := min(f, leading), min(f, trailing)
+			if f > 0 && lead == 0 && trail == 0 {
+				break // no more context lines to ignore
+			}
+			b := before[lead : len(before)-trail]
+			a := after[lead : len(after)-trail]
+
+			if at, ok := find(lines, b, pos, expected+lead+offset); ok {
+				out = append(out, lines[pos:at]...)
+				out = append(out, a...)
+				pos = at + len(b)
+				offset = at - lead - expected
+				result.Applied, result.Offset, result.Fuzz = true, offset, f
+			}
+		}
+
+		if !result.Applied {
+			conflicts = append(conflicts, hunk)
+		}
+		results = append(results, result)
+	}
+	out = append(out, lines[pos:]...)
+
+	result := strings.Join(out, "\n")
+	if len(out) > 0 && (len(text) == 0 || strings.HasSuffix(text, "\n")) {
+		result += "\n"
+	}
+	if len(conflicts) > 0 {
+		return result, results, &ConflictError{Hunks: conflicts}
+	}
+	return result, results, nil
+}
+
+// sides returns the lines of the hunk before and after it is applied, and the number of context lines
+// at the beginning and the end of the hunk.
+func (h *Hunk) sides() (before, after []string, leading, trailing int) {
+	for _, line := range h.Lines {
+		switch line[0] {
+		case ' ':
+			before = append(before, line[1:])
+			after = append(after, line[1:])
+		case '-':
+			before = append(before, line[1:])
+		case '+':
+			after = append(after, line[1:])
+		}
+	}
+	for leading < len(h.Lines) && h.Lines[leading][0] == ' ' {
+		leading++
+	}
+	for trailing < len(h.Lines)-leading && h.Lines[len(h.Lines)-1-trailing][0] == ' ' {
+		trailing++
+	}
+	return
+}
+
+// find returns the index of lines from the pos where the expected lines appear, looking for the nearest
+// one from the hint.
+func find(lines, expected []string, pos, hint int) (int, bool) {
+	last := len(lines) - len(expected)
+	if last < pos {
+		return 0, false
+	}
+	if hint < pos {
+		hint = pos
+	} else if hint > last {
+		hint = last
+	}
+	for distance := 0; hint-distance >= pos || hint+distance <= last; distance++ {
+		if at := hint + distance; at <= last && matches(lines[at:], expected) {
+			return at, true
+		}
+		if at := hint - distance; distance > 0 && at >= pos && matches(lines[at:], expected) {
+			return at, true
+		}
+	}
+	return 0, false
+}
+
+func matches(lines, expected []string) bool {
+	for i, line := range expected {
+		if lines[i] != line {
+			return false
+		}
+	}
+	return true
+}
+
+func min(a, b int) int {
+	if a < b {
+		return a
+	}
+	return b
+}
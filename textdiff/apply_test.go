@@ -0,0 +1,92 @@
+// Copyright 2026 LINE Corporation
+//
+// LINE Corporation licenses this file to you under the Apache License,
+// version 2.0 (the "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at:
+//
+//   https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+// WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+// License for the specific language governing permissions and limitations
+// under the License.
+
+package textdiff
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestParse(t *testing.T) {
+	// The format of the diff returned by Central Dogma.
+	patch, err := Parse("--- /b.txt\n+++ /b.txt\n@@ -1,1 +1,1 @@\n-foo\n+bar")
+	if err != nil {
+		t.Fatalf("Parse returned an error: %v", err)
+	}
+	want := &FilePatch{OldName: "/b.txt", NewName: "/b.txt", Hunks: []*Hunk{
+		{OldStart: 1, OldLines: 1, NewStart: 1, NewLines: 1, Lines: []string{"-foo", "+bar"}}}}
+	if !reflect.DeepEqual(patch, want) {
+		t.Errorf("Parse returned %+v, want %+v", patch, want)
+	}
+
+	invalids := []string{
+		"--- /b.txt\n+++ /b.txt\n@@ -1 +1 @@\n-foo\n",
+		"--- /b.txt\n+++ /b.txt\n@@ -1 +1 @@\n-foo\n+bar\n+baz\n",
+		"--- /b.txt\n+++ /b.txt\n@@ -a +1 @@\n-foo\n+bar\n",
+		"--- /b.txt\n+++ /b.txt\n@@ -1 +1 @@\n*foo\n+bar\n",
+	}
+	for _, invalid := range invalids {
+		if _, err := Parse(invalid); err == nil {
+			t.Errorf("Parse(%q) returned no error", invalid)
+		}
+	}
+}
+
+func TestApply(t *testing.T) {
+	var tests = []struct {
+		text  string
+		patch string
+		want  string
+	}{
+		{"foo\n", "--- /b.txt\n+++ /b.txt\n@@ -1,1 +1,1 @@\n-foo\n+bar", "bar\n"},
+		{"foo", "--- /b.txt\n+++ /b.txt\n@@ -1,1 +1,1 @@\n-foo\n+bar", "bar"},
+		{"", "--- /b.txt\n+++ /b.txt\n@@ -0,0 +1,2 @@\n+a\n+b\n", "a\nb\n"},
+		// The lines were moved by the other changes.
+		{"x\ny\n1\n2\n3\n", "--- /b.txt\n+++ /b.txt\n@@ -1,3 +1,3 @@\n 1\n-2\n+two\n 3\n", "x\ny\n1\ntwo\n3\n"},
+	}
+
+	for _, test := range tests {
+		got, err := Apply(test.text, test.patch)
+		if err != nil {
+			t.Fatalf("Apply(%q, %q) returned an error: %v", test.text, test.patch, err)
+		}
+		if got != test.want {
+			t.Errorf("Apply(%q, %q) = %q, want %q", test.text, test.patch, got, test.want)
+		}
+	}
+}
+
+func TestApplyFuzzy(t *testing.T) {
+	text := "0\n1\n2\n3\n4\nchanged\n"
+	patch := "--- /b.txt\n+++ /b.txt\n@@ -1,5 +1,5 @@\n 1\n 2\n-3\n+three\n 4\n 5\n"
+
+	_, _, err := ApplyFuzzy(text, patch, 0)
+	var conflictErr *ConflictError
+	if !errors.As(err, &conflictErr) || len(conflictErr.Hunks) != 1 {
+		t.Fatalf("ApplyFuzzy with no fuzz returned %v, want a ConflictError", err)
+	}
+
+	got, results, err := ApplyFuzzy(text, patch, 1)
+	if err != nil {
+		t.Fatalf("ApplyFuzzy returned an error: %v", err)
+	}
+	if want := "0\n1\n2\nthree\n4\nchanged\n"; got != want {
+		t.Errorf("ApplyFuzzy returned %q, want %q", got, want)
+	}
+	if len(results) != 1 || !results[0].Applied || results[0].Fuzz != 1 || results[0].Offset != 1 {
+		t.Errorf("ApplyFuzzy returned %+v, want applied with fuzz 1 and offset 1", results)
+	}
+}
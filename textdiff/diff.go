@@ -0,0 +1,217 @@
+// Copyright 2026 LINE Corporation
+//
+// LINE Corporation licenses this file to you under the Apache License,
+// version 2.0 (the "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at:
+//
+//   https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+// WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+// License for the specific language governing permissions and limitations
+// under the License.
+
+package textdiff
+
+type editKind int
+
+const (
+	equal editKind = iota
+	deletion
+	insertion
+)
+
+type edit struct {
+	kind editKind
+	line string
+}
+
+// Diff returns the unified diff between the oldText and the newText of the file at the path with
+// DefaultContextLines lines of context. It returns an empty string if the texts have the same lines.
+func Diff(path, oldText, newText string) string {
+	return DiffFile(path, oldText, newText, DefaultContextLines).String()
+}
+
+// DiffFile returns the FilePatch between the oldText and the newText of the file at the path with
+// the specified number of context lines.
+func DiffFile(path, oldText, newText string, contextLines int) *FilePatch {
+	edits := diffLines(splitLines(oldText), splitLines(newText))
+	return &FilePatch{OldName: path, NewName: path, Hunks: toHunks(edits, contextLines)}
+}
+
+// diffLines returns the shortest edit script which transforms a into b using the linear space variant of
+// the Myers' algorithm, which finds the middle snake of the edit path and divides the problem at it.
+func diffLines(a, b []string) []edit {
+	d := &differ{a: a, b: b}
+	d.compare(0, len(a), 0, len(b))
+	return d.edits
+}
+
+type differ struct {
+	a, b  []string
+	edits []edit
+}
+
+// compare appends the edits which transform a[aLo:aHi] into b[bLo:bHi].
+func (d *differ) compare(aLo, aHi, bLo, bHi int) {
+	for aLo < aHi && bLo < bHi && d.a[aLo] == d.b[bLo] {
+		d.edits = append(d.edits, edit{kind: equal, line: d.a[aLo]})
+		aLo++
+		bLo++
+	}
+	aEnd := aHi
+	for aLo < aHi && bLo < bHi && d.a[aHi-1] == d.b[bHi-1] {
+		aHi--
+		bHi--
+	}
+
+	switch {
+	case aLo == aHi:
+		for _, line := range d.b[bLo:bHi] {
+			d.edits = append(d.edits, edit{kind: insertion, line: line})
+		}
+	case bLo == bHi:
+		for _, line := range d.a[aLo:aHi] {
+			d.edits = append(d.edits, edit{kind: deletion, line: line})
+		}
+	default:
+		x, y, u, v := d.middleSnake(aLo, aHi, bLo, bHi)
+		d.compare(aLo, x, bLo, y)
+		for _, line := range d.a[x:u] {
+			d.edits = append(d.edits, edit{kind: equal, line: line})
+		}
+		d.compare(u, aHi, v, bHi)
+	}
+
+	for _, line := range d.a[aHi:aEnd] {
+		d.edits = append(d.edits, edit{kind: equal, line: line})
+	}
+}
+
+// middleSnake returns the snake from (x, y) to (u, v) in the middle of the shortest edit path from
+// (aLo, bLo) to (aHi, bHi), by searching the path forward from the start and backward from the end at
+// the same time until they overlap. Both ranges must not be empty.
+func (d *differ) middleSnake(aLo, aHi, bLo, bHi int) (x, y, u, v int) {
+	n, m := aHi-aLo, bHi-bLo
+	delta := n - m
+	odd := delta%2 != 0
+	maxD := (n + m + 1) / 2
+	offset := maxD + 1
+
+	// vf[k+offset] is the furthest x on the diagonal k = x - y of the forward path. vb is the same for
+	// the backward path, in the coordinates reversed from the end, whose diagonal k is delta - k forward.
+	vf := make([]int, 2*offset+1)
+	vb := make([]int, 2*offset+1)
+	for step := 0; step <= maxD; step++ {
+		for k := -step; k <= step; k += 2 {
+			var x0 int
+			if k == -step || (k != step && vf[k-1+offset] < vf[k+1+offset]) {
+				x0 = vf[k+1+offset] // move down
+			} else {
+				x0 = vf[k-1+offset] + 1 // move right
+			}
+			y0 := x0 - k
+			x, y := x0, y0
+			for x < n && y < m && d.a[aLo+x] == d.b[bLo+y] {
+				x++
+				y++
+			}
+			vf[k+offset] = x
+			if rk := delta - k; odd && rk >= -(step-1) && rk <= step-1 && x+vb[rk+offset] >= n {
+				return aLo + x0, bLo + y0, aLo + x, bLo + y
+			}
+		}
+
+		for k := -step; k <= step; k += 2 {
+			var x0 int
+			if k == -step || (k != step && vb[k-1+offset] < vb[k+1+offset]) {
+				x0 = vb[k+1+offset]
+			} else {
+				x0 = vb[k-1+offset] + 1
+			}
+			y0 := x0 - k
+			x, y := x0, y0
+			for x < n && y < m && d.a[aHi-1-x] == d.b[bHi-1-y] {
+				x++
+				y++
+			}
+			vb[k+offset] = x
+			if fk := delta - k; !odd && fk >= -step && fk <= step && vf[fk+offset]+x >= n {
+				return aHi - x, bHi - y, aHi - x0, bHi - y0
+			}
+		}
+	}
+	panic("textdiff: no middle snake") // unreachable
+}
+
+func toHunks(edits []edit, contextLines int) []*Hunk {
+	var hunks []*Hunk
+	var hunk *Hunk
+	oldLine, newLine := 1, 1 // the line numbers of the next edit
+	lastChange := -1         // the index of the last changed edit in the current hunk
+
+	for i, e := range edits {
+		if e.kind != equal {
+			if hunk == nil || i-lastChange-1 > 2*contextLines {
+				// Start a new hunk with the preceding context lines.
+				start := i - contextLines
+				if start < 0 {
+					start = 0
+				}
+				numContext := i - start
+				hunk = &Hunk{OldStart: oldLine - numContext, NewStart: newLine - numContext}
+				for _, c := range edits[start:i] {
+					hunk.Lines = append(hunk.Lines, " "+c.line)
+				}
+				hunk.OldLines, hunk.NewLines = numContext, numContext
+				hunks = append(hunks, hunk)
+			} else {
+				// Merge into the current hunk with the rest of the context lines between the changes.
+				start := lastChange + contextLines + 1
+				if start > i {
+					start = i
+				}
+				for _, c := range edits[start:i] {
+					hunk.Lines = append(hunk.Lines, " "+c.line)
+					hunk.OldLines++
+					hunk.NewLines++
+				}
+			}
+			if e.kind == deletion {
+				hunk.Lines = append(hunk.Lines, "-"+e.line)
+				hunk.OldLines++
+			} else {
+				hunk.Lines = append(hunk.Lines, "+"+e.line)
+				hunk.NewLines++
+			}
+			lastChange = i
+		} else if hunk != nil && i-lastChange <= contextLines {
+			// The trailing context lines of the current hunk.
+			hunk.Lines = append(hunk.Lines, " "+e.line)
+			hunk.OldLines++
+			hunk.NewLines++
+		}
+
+		switch e.kind {
+		case equal:
+			oldLine++
+			newLine++
+		case deletion:
+			oldLine++
+		case insertion:
+			newLine++
+		}
+	}
+
+	for _, h := range hunks {
+		// An empty range starts at the line before it, e.g. "@@ -0,0 +1,2 @@".
+		if h.OldLines == 0 {
+			h.OldStart--
+		}
+		if h.NewLines == 0 {
+			h.NewStart--
+		}
+	}
+	return hunks
+}
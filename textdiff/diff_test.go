@@ -0,0 +1,157 @@
+// Copyright 2026 LINE Corporation
+//
+// LINE Corporation licenses this file to you under the Apache License,
+// version 2.0 (the "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at:
+//
+//   https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+// WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+// License for the specific language governing permissions and limitations
+// under the License.
+
+package textdiff
+
+import (
+	"fmt"
+	"math/rand"
+	"strings"
+	"testing"
+)
+
+func TestDiff(t *testing.T) {
+	var tests = []struct {
+		oldText string
+		newText string
+		want    string
+	}{
+		{"foo\n", "foo\n", ""},
+		{"foo\n", "bar\n", "--- /b.txt\n+++ /b.txt\n@@ -1,1 +1,1 @@\n-foo\n+bar\n"},
+		{"", "a\nb\n", "--- /b.txt\n+++ /b.txt\n@@ -0,0 +1,2 @@\n+a\n+b\n"},
+		{"a\nb\n", "", "--- /b.txt\n+++ /b.txt\n@@ -1,2 +0,0 @@\n-a\n-b\n"},
+		{"1\n2\n3\n4\n5\n6\n7\n8\n", "1\n2\n3\n4\nfive\n6\n7\n8\n",
+			"--- /b.txt\n+++ /b.txt\n@@ -2,7 +2,7 @@\n 2\n 3\n 4\n-5\n+five\n 6\n 7\n 8\n"},
+		// Two changes which are close enough are merged into a hunk.
+		{"1\n2\n3\n4\n5\n6\n7\n8\n", "one\n2\n3\n4\n5\n6\n7\neight\n",
+			"--- /b.txt\n+++ /b.txt\n@@ -1,8 +1,8 @@\n-1\n+one\n 2\n 3\n 4\n 5\n 6\n 7\n-8\n+eight\n"},
+		// Two changes which are far from each other are split into two hunks.
+		{"1\n2\n3\n4\n5\n6\n7\n8\n9\n10\n", "one\n2\n3\n4\n5\n6\n7\n8\n9\nten\n",
+			"--- /b.txt\n+++ /b.txt\n@@ -1,4 +1,4 @@\n-1\n+one\n 2\n 3\n 4\n@@ -7,4 +7,4 @@\n 7\n 8\n 9\n-10\n+ten\n"},
+	}
+
+	for _, test := range tests {
+		if got := Diff("/b.txt", test.oldText, test.newText); got != test.want {
+			t.Errorf("Diff(%q, %q) = %q, want %q", test.oldText, test.newText, got, test.want)
+		}
+	}
+}
+
+func TestDiff_RoundTrip(t *testing.T) {
+	oldText := strings.Repeat("a\nb\nc\nd\ne\n", 20)
+	newText := strings.Replace(strings.Replace(oldText, "c\n", "C\nC2\n", 3), "e\n", "", 5) + "z\n"
+
+	patch := Diff("/a.txt", oldText, newText)
+	got, err := Apply(oldText, patch)
+	if err != nil {
+		t.Fatalf("Apply returned an error: %v\n%s", err, patch)
+	}
+	if got != newText {
+		t.Errorf("Apply(Diff(old, new)) = %q, want %q", got, newText)
+	}
+}
+
+func TestDiff_LargeRewrite(t *testing.T) {
+	var oldLines, newLines strings.Builder
+	for i := 0; i < 5000; i++ {
+		fmt.Fprintf(&oldLines, "old %d\n", i)
+		fmt.Fprintf(&newLines, "new %d\n", i)
+	}
+	oldText, newText := oldLines.String(), newLines.String()
+
+	filePatch := DiffFile("/a.txt", oldText, newText, DefaultContextLines)
+	if len(filePatch.Hunks) != 1 || filePatch.Hunks[0].OldLines != 5000 || filePatch.Hunks[0].NewLines != 5000 {
+		t.Fatalf("DiffFile returned %d hunks, want a hunk which replaces 5000 lines", len(filePatch.Hunks))
+	}
+	got, err := Apply(oldText, filePatch.String())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got != newText {
+		t.Error("Apply(Diff(old, new)) did not return the new text")
+	}
+}
+
+func TestDiffLines_Shortest(t *testing.T) {
+	random := rand.New(rand.NewSource(1))
+	randomLines := func() []string {
+		lines := make([]string, random.Intn(30))
+		for i := range lines {
+			lines[i] = string(rune('a' + random.Intn(4)))
+		}
+		return lines
+	}
+
+	for i := 0; i < 500; i++ {
+		a, b := randomLines(), randomLines()
+		edits := diffLines(a, b)
+
+		var gotA, gotB []string
+		numChanges := 0
+		for _, e := range edits {
+			if e.kind != insertion {
+				gotA = append(gotA, e.line)
+			}
+			if e.kind != deletion {
+				gotB = append(gotB, e.line)
+			}
+			if e.kind != equal {
+				numChanges++
+			}
+		}
+		if strings.Join(gotA, ",") != strings.Join(a, ",") || strings.Join(gotB, ",") != strings.Join(b, ",") {
+			t.Fatalf("diffLines(%v, %v) = %v, which does not transform a into b", a, b, edits)
+		}
+		if want := len(a) + len(b) - 2*lcsLength(a, b); numChanges != want {
+			t.Fatalf("diffLines(%v, %v) has %d changes, want %d", a, b, numChanges, want)
+		}
+	}
+}
+
+func lcsLength(a, b []string) int {
+	lengths := make([][]int, len(a)+1)
+	for i := range lengths {
+		lengths[i] = make([]int, len(b)+1)
+	}
+	for i := len(a) - 1; i >= 0; i-- {
+		for j := len(b) - 1; j >= 0; j-- {
+			if a[i] == b[j] {
+				lengths[i][j] = lengths[i+1][j+1] + 1
+			} else if lengths[i+1][j] > lengths[i][j+1] {
+				lengths[i][j] = lengths[i+1][j]
+			} else {
+				lengths[i][j] = lengths[i][j+1]
+			}
+		}
+	}
+	return lengths[0][0]
+}
+
+func TestFilePatch_OldLine(t *testing.T) {
+	oldText := "1\n2\n3\n4\n5\n6\n7\n8\n9\n10\n11\n12\n"
+	newText := "0\n1\n2\n3\n4\n5\nsix\n7\n8\n9\n10\n12\n13\n"
+	patch := DiffFile("/a.txt", oldText, newText, 1)
+
+	// 0 means the line was inserted.
+	want := []int{0, 1, 2, 3, 4, 5, 0, 7, 8, 9, 10, 12, 0}
+	for i, w := range want {
+		got, ok := patch.OldLine(i + 1)
+		if !ok {
+			got = 0
+		}
+		if got != w {
+			t.Errorf("OldLine(%d) = %d, want %d", i+1, got, w)
+		}
+	}
+}
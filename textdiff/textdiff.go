@@ -0,0 +1,198 @@
+// Copyright 2026 LINE Corporation
+//
+// LINE Corporation licenses this file to you under the Apache License,
+// version 2.0 (the "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at:
+//
+//   https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+// WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+// License for the specific language governing permissions and limitations
+// under the License.
+
+/*
+Package textdiff generates and applies unified diffs, which is the format of the APPLY_TEXT_PATCH changes
+of Central Dogma.
+
+	patch := textdiff.Diff("/a.txt", oldContent, newContent)
+	result, _, err := client.PatchText(ctx, "foo", "bar", centraldogma.Head(), commitMessage, "/a.txt", patch)
+
+Texts are compared line by line. Like Central Dogma, which stores a text file with a trailing newline,
+a unified diff does not tell whether the last line ends with a newline.
+*/
+package textdiff
+
+import (
+	"bufio"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// DefaultContextLines is the number of unchanged lines around the changes which Diff puts into a hunk.
+const DefaultContextLines = 3
+
+// Hunk represents a hunk of a unified diff.
+type Hunk struct {
+	OldStart int // 1-based line number of the first line in the old text
+	OldLines int
+	NewStart int // 1-based line number of the first line in the new text
+	NewLines int
+	// Lines are the lines of the hunk. Each line starts with ' ' (context), '-' (deletion) or '+' (insertion).
+	Lines []string
+}
+
+// FilePatch represents a unified diff of a file.
+type FilePatch struct {
+	OldName string
+	NewName string
+	Hunks   []*Hunk
+}
+
+// String returns the unified diff format of the FilePatch. It returns an empty string if there are no hunks.
+func (p *FilePatch) String() string {
+	if len(p.Hunks) == 0 {
+		return ""
+	}
+	var b strings.Builder
+	fmt.Fprintf(&b, "--- %s\n+++ %s\n", p.OldName, p.NewName)
+	for _, hunk := range p.Hunks {
+		fmt.Fprintf(&b, "@@ -%s +%s @@\n",
+			formatRange(hunk.OldStart, hunk.OldLines), formatRange(hunk.NewStart, hunk.NewLines))
+		for _, line := range hunk.Lines {
+			b.WriteString(line)
+			b.WriteString("\n")
+		}
+	}
+	return b.String()
+}
+
+// OldLine returns the line number in the old text of the specified line in the new text. It returns false
+// if the line was inserted by the patch. The line numbers are 1-based.
+func (p *FilePatch) OldLine(newLine int) (int, bool) {
+	delta := 0 // the old line number minus the new line number after the last hunk
+	for _, hunk := range p.Hunks {
+		oldNum, newNum := firstLine(hunk.OldStart, hunk.OldLines), firstLine(hunk.NewStart, hunk.NewLines)
+		if newLine < newNum {
+			return newLine + oldNum - newNum, true
+		}
+		for _, line := range hunk.Lines {
+			switch line[0] {
+			case ' ':
+				if newNum == newLine {
+					return oldNum, true
+				}
+				oldNum++
+				newNum++
+			case '-':
+				oldNum++
+			case '+':
+				if newNum == newLine {
+					return 0, false
+				}
+				newNum++
+			}
+		}
+		delta = oldNum - newNum
+	}
+	return newLine + delta, true
+}
+
+// firstLine returns the number of the first line of a range. An empty range starts at the line before it.
+func firstLine(start, lines int) int {
+	if lines == 0 {
+		return start + 1
+	}
+	return start
+}
+
+func formatRange(start, lines int) string {
+	return strconv.Itoa(start) + "," + strconv.Itoa(lines)
+}
+
+var hunkHeader = regexp.MustCompile(`^@@ -(\d+)(?:,(\d+))? \+(\d+)(?:,(\d+))? @@`)
+
+// Parse parses a unified diff of a file.
+func Parse(patch string) (*FilePatch, error) {
+	filePatch := new(FilePatch)
+	var hunk *Hunk
+	var oldLeft, newLeft int
+
+	scanner := bufio.NewScanner(strings.NewReader(patch))
+	scanner.Buffer(make([]byte, 0, 64*1024), len(patch)+1)
+	for lineNum := 1; scanner.Scan(); lineNum++ {
+		line := scanner.Text()
+		switch {
+		case hunk == nil && strings.HasPrefix(line, "--- "):
+			filePatch.OldName = strings.TrimPrefix(line, "--- ")
+		case hunk == nil && strings.HasPrefix(line, "+++ "):
+			filePatch.NewName = strings.TrimPrefix(line, "+++ ")
+		case strings.HasPrefix(line, "@@"):
+			if hunk != nil && (oldLeft != 0 || newLeft != 0) {
+				return nil, fmt.Errorf("textdiff: the hunk ending at line %d is truncated", lineNum-1)
+			}
+			m := hunkHeader.FindStringSubmatch(line)
+			if m == nil {
+				return nil, fmt.Errorf("textdiff: invalid hunk header at line %d: %q", lineNum, line)
+			}
+			hunk = &Hunk{
+				OldStart: atoi(m[1], 0), OldLines: atoi(m[2], 1),
+				NewStart: atoi(m[3], 0), NewLines: atoi(m[4], 1),
+			}
+			oldLeft, newLeft = hunk.OldLines, hunk.NewLines
+			filePatch.Hunks = append(filePatch.Hunks, hunk)
+		case hunk == nil:
+			// Ignore the preamble such as "diff --git" or "index" lines.
+		case strings.HasPrefix(line, `\`):
+			// "\ No newline at end of file"
+		case len(line) == 0 && oldLeft == 0 && newLeft == 0:
+			// A trailing empty line after the last hunk.
+		default:
+			if len(line) == 0 {
+				// Some tools strip the trailing space of an empty context line.
+				line = " "
+			}
+			switch line[0] {
+			case ' ':
+				oldLeft--
+				newLeft--
+			case '-':
+				oldLeft--
+			case '+':
+				newLeft--
+			default:
+				return nil, fmt.Errorf("textdiff: invalid line at line %d: %q", lineNum, line)
+			}
+			if oldLeft < 0 || newLeft < 0 {
+				return nil, fmt.Errorf("textdiff: the hunk at line %d has more lines than its header", lineNum)
+			}
+			hunk.Lines = append(hunk.Lines, line)
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	if hunk != nil && (oldLeft != 0 || newLeft != 0) {
+		return nil, fmt.Errorf("textdiff: the last hunk is truncated")
+	}
+	return filePatch, nil
+}
+
+func atoi(s string, defaultValue int) int {
+	if len(s) == 0 {
+		return defaultValue
+	}
+	n, _ := strconv.Atoi(s)
+	return n
+}
+
+// splitLines splits the text into lines without their line terminators.
+func splitLines(text string) []string {
+	if len(text) == 0 {
+		return nil
+	}
+	return strings.Split(strings.TrimSuffix(text, "\n"), "\n")
+}
@@ -0,0 +1,190 @@
+// Copyright 2026 LINE Corporation
+//
+// LINE Corporation licenses this file to you under the Apache License,
+// version 2.0 (the "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at:
+//
+//   https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+// WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+// License for the specific language governing permissions and limitations
+// under the License.
+
+package centraldogma
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"path"
+	"strconv"
+)
+
+type tokenService service
+
+// Token represents an application token which is used to access the Central Dogma server.
+type Token struct {
+	AppID string `json:"appId"`
+	// Secret is the secret of the token. It is returned only when the token is created, or
+	// when an administrator lists the tokens.
+	Secret       string            `json:"secret,omitempty"`
+	Admin        bool              `json:"admin"`
+	Creation     *UserAndTimestamp `json:"creation,omitempty"`
+	Deactivation *UserAndTimestamp `json:"deactivation,omitempty"`
+	Deletion     *UserAndTimestamp `json:"deletion,omitempty"`
+}
+
+// IsActive returns whether the token is neither deactivated nor deleted.
+func (t *Token) IsActive() bool {
+	return t.Deactivation == nil && t.Deletion == nil
+}
+
+// TokenRegistration represents an application token which is registered to a project.
+type TokenRegistration struct {
+	AppID    string            `json:"appId"`
+	Role     ProjectRole       `json:"role"`
+	Creation *UserAndTimestamp `json:"creation,omitempty"`
+}
+
+func (t *tokenService) create(ctx context.Context, appID string, admin bool) (*Token, int, error) {
+	u, err := url.Parse(path.Join(
+		defaultPathPrefix,
+		tokens,
+	))
+	if err != nil {
+		return nil, UnknownHttpStatusCode, err
+	}
+
+	form := url.Values{}
+	form.Set("appId", appID)
+	form.Set("isAdmin", strconv.FormatBool(admin))
+	req, err := t.client.newRequest(http.MethodPost, u, form.Encode())
+	if err != nil {
+		return nil, UnknownHttpStatusCode, err
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+	token := new(Token)
+	httpStatusCode, err := t.client.do(ctx, req, token, false)
+	if err != nil {
+		return nil, httpStatusCode, err
+	}
+	return token, httpStatusCode, nil
+}
+
+func (t *tokenService) list(ctx context.Context) ([]*Token, int, error) {
+	u, err := url.Parse(path.Join(
+		defaultPathPrefix,
+		tokens,
+	))
+	if err != nil {
+		return nil, UnknownHttpStatusCode, err
+	}
+
+	req, err := t.client.newRequest(http.MethodGet, u, nil)
+	if err != nil {
+		return nil, UnknownHttpStatusCode, err
+	}
+
+	var tokens []*Token
+	httpStatusCode, err := t.client.do(ctx, req, &tokens, false)
+	if err != nil {
+		return nil, httpStatusCode, err
+	}
+	return tokens, httpStatusCode, nil
+}
+
+func (t *tokenService) setStatus(ctx context.Context, appID, status string) (*Token, int, error) {
+	u, err := url.Parse(path.Join(
+		defaultPathPrefix,
+		tokens, appID,
+	))
+	if err != nil {
+		return nil, UnknownHttpStatusCode, err
+	}
+
+	body := []map[string]interface{}{{"op": "replace", "path": "/status", "value": status}}
+	req, err := t.client.newRequest(http.MethodPatch, u, body)
+	if err != nil {
+		return nil, UnknownHttpStatusCode, err
+	}
+
+	token := new(Token)
+	httpStatusCode, err := t.client.do(ctx, req, token, false)
+	if err != nil {
+		return nil, httpStatusCode, err
+	}
+	return token, httpStatusCode, nil
+}
+
+func (t *tokenService) deactivate(ctx context.Context, appID string) (*Token, int, error) {
+	return t.setStatus(ctx, appID, "inactive")
+}
+
+func (t *tokenService) activate(ctx context.Context, appID string) (*Token, int, error) {
+	return t.setStatus(ctx, appID, "active")
+}
+
+func (t *tokenService) delete(ctx context.Context, appID string) (int, error) {
+	u, err := url.Parse(path.Join(
+		defaultPathPrefix,
+		tokens, appID,
+	))
+	if err != nil {
+		return UnknownHttpStatusCode, err
+	}
+
+	req, err := t.client.newRequest(http.MethodDelete, u, nil)
+	if err != nil {
+		return UnknownHttpStatusCode, err
+	}
+	return t.client.do(ctx, req, nil, false)
+}
+
+func (t *tokenService) addToProject(ctx context.Context,
+	projectName, appID string, role ProjectRole) (int, error) {
+	u, err := url.Parse(path.Join(
+		defaultPathPrefix,
+		metadata, projectName,
+		tokens,
+	))
+	if err != nil {
+		return UnknownHttpStatusCode, err
+	}
+
+	body := map[string]interface{}{"id": appID, "role": role}
+	req, err := t.client.newRequest(http.MethodPost, u, body)
+	if err != nil {
+		return UnknownHttpStatusCode, err
+	}
+	return t.client.do(ctx, req, nil, false)
+}
+
+func (t *tokenService) removeFromProject(ctx context.Context, projectName, appID string) (int, error) {
+	u, err := url.Parse(path.Join(
+		defaultPathPrefix,
+		metadata, projectName,
+		tokens, appID,
+	))
+	if err != nil {
+		return UnknownHttpStatusCode, err
+	}
+
+	req, err := t.client.newRequest(http.MethodDelete, u, nil)
+	if err != nil {
+		return UnknownHttpStatusCode, err
+	}
+	return t.client.do(ctx, req, nil, false)
+}
+
+func (t *tokenService) grantRepositoryPermission(ctx context.Context,
+	projectName, repoName, appID string, permissions []Permission) (int, error) {
+	return t.client.repository.addPermission(ctx, projectName, repoName, tokens, appID, permissions)
+}
+
+func (t *tokenService) revokeRepositoryPermission(ctx context.Context,
+	projectName, repoName, appID string) (int, error) {
+	return t.client.repository.removePermission(ctx, projectName, repoName, tokens, appID)
+}
@@ -0,0 +1,160 @@
+// Copyright 2026 LINE Corporation
+//
+// LINE Corporation licenses this file to you under the Apache License,
+// version 2.0 (the "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at:
+//
+//   https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+// WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+// License for the specific language governing permissions and limitations
+// under the License.
+
+package centraldogma
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"reflect"
+	"testing"
+)
+
+func TestCreateToken(t *testing.T) {
+	c, mux, teardown := setup()
+	defer teardown()
+
+	mux.HandleFunc("/api/v1/tokens", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, http.MethodPost)
+		testHeader(t, r, "Content-Type", "application/x-www-form-urlencoded")
+		testBody(t, r, "appId=ci&isAdmin=false")
+		w.WriteHeader(http.StatusCreated)
+		fmt.Fprint(w, `{"appId":"ci", "secret":"appToken-secret", "admin":false,
+"creation":{"user":"minux@m.x", "timestamp":"2026-01-02T03:04:05Z"}}`)
+	})
+
+	token, httpStatusCode, err := c.CreateToken(context.Background(), "ci", false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	testStatusCode(t, httpStatusCode, 201)
+
+	want := &Token{AppID: "ci", Secret: "appToken-secret",
+		Creation: &UserAndTimestamp{User: "minux@m.x", Timestamp: "2026-01-02T03:04:05Z"}}
+	if !reflect.DeepEqual(token, want) {
+		t.Errorf("CreateToken returned %+v, want %+v", token, want)
+	}
+}
+
+func TestListTokens(t *testing.T) {
+	c, mux, teardown := setup()
+	defer teardown()
+
+	mux.HandleFunc("/api/v1/tokens", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, http.MethodGet)
+		fmt.Fprint(w, `[{"appId":"ci", "admin":false},
+{"appId":"admin", "admin":true, "deactivation":{"user":"minux@m.x", "timestamp":"2026-01-02T03:04:05Z"}}]`)
+	})
+
+	tokens, _, err := c.ListTokens(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(tokens) != 2 || !tokens[0].IsActive() || tokens[1].IsActive() || !tokens[1].Admin {
+		t.Errorf("ListTokens returned %+v", tokens)
+	}
+}
+
+func TestDeactivateAndActivateToken(t *testing.T) {
+	c, mux, teardown := setup()
+	defer teardown()
+
+	var status string
+	mux.HandleFunc("/api/v1/tokens/ci", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, http.MethodPatch)
+		testHeader(t, r, "Content-Type", "application/json-patch+json")
+		testBody(t, r, `[{"op":"replace","path":"/status","value":"`+status+`"}]`+"\n")
+		fmt.Fprint(w, `{"appId":"ci", "admin":false}`)
+	})
+
+	status = "inactive"
+	if _, _, err := c.DeactivateToken(context.Background(), "ci"); err != nil {
+		t.Fatal(err)
+	}
+	status = "active"
+	token, _, err := c.ActivateToken(context.Background(), "ci")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := (&Token{AppID: "ci"}); !reflect.DeepEqual(token, want) {
+		t.Errorf("ActivateToken returned %+v, want %+v", token, want)
+	}
+}
+
+func TestDeleteToken(t *testing.T) {
+	c, mux, teardown := setup()
+	defer teardown()
+
+	mux.HandleFunc("/api/v1/tokens/ci", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, http.MethodDelete)
+		w.WriteHeader(http.StatusNoContent)
+	})
+
+	httpStatusCode, err := c.DeleteToken(context.Background(), "ci")
+	if err != nil {
+		t.Fatal(err)
+	}
+	testStatusCode(t, httpStatusCode, 204)
+}
+
+func TestAddAndRemoveTokenFromProject(t *testing.T) {
+	c, mux, teardown := setup()
+	defer teardown()
+
+	mux.HandleFunc("/api/v1/metadata/foo/tokens", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, http.MethodPost)
+		testBody(t, r, `{"id":"ci","role":"MEMBER"}`+"\n")
+		fmt.Fprint(w, `{"major":2, "minor":0}`)
+	})
+	mux.HandleFunc("/api/v1/metadata/foo/tokens/ci", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, http.MethodDelete)
+		w.WriteHeader(http.StatusNoContent)
+	})
+
+	if _, err := c.AddTokenToProject(context.Background(), "foo", "ci", RoleMember); err != nil {
+		t.Fatal(err)
+	}
+	httpStatusCode, err := c.RemoveTokenFromProject(context.Background(), "foo", "ci")
+	if err != nil {
+		t.Fatal(err)
+	}
+	testStatusCode(t, httpStatusCode, 204)
+}
+
+func TestTokenRepositoryPermission(t *testing.T) {
+	c, mux, teardown := setup()
+	defer teardown()
+
+	mux.HandleFunc("/api/v1/metadata/foo/repos/bar/perm/tokens", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, http.MethodPost)
+		testBody(t, r, `{"id":"ci","permissions":["READ","WRITE"]}`+"\n")
+		fmt.Fprint(w, `{"major":2, "minor":0}`)
+	})
+	mux.HandleFunc("/api/v1/metadata/foo/repos/bar/perm/tokens/ci", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, http.MethodDelete)
+		w.WriteHeader(http.StatusNoContent)
+	})
+
+	_, err := c.GrantTokenRepositoryPermission(context.Background(), "foo", "bar", "ci",
+		[]Permission{PermissionRead, PermissionWrite})
+	if err != nil {
+		t.Fatal(err)
+	}
+	httpStatusCode, err := c.RevokeTokenRepositoryPermission(context.Background(), "foo", "bar", "ci")
+	if err != nil {
+		t.Fatal(err)
+	}
+	testStatusCode(t, httpStatusCode, 204)
+}
@@ -15,13 +15,17 @@
 package centraldogma
 
 import (
+	"errors"
 	"fmt"
 	"math"
 	"math/rand"
 	"net/url"
+	"path"
 	"strconv"
 	"strings"
 	"time"
+
+	"go.linecorp.com/centraldogma/jsonpath"
 )
 
 const (
@@ -76,9 +80,7 @@ func getFileURLValues(v *url.Values, revision string, query *Query) (err error)
 
 func setJSONPaths(v *url.Values, query *Query) (err error) {
 	if query.Type == JSONPath {
-		if !strings.HasSuffix(strings.ToLower(query.Path), "json") {
-			err = fmt.Errorf("the extension of the file should be .json (path: %v)", query.Path)
-		} else {
+		if err = validateJSONPathQuery(query); err == nil {
 			for _, jsonPath := range query.Expressions {
 				v.Add("jsonpath", jsonPath)
 			}
@@ -87,6 +89,68 @@ func setJSONPaths(v *url.Values, query *Query) (err error) {
 	return
 }
 
+func validateJSONPathQuery(query *Query) error {
+	if !strings.HasSuffix(strings.ToLower(query.Path), "json") {
+		return fmt.Errorf("the extension of the file should be .json (path: %v)", query.Path)
+	}
+	return nil
+}
+
+// localJSONPathQuery returns the Identity query which fetches the file of the JSONPath query, so that
+// the expressions are evaluated by applyJSONPaths.
+func localJSONPathQuery(query *Query) (*Query, error) {
+	if err := validateJSONPathQuery(query); err != nil {
+		return nil, err
+	}
+	return &Query{Path: query.Path, Type: Identity}, nil
+}
+
+// applyJSONPaths replaces the content of the entry with the result of the JSON path expressions of the query.
+func applyJSONPaths(entry *Entry, query *Query) error {
+	content, err := jsonpath.EvalJSON(entry.Content, query.Expressions...)
+	if err != nil {
+		return err
+	}
+	entry.Content = content
+	return nil
+}
+
+// mergeQueryURLValues returns the encoded query params of the MergeQuery. Unlike url.Values.Encode,
+// this keeps the order of the "path" and "optional_path" params because it is the order of merging.
+func mergeQueryURLValues(revision string, mergeQuery *MergeQuery) (string, error) {
+	if mergeQuery == nil || len(mergeQuery.Sources) == 0 {
+		return "", errors.New("mergeQuery should have at least one source")
+	}
+
+	var params []string
+	for _, source := range mergeQuery.Sources {
+		if !strings.HasSuffix(strings.ToLower(source.Path), ".json") {
+			return "", fmt.Errorf("the extension of the file should be .json (path: %v)", source.Path)
+		}
+		key := "path"
+		if source.Optional {
+			key = "optional_path"
+		}
+		params = append(params, key+"="+url.QueryEscape(path.Join("/", source.Path)))
+	}
+	for _, jsonPath := range mergeQuery.Expressions {
+		params = append(params, "jsonpath="+url.QueryEscape(jsonPath))
+	}
+	if len(revision) != 0 {
+		params = append(params, "revision="+url.QueryEscape(revision))
+	}
+	return strings.Join(params, "&"), nil
+}
+
+// parseTimestamp parses the timestamp of the server, which is formatted in ISO 8601.
+func parseTimestamp(timestamp string) (time.Time, error) {
+	t, err := time.Parse(time.RFC3339Nano, timestamp)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("failed to parse the timestamp %q: %v", timestamp, err)
+	}
+	return t, nil
+}
+
 func nextDelay(numAttemptsSoFar int) time.Duration {
 	var nextDelay time.Duration
 	if numAttemptsSoFar == 1 {
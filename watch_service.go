@@ -15,7 +15,9 @@
 package centraldogma
 
 import (
+	"bytes"
 	"context"
+	"errors"
 	"fmt"
 	"net/http"
 	"net/url"
@@ -51,6 +53,20 @@ func (ws *watchService) watchFile(
 		return &WatchResult{Err: ErrQueryMustBeSet}
 	}
 
+	if ws.client.evaluatesJSONPathLocally() && query.Type == JSONPath {
+		identityQuery, err := localJSONPathQuery(query)
+		if err != nil {
+			return &WatchResult{Err: err}
+		}
+		result := ws.watchFile(ctx, projectName, repoName, lastKnownRevision, identityQuery, timeout)
+		if result.Err == nil && result.HttpStatusCode != http.StatusNotModified {
+			if err := applyJSONPaths(&result.Entry, query); err != nil {
+				return &WatchResult{HttpStatusCode: result.HttpStatusCode, Err: err}
+			}
+		}
+		return result
+	}
+
 	// build relative url
 	u, err := url.Parse(path.Join(
 		defaultPathPrefix,
@@ -165,7 +181,9 @@ type Watcher struct {
 	updateListenerChans atomic.Value // []chan *WatchResult
 	listenerChansLock   int32        // spin lock
 
-	doWatchFunc func(ctx context.Context, lastKnownRevision int) *WatchResult
+	doWatchFunc WatchFunc
+	// delayOnSuccess is the delay before the next watch request after a successful one.
+	delayOnSuccess time.Duration
 
 	projectName string
 	repoName    string
@@ -174,6 +192,35 @@ type Watcher struct {
 	numAttemptsSoFar int
 }
 
+// WatchFunc waits until a change newer than the lastKnownRevision is available and returns it, like a watch
+// request does. It returns a WatchResult whose HttpStatusCode is http.StatusNotModified if there is no change
+// for a while, and an error if the ctx is done. The Watcher retries after an error unless the error is
+// ErrWatcherClosed, which closes the Watcher, e.g. when the source of the changes is closed.
+type WatchFunc func(ctx context.Context, lastKnownRevision int) *WatchResult
+
+// NewWatcher returns a started Watcher which calls the watchFunc repeatedly and notifies its listeners of
+// the results, which is useful to substitute a Watcher of a Client in tests. Unlike the Watcher of a Client,
+// it calls the watchFunc again without a delay after a successful call, so the watchFunc should block until
+// a change is available. The Watcher stops when the ctx is done or it is closed.
+func NewWatcher(ctx context.Context, projectName, repoName, pathPattern string, watchFunc WatchFunc) *Watcher {
+	w := newWatcher(ctx, projectName, repoName, pathPattern)
+	w.doWatchFunc = watchFunc
+	w.delayOnSuccess = 0
+	w.start()
+	return w
+}
+
+// WatchChannel returns the channel which receives the results notified by the Watcher and the function which
+// closes the Watcher, like Client.WatchFile and Client.WatchRepository do. It is useful to implement them with
+// a Watcher returned by NewWatcher.
+func WatchChannel(w *Watcher) (result <-chan WatchResult, closer func()) {
+	ch := make(chan WatchResult, DefaultChannelBuffer)
+	_ = w.Watch(func(value WatchResult) {
+		ch <- value
+	})
+	return ch, w.Close
+}
+
 func newWatcher(ctx context.Context, projectName, repoName, pathPattern string) *Watcher {
 	watchCTX, watchCancelFunc := context.WithCancel(ctx)
 	return &Watcher{
@@ -181,6 +228,7 @@ func newWatcher(ctx context.Context, projectName, repoName, pathPattern string)
 		initialValueCh:  make(chan *WatchResult, 1),
 		watchCTX:        watchCTX,
 		watchCancelFunc: watchCancelFunc,
+		delayOnSuccess:  delayOnSuccess,
 		projectName:     projectName,
 		repoName:        repoName,
 		pathPattern:     pathPattern,
@@ -309,9 +357,23 @@ func (ws *watchService) fileWatcherWithTimeout(
 	}
 
 	w := newWatcher(ctx, projectName, repoName, query.Path)
+	var lastContent EntryContent
 	w.doWatchFunc = func(ctx context.Context, lastKnownRevision int) *WatchResult {
-		return ws.watchFile(ctx, projectName, repoName, strconv.Itoa(lastKnownRevision),
-			query, timeout)
+		for {
+			result := ws.watchFile(ctx, projectName, repoName, strconv.Itoa(lastKnownRevision),
+				query, timeout)
+			if !ws.client.evaluatesJSONPathLocally() || query.Type != JSONPath ||
+				result.Err != nil || result.HttpStatusCode == http.StatusNotModified {
+				return result
+			}
+			// When the expressions are evaluated locally, the server notifies every change of the file.
+			// Skip the changes which do not affect the result of the expressions.
+			if lastContent == nil || !bytes.Equal(lastContent, result.Entry.Content) {
+				lastContent = result.Entry.Content
+				return result
+			}
+			lastKnownRevision = result.Revision
+		}
 	}
 	return w, nil
 }
@@ -336,6 +398,57 @@ func (ws *watchService) repoWatcherWithTimeout(
 	return w, nil
 }
 
+func (ws *watchService) mergeWatcher(
+	ctx context.Context,
+	projectName, repoName string, mergeQuery *MergeQuery,
+) (*Watcher, error) {
+	return ws.mergeWatcherWithTimeout(ctx, projectName, repoName, mergeQuery, defaultWatchTimeout)
+}
+
+func (ws *watchService) mergeWatcherWithTimeout(
+	ctx context.Context,
+	projectName, repoName string, mergeQuery *MergeQuery,
+	timeout time.Duration,
+) (*Watcher, error) {
+	// validate the query before watching
+	if _, err := mergeQueryURLValues("", mergeQuery); err != nil {
+		return nil, err
+	}
+
+	paths := make([]string, len(mergeQuery.Sources))
+	for i, source := range mergeQuery.Sources {
+		paths[i] = path.Join("/", source.Path)
+	}
+	pathPattern := strings.Join(paths, ",")
+
+	w := newWatcher(ctx, projectName, repoName, pathPattern)
+	w.doWatchFunc = func(ctx context.Context, lastKnownRevision int) *WatchResult {
+		// The server cannot watch the merged result, so merge the files again whenever any of them changes.
+		watchResult := ws.watchRepo(ctx, projectName, repoName, strconv.Itoa(lastKnownRevision),
+			pathPattern, timeout)
+		if watchResult.Err != nil || watchResult.HttpStatusCode == http.StatusNotModified {
+			return watchResult
+		}
+
+		mergedEntry, httpStatusCode, err := ws.client.content.mergeFiles(ctx, projectName, repoName,
+			strconv.Itoa(watchResult.Revision), mergeQuery)
+		if err != nil {
+			return &WatchResult{HttpStatusCode: httpStatusCode, Err: err}
+		}
+		return &WatchResult{
+			Revision: mergedEntry.Revision,
+			Entry: Entry{
+				Path:     strings.Join(mergedEntry.Paths, ","),
+				Type:     mergedEntry.Type,
+				Content:  mergedEntry.Content,
+				Revision: mergedEntry.Revision,
+			},
+			HttpStatusCode: httpStatusCode,
+		}
+	}
+	return w, nil
+}
+
 func (w *Watcher) start() {
 	if atomic.CompareAndSwapInt32(&w.state, initial, started) {
 		go w.scheduleWatch()
@@ -389,6 +502,11 @@ func (w *Watcher) doWatch() {
 			// Cancelled by close()
 			return
 		}
+		if errors.Is(watchResult.Err, ErrWatcherClosed) {
+			// The source of the changes is closed, so retrying never succeeds.
+			w.Close()
+			return
+		}
 
 		log.Debug(watchResult.Err)
 
@@ -425,7 +543,7 @@ func (w *Watcher) delay() {
 	var delay time.Duration
 
 	if w.numAttemptsSoFar == 0 {
-		delay = delayOnSuccess
+		delay = w.delayOnSuccess
 	} else {
 		delay = nextDelay(w.numAttemptsSoFar)
 	}
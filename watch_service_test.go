@@ -268,6 +268,53 @@ func TestWatcher_started_AwaitInitialValue(t *testing.T) {
 	<-done
 }
 
+func TestFileWatcher_LocalJSONPath(t *testing.T) {
+	c, mux, teardown := setup()
+	defer teardown()
+	c.SetLocalJSONPathEvaluation(true)
+
+	// Only the revision 4 changes the value of "a".
+	contents := map[string]string{"1": `{"a":1,"b":1}`, "2": `{"a":1,"b":2}`, "3": `{"a":2,"b":2}`}
+	mux.HandleFunc("/api/v1/projects/foo/repos/bar/contents/a.json",
+		func(w http.ResponseWriter, r *http.Request) {
+			testURLQuery(t, r, "jsonpath", "")
+			lastKnownRevision := r.Header.Get("if-none-match")
+			content, ok := contents[lastKnownRevision]
+			if !ok {
+				time.Sleep(100 * time.Millisecond)
+				w.WriteHeader(http.StatusNotModified)
+				return
+			}
+			revision, _ := strconv.Atoi(lastKnownRevision)
+			fmt.Fprintf(w, `{"revision":%d, "entry":{"path":"/a.json", "type":"JSON", "content":%s}}`,
+				revision+1, content)
+		})
+
+	query := &Query{Path: "/a.json", Type: JSONPath, Expressions: []string{"$.a"}}
+	fw, _ := c.FileWatcher("foo", "bar", query)
+	defer fw.Close()
+
+	myCh := make(chan WatchResult, 128)
+	_ = fw.Watch(func(result WatchResult) { myCh <- result })
+	// Setting the evaluation while the watcher is running does not race with it.
+	c.SetLocalJSONPathEvaluation(true)
+
+	for _, want := range []struct {
+		revision int
+		content  string
+	}{{2, "1"}, {4, "2"}} {
+		select {
+		case result := <-myCh:
+			if result.Revision != want.revision || string(result.Entry.Content) != want.content {
+				t.Errorf("watch returned: %v %s, want %v %s", result.Revision, result.Entry.Content,
+					want.revision, want.content)
+			}
+		case <-time.After(3 * time.Second):
+			t.Fatal("failed to watch")
+		}
+	}
+}
+
 func TestRepoWatcher(t *testing.T) {
 	c, mux, teardown := setup()
 	defer teardown()
@@ -343,3 +390,73 @@ func TestRepoWatcherInvalidPathPattern(t *testing.T) {
 		close(myCh)
 	}
 }
+
+func TestMergeWatcher(t *testing.T) {
+	c, mux, teardown := setup()
+	defer teardown()
+
+	mux.HandleFunc("/api/v1/projects/foo/repos/bar/contents/a.json,/b.json",
+		func(w http.ResponseWriter, r *http.Request) {
+			testMethod(t, r, http.MethodGet)
+			if r.Header.Get("if-none-match") == "3" {
+				time.Sleep(100 * time.Millisecond)
+				w.WriteHeader(http.StatusNotModified)
+				return
+			}
+			fmt.Fprint(w, `{"revision":3}`)
+		})
+	mux.HandleFunc("/api/v1/projects/foo/repos/bar/merge",
+		func(w http.ResponseWriter, r *http.Request) {
+			testMethod(t, r, http.MethodGet)
+			testURLQuery(t, r, "revision", "3")
+			fmt.Fprint(w, `{"revision":3, "type":"JSON", "content":{"a":"b"}, "paths":["/a.json","/b.json"]}`)
+		})
+
+	mergeQuery := &MergeQuery{Sources: []*MergeSource{{Path: "/a.json"}, {Path: "/b.json", Optional: true}}}
+	mw, err := c.MergeWatcher("foo", "bar", mergeQuery)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer mw.Close()
+
+	result := mw.AwaitInitialValueWith(3 * time.Second)
+	if result.Err != nil {
+		t.Fatal(result.Err)
+	}
+	want := Entry{Path: "/a.json,/b.json", Type: JSON, Content: EntryContent(`{"a":"b"}`), Revision: 3}
+	if !reflect.DeepEqual(result.Entry, want) {
+		t.Errorf("MergeWatcher returned %+v, want %+v", result.Entry, want)
+	}
+
+	if _, err := c.MergeWatcher("foo", "bar", &MergeQuery{}); err == nil {
+		t.Error("MergeWatcher without sources should fail")
+	}
+}
+
+func TestNewWatcher(t *testing.T) {
+	revisions := make(chan int, 2)
+	w := NewWatcher(context.Background(), "foo", "bar", "/**",
+		func(ctx context.Context, lastKnownRevision int) *WatchResult {
+			revisions <- lastKnownRevision
+			if lastKnownRevision >= 3 {
+				<-ctx.Done()
+				return &WatchResult{Err: ctx.Err()}
+			}
+			return &WatchResult{Revision: lastKnownRevision + 2, HttpStatusCode: http.StatusOK}
+		})
+	defer w.Close()
+
+	if result := w.AwaitInitialValueWith(time.Second); result.Err != nil || result.Revision != 3 {
+		t.Fatalf("AwaitInitialValue() = %+v, want the revision 3", result)
+	}
+	for _, want := range []int{1, 3} {
+		select {
+		case got := <-revisions:
+			if got != want {
+				t.Errorf("lastKnownRevision = %d, want %d", got, want)
+			}
+		case <-time.After(time.Second):
+			t.Fatalf("timed out waiting for the watch with the revision %d", want)
+		}
+	}
+}